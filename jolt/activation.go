@@ -0,0 +1,59 @@
+package jolt
+
+// #include "wrapper/activation.h"
+import "C"
+
+// bodyActivationBatch is the number of activation events taken from C at once
+const bodyActivationBatch = 64
+
+// SetBodyActivationListener sets callbacks for bodies waking up (onActivated) and going to
+// sleep (onDeactivated), e.g. to switch AI or rendering LOD without polling IsActive.
+// Either callback may be nil; passing nil for both removes the listener.
+//
+// Jolt reports the changes from its worker threads, so they are queued and the callbacks run
+// on the goroutine calling Update, after each simulation step. They may use the BodyInterface.
+// Bodies woken up by ActivateBody are reported after the next step. The *BodyID passed to
+// the callbacks is only valid during the callback (don't destroy or keep it).
+//
+// Example:
+//
+//	ps.SetBodyActivationListener(
+//	    func(bodyID *jolt.BodyID) { awake[bodyID.GetIndexAndSequenceNumber()] = true },
+//	    func(bodyID *jolt.BodyID) { delete(awake, bodyID.GetIndexAndSequenceNumber()) },
+//	)
+func (ps *PhysicsSystem) SetBodyActivationListener(onActivated, onDeactivated func(bodyID *BodyID)) {
+	ps.onBodyActivated = onActivated
+	ps.onBodyDeactivated = onDeactivated
+
+	enabled := C.int(0)
+	if onActivated != nil || onDeactivated != nil {
+		enabled = C.int(1)
+	}
+	C.JoltPhysicsSystemSetBodyActivationListener(ps.handle, enabled)
+}
+
+// dispatchBodyActivations calls the activation callbacks for the events queued during the last step
+func (ps *PhysicsSystem) dispatchBodyActivations() {
+	if ps.onBodyActivated == nil && ps.onBodyDeactivated == nil {
+		return
+	}
+
+	var events [bodyActivationBatch]C.JoltBodyActivationEvent
+	for {
+		numEvents := int(C.JoltPhysicsSystemTakeBodyActivationEvents(ps.handle, &events[0], C.int(len(events))))
+		for i := 0; i < numEvents; i++ {
+			bodyID := &BodyID{handle: events[i].bodyID}
+			if events[i].activated != 0 {
+				if ps.onBodyActivated != nil {
+					ps.onBodyActivated(bodyID)
+				}
+			} else if ps.onBodyDeactivated != nil {
+				ps.onBodyDeactivated(bodyID)
+			}
+			bodyID.Destroy()
+		}
+		if numEvents < len(events) {
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package jolt
+
+import "testing"
+
+func TestBodyActivationListener(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	activated := map[uint32]int{}
+	deactivated := map[uint32]int{}
+	ps.SetBodyActivationListener(
+		func(bodyID *BodyID) { activated[bodyID.GetIndexAndSequenceNumber()]++ },
+		func(bodyID *BodyID) { deactivated[bodyID.GetIndexAndSequenceNumber()]++ },
+	)
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	boxID := bi.CreateDynamicBody(box, Vec3{X: 0, Y: 2, Z: 0})
+	defer boxID.Destroy()
+	key := boxID.GetIndexAndSequenceNumber()
+
+	// Land and settle until the box falls asleep
+	for i := 0; i < 300; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	if bi.IsActive(boxID) {
+		t.Fatal("Box is still active after settling, expected it to sleep")
+	}
+	if activated[key] != 1 {
+		t.Errorf("Activated callback fired %d times for the box, expected 1", activated[key])
+	}
+	if deactivated[key] != 1 {
+		t.Errorf("Deactivated callback fired %d times for the box, expected 1", deactivated[key])
+	}
+	if activated[floorID.GetIndexAndSequenceNumber()] != 0 {
+		t.Error("Activated callback fired for the static floor")
+	}
+
+	// Removing the listener stops the callbacks
+	ps.SetBodyActivationListener(nil, nil)
+	bi.ActivateBody(boxID)
+	ps.Update(1.0 / 60.0)
+	if activated[key] != 1 {
+		t.Errorf("Activated callback fired %d times after removing the listener, expected 1", activated[key])
+	}
+}
@@ -2,6 +2,12 @@ package jolt
 
 // #include "wrapper/body.h"
 import "C"
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
 
 // MotionType determines how a body responds to forces
 type MotionType int
@@ -15,28 +21,81 @@ const (
 // BodyInterface provides methods to create and manipulate physics bodies
 type BodyInterface struct {
 	handle C.JoltBodyInterface
+	ps     *PhysicsSystem
 }
 
 // GetBodyInterface returns the interface for creating/manipulating bodies
 func (ps *PhysicsSystem) GetBodyInterface() *BodyInterface {
 	handle := C.JoltPhysicsSystemGetBodyInterface(ps.handle)
-	return &BodyInterface{handle: handle}
+	return &BodyInterface{handle: handle, ps: ps}
 }
 
 // BodyID uniquely identifies a physics body
 type BodyID struct {
 	handle C.JoltBodyID
+	freed  atomic.Bool // Set once the ID is freed, so Destroy and the finalizer only free it once
 }
 
-// Destroy frees the body ID
+// Destroy frees the body ID (safe to call on a nil BodyID)
 func (b *BodyID) Destroy() {
+	if b == nil {
+		return
+	}
+	b.free()
+}
+
+// free frees the ID, returns false if it was already freed
+func (b *BodyID) free() bool {
+	if !b.freed.CompareAndSwap(false, true) {
+		return false
+	}
+	C.JoltDestroyBodyID(b.handle)
+	return true
+}
+
+// newBodyIDStorage allocates a body ID for C code to copy IDs into and hands out as borrowed.
+// It is marked freed so Destroy on it is a no-op; the owner frees it with freeStorage.
+func newBodyIDStorage() *BodyID {
+	b := &BodyID{handle: C.JoltCreateBodyID()}
+	b.freed.Store(true)
+	return b
+}
+
+// freeStorage frees a body ID allocated by newBodyIDStorage
+func (b *BodyID) freeStorage() {
 	C.JoltDestroyBodyID(b.handle)
 }
 
+// GetIndexAndSequenceNumber returns the value of the body ID, which uniquely identifies a body
+// within its physics system. Use it to compare body IDs or as a map key, since different
+// *BodyID values can refer to the same body (e.g. IDs passed to contact callbacks).
+func (b *BodyID) GetIndexAndSequenceNumber() uint32 {
+	value := C.JoltBodyIDGetIndexAndSequenceNumber(b.handle)
+	runtime.KeepAlive(b)
+	return uint32(value)
+}
+
+// Handle returns the raw C handle (a pointer to a JPH::BodyID) for passing to your own cgo code.
+//
+// This is an unsafe escape hatch: the pointer is owned by this BodyID and becomes invalid after Destroy.
+// The layout behind it is an implementation detail that may change between releases.
+func (b *BodyID) Handle() unsafe.Pointer {
+	return unsafe.Pointer(b.handle)
+}
+
+// BodyIDFromHandle wraps a raw C handle (a pointer to a JPH::BodyID) obtained from Handle or your own cgo code.
+//
+// This is an unsafe escape hatch: the handle is not validated, and Destroy on the result frees it,
+// so destroy only one of the BodyIDs sharing a handle.
+func BodyIDFromHandle(handle unsafe.Pointer) *BodyID {
+	return &BodyID{handle: C.JoltBodyID(handle)}
+}
+
 // GetPosition returns the current position of a body
 func (bi *BodyInterface) GetPosition(bodyID *BodyID) Vec3 {
 	var x, y, z C.float
 	C.JoltGetBodyPosition(bi.handle, bodyID.handle, &x, &y, &z)
+	runtime.KeepAlive(bodyID)
 	return Vec3{
 		X: float32(x),
 		Y: float32(y),
@@ -44,6 +103,410 @@ func (bi *BodyInterface) GetPosition(bodyID *BodyID) Vec3 {
 	}
 }
 
+// SetRotation updates the rotation of a body
+// Note: This does not wake up a sleeping body
+func (bi *BodyInterface) SetRotation(bodyID *BodyID, rotation Quat) {
+	C.JoltSetBodyRotation(
+		bi.handle,
+		bodyID.handle,
+		C.float(rotation.X),
+		C.float(rotation.Y),
+		C.float(rotation.Z),
+		C.float(rotation.W),
+		C.int(0),
+	)
+	runtime.KeepAlive(bodyID)
+}
+
+// MoveKinematic sets the velocities of a kinematic body so it reaches targetPosition and
+// targetRotation after deltaTime seconds, and activates it. Unlike SetPosition, the body
+// pushes dynamic bodies it moves into and carries bodies standing on it (e.g. elevators).
+// Pass the deltaTime of the next Update. Returns an error for static bodies, which can't move.
+//
+// Example:
+//
+//	if err := bi.MoveKinematic(elevator, floorPos.Add(jolt.Vec3{Y: 0.05}), jolt.QuatIdentity(), 1.0/60.0); err != nil {
+//	    log.Printf("elevator: %v", err)
+//	}
+//	ps.Update(1.0 / 60.0)
+func (bi *BodyInterface) MoveKinematic(bodyID *BodyID, targetPosition Vec3, targetRotation Quat, deltaTime float32) error {
+	result := C.JoltMoveBodyKinematic(
+		bi.handle,
+		bodyID.handle,
+		C.float(targetPosition.X),
+		C.float(targetPosition.Y),
+		C.float(targetPosition.Z),
+		C.float(targetRotation.X),
+		C.float(targetRotation.Y),
+		C.float(targetRotation.Z),
+		C.float(targetRotation.W),
+		C.float(deltaTime),
+	)
+	runtime.KeepAlive(bodyID)
+	if result == 0 {
+		return fmt.Errorf("cannot move static body kinematically")
+	}
+	return nil
+}
+
+// GetRotation returns the rotation of a body
+func (bi *BodyInterface) GetRotation(bodyID *BodyID) Quat {
+	var x, y, z, w C.float
+	C.JoltGetBodyRotation(bi.handle, bodyID.handle, &x, &y, &z, &w)
+	runtime.KeepAlive(bodyID)
+	return Quat{
+		X: float32(x),
+		Y: float32(y),
+		Z: float32(z),
+		W: float32(w),
+	}
+}
+
+// GetWorldTransform returns the position and rotation of a body as a single column-major
+// 4x4 matrix, ready to be used as a model matrix in OpenGL or Vulkan
+//
+// Example:
+//
+//	model := bi.GetWorldTransform(crate)
+//	gl.UniformMatrix4fv(modelLoc, 1, false, &model[0])
+func (bi *BodyInterface) GetWorldTransform(bodyID *BodyID) Mat4 {
+	var cMatrix [16]C.float
+	C.JoltGetBodyWorldTransform(bi.handle, bodyID.handle, &cMatrix[0])
+	runtime.KeepAlive(bodyID)
+
+	var m Mat4
+	for i, v := range cMatrix {
+		m[i] = float32(v)
+	}
+	return m
+}
+
+// GetTransforms returns the world transforms of many bodies in one call, in the same order as bodyIDs.
+// This is much cheaper than calling GetWorldTransform per body when syncing a renderer with thousands of bodies.
+//
+// Example:
+//
+//	models := bi.GetTransforms(crates)
+//	for i, model := range models {
+//	    instanceData[i] = model
+//	}
+func (bi *BodyInterface) GetTransforms(bodyIDs []*BodyID) []Mat4 {
+	if len(bodyIDs) == 0 {
+		return []Mat4{}
+	}
+
+	handles := make([]C.JoltBodyID, len(bodyIDs))
+	for i, bodyID := range bodyIDs {
+		handles[i] = bodyID.handle
+	}
+
+	cMatrices := make([]C.float, len(bodyIDs)*16)
+	C.JoltGetBodyWorldTransforms(bi.handle, &handles[0], C.int(len(bodyIDs)), &cMatrices[0])
+	runtime.KeepAlive(bodyIDs)
+
+	transforms := make([]Mat4, len(bodyIDs))
+	for i := range transforms {
+		for j := range transforms[i] {
+			transforms[i][j] = float32(cMatrices[i*16+j])
+		}
+	}
+	return transforms
+}
+
+// GetCombinedWorldBounds returns the world space bounding box enclosing all given bodies in one call,
+// e.g. to frame a group of bodies with the camera. Bodies that don't exist are skipped.
+// Returns zero vectors if bodyIDs is empty or none of the bodies exist.
+//
+// Example:
+//
+//	min, max := bi.GetCombinedWorldBounds(squad)
+//	camera.LookAt(min.Add(max).Mul(0.5))
+func (bi *BodyInterface) GetCombinedWorldBounds(bodyIDs []*BodyID) (min, max Vec3) {
+	if len(bodyIDs) == 0 {
+		return Vec3{}, Vec3{}
+	}
+
+	handles := make([]C.JoltBodyID, len(bodyIDs))
+	for i, bodyID := range bodyIDs {
+		handles[i] = bodyID.handle
+	}
+
+	var minX, minY, minZ, maxX, maxY, maxZ C.float
+	ok := C.JoltGetBodiesCombinedWorldBounds(bi.handle, &handles[0], C.int(len(bodyIDs)),
+		&minX, &minY, &minZ, &maxX, &maxY, &maxZ)
+	runtime.KeepAlive(bodyIDs)
+	if ok == 0 {
+		return Vec3{}, Vec3{}
+	}
+	return Vec3{X: float32(minX), Y: float32(minY), Z: float32(minZ)},
+		Vec3{X: float32(maxX), Y: float32(maxY), Z: float32(maxZ)}
+}
+
+// LocalToWorld converts a point in the local space of a body to world space
+func (bi *BodyInterface) LocalToWorld(bodyID *BodyID, localPoint Vec3) Vec3 {
+	return bi.GetPosition(bodyID).Add(bi.GetRotation(bodyID).RotateVec3(localPoint))
+}
+
+// GetCenterOfMassPosition returns the world space center of mass of a body
+// This differs from GetPosition (the body origin) when the shape's center of mass
+// is not at its origin, e.g. for shapes created with CreateCapsuleAtFeet
+func (bi *BodyInterface) GetCenterOfMassPosition(bodyID *BodyID) Vec3 {
+	var x, y, z C.float
+	C.JoltGetBodyCenterOfMassPosition(bi.handle, bodyID.handle, &x, &y, &z)
+	runtime.KeepAlive(bodyID)
+	return Vec3{
+		X: float32(x),
+		Y: float32(y),
+		Z: float32(z),
+	}
+}
+
+// SetLinearVelocity sets the linear velocity of a body (wakes it up if the velocity is non-zero)
+func (bi *BodyInterface) SetLinearVelocity(bodyID *BodyID, velocity Vec3) {
+	C.JoltSetBodyLinearVelocity(bi.handle, bodyID.handle, C.float(velocity.X), C.float(velocity.Y), C.float(velocity.Z))
+	runtime.KeepAlive(bodyID)
+}
+
+// GetLinearVelocity returns the linear velocity of a body
+func (bi *BodyInterface) GetLinearVelocity(bodyID *BodyID) Vec3 {
+	var x, y, z C.float
+	C.JoltGetBodyLinearVelocity(bi.handle, bodyID.handle, &x, &y, &z)
+	runtime.KeepAlive(bodyID)
+	return Vec3{
+		X: float32(x),
+		Y: float32(y),
+		Z: float32(z),
+	}
+}
+
+// GetAngularVelocity returns the angular velocity of a body in radians/s around each world axis
+func (bi *BodyInterface) GetAngularVelocity(bodyID *BodyID) Vec3 {
+	var x, y, z C.float
+	C.JoltGetBodyAngularVelocity(bi.handle, bodyID.handle, &x, &y, &z)
+	runtime.KeepAlive(bodyID)
+	return Vec3{
+		X: float32(x),
+		Y: float32(y),
+		Z: float32(z),
+	}
+}
+
+// AddTorque adds a torque (N·m) to a body for the next simulation step
+func (bi *BodyInterface) AddTorque(bodyID *BodyID, torque Vec3) {
+	C.JoltAddBodyTorque(bi.handle, bodyID.handle, C.float(torque.X), C.float(torque.Y), C.float(torque.Z))
+	runtime.KeepAlive(bodyID)
+}
+
+// SetInertia overrides the mass and inertia that were calculated from the body's shape.
+// Only affects dynamic bodies, and is reset when the shape is changed with updateMassProperties.
+//
+// Parameters:
+//   - mass: Mass of the body in kg
+//   - inertiaDiagonal: Inertia around the body's local X, Y and Z axes in kg·m².
+//     A lower value makes the body spin more easily around that axis, 0 prevents rotation around it.
+//
+// Example:
+//
+//	// Car chassis that resists rolling but turns easily
+//	bi.SetInertia(chassis, 1200, jolt.Vec3{X: 2000, Y: 1500, Z: 600})
+func (bi *BodyInterface) SetInertia(bodyID *BodyID, mass float32, inertiaDiagonal Vec3) {
+	C.JoltSetBodyInertia(
+		bi.ps.handle,
+		bodyID.handle,
+		C.float(mass),
+		C.float(inertiaDiagonal.X),
+		C.float(inertiaDiagonal.Y),
+		C.float(inertiaDiagonal.Z),
+	)
+	runtime.KeepAlive(bodyID)
+}
+
+// SetMaxLinearVelocity caps the speed (m/s) of a dynamic or kinematic body, e.g. so debris thrown
+// by an explosion can't tunnel through walls. Jolt clamps the velocity every step (default: 500 m/s).
+// Has no effect on static bodies. Returns an error and keeps the old limit if max is negative or NaN.
+//
+// Example:
+//
+//	if err := bi.SetMaxLinearVelocity(debris, 30); err != nil {
+//	    log.Fatal(err)
+//	}
+//	ps.ApplyRadialImpulse(grenadePos, 5, 500)
+func (bi *BodyInterface) SetMaxLinearVelocity(bodyID *BodyID, max float32) error {
+	if !(max >= 0) {
+		return fmt.Errorf("invalid max linear velocity %v, expected 0 or more", max)
+	}
+	C.JoltSetBodyMaxLinearVelocity(bi.ps.handle, bodyID.handle, C.float(max))
+	runtime.KeepAlive(bodyID)
+	return nil
+}
+
+// GetMaxLinearVelocity returns the maximum speed (m/s) of a body, 0 for static bodies
+func (bi *BodyInterface) GetMaxLinearVelocity(bodyID *BodyID) float32 {
+	max := C.JoltGetBodyMaxLinearVelocity(bi.ps.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return float32(max)
+}
+
+// SetMaxAngularVelocity caps the angular speed (rad/s) of a dynamic or kinematic body.
+// Jolt clamps the angular velocity every step (default: 0.25 * pi * 60 rad/s).
+// Has no effect on static bodies. Returns an error and keeps the old limit if max is negative or NaN.
+func (bi *BodyInterface) SetMaxAngularVelocity(bodyID *BodyID, max float32) error {
+	if !(max >= 0) {
+		return fmt.Errorf("invalid max angular velocity %v, expected 0 or more", max)
+	}
+	C.JoltSetBodyMaxAngularVelocity(bi.ps.handle, bodyID.handle, C.float(max))
+	runtime.KeepAlive(bodyID)
+	return nil
+}
+
+// GetMaxAngularVelocity returns the maximum angular speed (rad/s) of a body, 0 for static bodies
+func (bi *BodyInterface) GetMaxAngularVelocity(bodyID *BodyID) float32 {
+	max := C.JoltGetBodyMaxAngularVelocity(bi.ps.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return float32(max)
+}
+
+// GetSurfaceNormal returns the world space surface normal of a body at worldPoint, a point on
+// the part of the body identified by subShapeID. Take both from a ray cast hit to get the exact
+// face normal, also on mesh edges where the hit normal may belong to either triangle.
+// Returns the zero vector if the body doesn't exist.
+//
+// Example:
+//
+//	if hit, ok := ps.CastRay(eye, aim.Mul(100)); ok {
+//	    normal := bi.GetSurfaceNormal(hit.BodyID, hit.SubShapeID2, hit.HitPoint)
+//	    spawnDecal(hit.HitPoint, normal)
+//	    hit.BodyID.Destroy()
+//	}
+func (bi *BodyInterface) GetSurfaceNormal(bodyID *BodyID, subShapeID uint32, worldPoint Vec3) Vec3 {
+	var x, y, z C.float
+	ok := C.JoltGetBodySurfaceNormal(
+		bi.ps.handle,
+		bodyID.handle,
+		C.uint(subShapeID),
+		C.float(worldPoint.X),
+		C.float(worldPoint.Y),
+		C.float(worldPoint.Z),
+		&x, &y, &z,
+	)
+	runtime.KeepAlive(bodyID)
+	if ok == 0 {
+		return Vec3{}
+	}
+	return Vec3{X: float32(x), Y: float32(y), Z: float32(z)}
+}
+
+// MassProperties describes the mass distribution of a dynamic body
+type MassProperties struct {
+	Mass         float32 // Mass in kg
+	CenterOfMass Vec3    // Center of mass relative to the body position, in local space
+	Inertia      Mat3    // Inertia tensor around the center of mass in local space (kg·m²)
+}
+
+// GetMassProperties returns the mass, center of mass and inertia tensor of a body,
+// including overrides made with SetInertia. Returns the zero value for static and kinematic bodies.
+// Axes that can't rotate have an inertia of 0, like SetInertia takes them.
+func (bi *BodyInterface) GetMassProperties(bodyID *BodyID) MassProperties {
+	var mass C.float
+	var com [3]C.float
+	var inertia [9]C.float
+	ok := C.JoltGetBodyMassProperties(bi.ps.handle, bodyID.handle, &mass, &com[0], &inertia[0])
+	runtime.KeepAlive(bodyID)
+	if ok == 0 {
+		return MassProperties{}
+	}
+
+	props := MassProperties{
+		Mass:         float32(mass),
+		CenterOfMass: Vec3{X: float32(com[0]), Y: float32(com[1]), Z: float32(com[2])},
+	}
+	for i, v := range inertia {
+		props.Inertia[i] = float32(v)
+	}
+	return props
+}
+
+// IsActive returns true if the body is awake and being simulated, false if it is sleeping
+func (bi *BodyInterface) IsActive(bodyID *BodyID) bool {
+	active := C.JoltIsBodyActive(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return active != 0
+}
+
+// ApplyBuoyancyImpulse applies buoyancy and drag to a body in a fluid (e.g. water) for one step.
+// Call it every frame before Update for bodies that may be in the fluid to make them float.
+//
+// Parameters:
+//   - surfacePosition, surfaceNormal: Plane of the fluid surface, normal pointing out of the fluid
+//   - buoyancy: 1 = neutral, < 1 sinks, > 1 floats
+//   - linearDrag, angularDrag: Drag coefficients slowing the body down in the fluid (e.g. 0.5 and 0.01)
+//   - fluidVelocity: Velocity of the fluid (e.g. a river current)
+//   - gravity: Gravity acting on the body, usually ps.GetGravity()
+//   - deltaTime: The step that will be simulated next
+//
+// Returns true if the body is (partially) submerged and impulses were applied.
+//
+// Example:
+//
+//	up := jolt.Vec3{X: 0, Y: 1, Z: 0}
+//	bi.ApplyBuoyancyImpulse(crate, jolt.Vec3{}, up, 1.5, 0.5, 0.01, jolt.Vec3{}, ps.GetGravity(), dt)
+//	ps.Update(dt)
+func (bi *BodyInterface) ApplyBuoyancyImpulse(bodyID *BodyID, surfacePosition, surfaceNormal Vec3, buoyancy, linearDrag, angularDrag float32, fluidVelocity Vec3, gravity Vec3, deltaTime float32) bool {
+	submerged := C.JoltApplyBuoyancyImpulse(
+		bi.handle,
+		bodyID.handle,
+		C.float(surfacePosition.X), C.float(surfacePosition.Y), C.float(surfacePosition.Z),
+		C.float(surfaceNormal.X), C.float(surfaceNormal.Y), C.float(surfaceNormal.Z),
+		C.float(buoyancy), C.float(linearDrag), C.float(angularDrag),
+		C.float(fluidVelocity.X), C.float(fluidVelocity.Y), C.float(fluidVelocity.Z),
+		C.float(gravity.X), C.float(gravity.Y), C.float(gravity.Z),
+		C.float(deltaTime),
+	)
+	runtime.KeepAlive(bodyID)
+	return submerged != 0
+}
+
+// SetUserData stores an arbitrary 64-bit value on a body (e.g. an entity ID)
+func (bi *BodyInterface) SetUserData(bodyID *BodyID, userData uint64) {
+	C.JoltSetBodyUserData(bi.handle, bodyID.handle, C.ulonglong(userData))
+	runtime.KeepAlive(bodyID)
+}
+
+// GetUserData returns the value stored with SetUserData (0 if never set)
+func (bi *BodyInterface) GetUserData(bodyID *BodyID) uint64 {
+	userData := C.JoltGetBodyUserData(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return uint64(userData)
+}
+
+// SetFriction sets the friction of a body (default: 0.2). Usually between 0 (no friction) and 1.
+// The friction of a contact combines both bodies, by default as the geometric mean (see ContactSettings).
+func (bi *BodyInterface) SetFriction(bodyID *BodyID, friction float32) {
+	C.JoltSetBodyFriction(bi.handle, bodyID.handle, C.float(friction))
+	runtime.KeepAlive(bodyID)
+}
+
+// GetFriction returns the friction of a body
+func (bi *BodyInterface) GetFriction(bodyID *BodyID) float32 {
+	friction := C.JoltGetBodyFriction(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return float32(friction)
+}
+
+// SetRestitution sets the restitution (bounciness) of a body (default: 0).
+// 0 means collisions are fully inelastic, 1 means the body bounces back at the speed it hit with.
+func (bi *BodyInterface) SetRestitution(bodyID *BodyID, restitution float32) {
+	C.JoltSetBodyRestitution(bi.handle, bodyID.handle, C.float(restitution))
+	runtime.KeepAlive(bodyID)
+}
+
+// GetRestitution returns the restitution (bounciness) of a body
+func (bi *BodyInterface) GetRestitution(bodyID *BodyID) float32 {
+	restitution := C.JoltGetBodyRestitution(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return float32(restitution)
+}
+
 // CreateBody creates a body with specific motion type and sensor flag.
 //
 // Parameters:
@@ -52,6 +515,9 @@ func (bi *BodyInterface) GetPosition(bodyID *BodyID) Vec3 {
 //   - motionType: MotionTypeStatic, MotionTypeKinematic, or MotionTypeDynamic
 //   - isSensor: If true, body is detected by queries but doesn't generate contact forces
 //
+// Returns nil if shape is nil or has no handle (e.g. the result of a failed constructor),
+// or if Jolt couldn't create the body because the physics system is full.
+//
 // Examples:
 //
 //	// Create static ground
@@ -68,6 +534,10 @@ func (bi *BodyInterface) GetPosition(bodyID *BodyID) Vec3 {
 //	sensor := bi.CreateBody(capsule, jolt.Vec3{X: 0, Y: 1, Z: 0}, jolt.MotionTypeKinematic, true)
 //	bi.ActivateBody(sensor)
 func (bi *BodyInterface) CreateBody(shape *Shape, position Vec3, motionType MotionType, isSensor bool) *BodyID {
+	if shape == nil || shape.handle == nil {
+		return nil
+	}
+
 	sensor := C.int(0)
 	if isSensor {
 		sensor = C.int(1)
@@ -82,11 +552,119 @@ func (bi *BodyInterface) CreateBody(shape *Shape, position Vec3, motionType Moti
 		C.JoltMotionType(motionType),
 		sensor,
 	)
+	runtime.KeepAlive(shape)
+	if handle == nil {
+		return nil
+	}
+
+	return newBodyID(handle)
+}
+
+// CreateDynamicBody creates a solid dynamic body and activates it, so it starts moving
+// under gravity on the next Update. It is shorthand for CreateBody with MotionTypeDynamic
+// followed by ActivateBody. Returns nil if CreateBody does.
+//
+// Example:
+//
+//	ball := bi.CreateDynamicBody(jolt.CreateSphere(0.5), jolt.Vec3{X: 0, Y: 10, Z: 0})
+//	defer ball.Destroy()
+func (bi *BodyInterface) CreateDynamicBody(shape *Shape, position Vec3) *BodyID {
+	bodyID := bi.CreateBody(shape, position, MotionTypeDynamic, false)
+	if bodyID != nil {
+		bi.ActivateBody(bodyID)
+	}
+	return bodyID
+}
+
+// CreateBodiesFromShape creates one body per position, all sharing shape, and adds them to the
+// system in a single batch. This is much cheaper than calling CreateBody in a loop when spawning
+// thousands of bodies, since the broadphase is updated once instead of per body.
+// If isDynamic is true the bodies are dynamic and activated, otherwise they are static.
+// The returned body IDs are in the same order as positions; the slice is shorter than positions
+// if the system ran out of bodies. Returns nil if shape is nil or positions is empty.
+//
+// Example:
+//
+//	positions := make([]jolt.Vec3, 1000)
+//	for i := range positions {
+//	    positions[i] = jolt.Vec3{X: float32(i%10) * 2, Y: 10, Z: float32(i/10) * 2}
+//	}
+//	crates := bi.CreateBodiesFromShape(box, positions, true)
+func (bi *BodyInterface) CreateBodiesFromShape(shape *Shape, positions []Vec3, isDynamic bool) []*BodyID {
+	if shape == nil || shape.handle == nil || len(positions) == 0 {
+		return nil
+	}
+
+	cPositions := make([]C.float, len(positions)*3)
+	for i, p := range positions {
+		cPositions[i*3] = C.float(p.X)
+		cPositions[i*3+1] = C.float(p.Y)
+		cPositions[i*3+2] = C.float(p.Z)
+	}
 
-	return &BodyID{handle: handle}
+	dynamic := C.int(0)
+	if isDynamic {
+		dynamic = C.int(1)
+	}
+
+	handles := make([]C.JoltBodyID, len(positions))
+	n := int(C.JoltCreateBodiesFromShape(bi.handle, shape.handle, &cPositions[0], C.int(len(positions)), dynamic, &handles[0]))
+	runtime.KeepAlive(shape)
+
+	bodyIDs := make([]*BodyID, n)
+	for i := range bodyIDs {
+		bodyIDs[i] = newBodyID(handles[i])
+	}
+	return bodyIDs
+}
+
+// arenaWallThickness is the thickness of the walls created by CreateArena
+const arenaWallThickness = 1
+
+// CreateArena creates a static floor and four static walls enclosing a box-shaped region
+// centered on the origin, e.g. for tests and prototypes.
+//
+// Parameters:
+//   - size: Width (X) and depth (Z) of the enclosed region, and thickness (Y) of the floor below Y = 0
+//   - wallHeight: Height of the walls above the floor
+//
+// Returns the IDs of the floor and the walls (the caller destroys them).
+//
+// Example:
+//
+//	arena := ps.CreateArena(jolt.Vec3{X: 20, Y: 1, Z: 20}, 2)
+//	defer func() {
+//	    for _, bodyID := range arena {
+//	        bodyID.Destroy()
+//	    }
+//	}()
+func (ps *PhysicsSystem) CreateArena(size Vec3, wallHeight float32) []*BodyID {
+	bi := ps.GetBodyInterface()
+	halfX, halfZ := size.X/2, size.Z/2
+	const halfWall = arenaWallThickness / 2.0
+
+	// Floor extends under the walls so balls can't slip through the corners
+	floor := CreateBox(Vec3{X: halfX + arenaWallThickness, Y: size.Y / 2, Z: halfZ + arenaWallThickness})
+	defer floor.Destroy()
+
+	// X walls cover the corners, Z walls fit between them
+	wallX := CreateBox(Vec3{X: halfWall, Y: wallHeight / 2, Z: halfZ + arenaWallThickness})
+	defer wallX.Destroy()
+	wallZ := CreateBox(Vec3{X: halfX, Y: wallHeight / 2, Z: halfWall})
+	defer wallZ.Destroy()
+
+	wallY := wallHeight / 2
+	return []*BodyID{
+		bi.CreateBody(floor, Vec3{X: 0, Y: -size.Y / 2, Z: 0}, MotionTypeStatic, false),
+		bi.CreateBody(wallX, Vec3{X: -halfX - halfWall, Y: wallY, Z: 0}, MotionTypeStatic, false),
+		bi.CreateBody(wallX, Vec3{X: halfX + halfWall, Y: wallY, Z: 0}, MotionTypeStatic, false),
+		bi.CreateBody(wallZ, Vec3{X: 0, Y: wallY, Z: -halfZ - halfWall}, MotionTypeStatic, false),
+		bi.CreateBody(wallZ, Vec3{X: 0, Y: wallY, Z: halfZ + halfWall}, MotionTypeStatic, false),
+	}
 }
 
 // SetPosition updates the position of a body
+// Note: This does not wake up a sleeping body, use SetPositionAndActivate for teleports
 func (bi *BodyInterface) SetPosition(bodyID *BodyID, position Vec3) {
 	C.JoltSetBodyPosition(
 		bi.handle,
@@ -94,31 +672,231 @@ func (bi *BodyInterface) SetPosition(bodyID *BodyID, position Vec3) {
 		C.float(position.X),
 		C.float(position.Y),
 		C.float(position.Z),
+		C.int(0),
 	)
+	runtime.KeepAlive(bodyID)
+}
+
+// SetPositionAndActivate updates the position of a body and wakes it up,
+// so a sleeping dynamic body responds to gravity at its new position
+func (bi *BodyInterface) SetPositionAndActivate(bodyID *BodyID, position Vec3) {
+	C.JoltSetBodyPosition(
+		bi.handle,
+		bodyID.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		C.int(1),
+	)
+	runtime.KeepAlive(bodyID)
 }
 
 // ActivateBody makes a body participate in the simulation
 func (bi *BodyInterface) ActivateBody(bodyID *BodyID) {
 	C.JoltActivateBody(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
 }
 
 // DeactivateBody removes a body from active simulation
 func (bi *BodyInterface) DeactivateBody(bodyID *BodyID) {
 	C.JoltDeactivateBody(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
 }
 
-// SetShape changes the collision shape of a body
+// SetCollisionEnabled turns collision of a body on or off without removing it from the world.
+// A body with collision disabled keeps its velocity and is still affected by gravity, but passes
+// through everything and isn't hit by queries ("ghost mode").
+// Enabling collision moves the body back to the object layer it had when collision was disabled,
+// or to ObjectLayerNonMoving or ObjectLayerMoving depending on its motion type if that is unknown.
+//
+// Example:
+//
+//	bi.SetCollisionEnabled(player, false) // noclip on
+//	bi.SetCollisionEnabled(player, true)  // noclip off
+func (bi *BodyInterface) SetCollisionEnabled(bodyID *BodyID, enabled bool) {
+	ps := bi.ps
+	key := bodyID.GetIndexAndSequenceNumber()
+
+	ps.disabledLayersMu.Lock()
+	defer ps.disabledLayersMu.Unlock()
+
+	current := bi.GetObjectLayer(bodyID)
+	if !enabled {
+		if current != ObjectLayerDisabled {
+			if ps.disabledLayers == nil {
+				ps.disabledLayers = make(map[uint32]ObjectLayer)
+			}
+			ps.disabledLayers[key] = current
+			C.JoltSetBodyCollisionEnabled(bi.handle, bodyID.handle, 0)
+			runtime.KeepAlive(bodyID)
+		}
+		return
+	}
+
+	previous, ok := ps.disabledLayers[key]
+	delete(ps.disabledLayers, key)
+	if current != ObjectLayerDisabled {
+		return // Already enabled, e.g. moved to another layer with SetObjectLayer
+	}
+	if ok {
+		C.JoltSetBodyObjectLayer(bi.handle, bodyID.handle, C.int(previous))
+		runtime.KeepAlive(bodyID)
+	} else {
+		C.JoltSetBodyCollisionEnabled(bi.handle, bodyID.handle, 1)
+		runtime.KeepAlive(bodyID)
+	}
+}
+
+// IsCollisionEnabled returns false if collision was disabled with SetCollisionEnabled
+func (bi *BodyInterface) IsCollisionEnabled(bodyID *BodyID) bool {
+	enabled := C.JoltIsBodyCollisionEnabled(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return enabled != 0
+}
+
+// SetObjectLayer moves a body to another object layer, changing what it collides with.
+// CreateBody puts static bodies in ObjectLayerNonMoving and other bodies in ObjectLayerMoving.
+//
+// Example:
+//
+//	// Waypoint that raycasts can find but nothing bumps into
+//	marker := bi.CreateBody(sphere, waypoint, jolt.MotionTypeStatic, false)
+//	bi.SetObjectLayer(marker, jolt.ObjectLayerQueryOnly)
+//
+// Returns an error if layer is not one of the ObjectLayer constants.
+func (bi *BodyInterface) SetObjectLayer(bodyID *BodyID, layer ObjectLayer) error {
+	if layer >= numObjectLayers {
+		return fmt.Errorf("invalid object layer %d", layer)
+	}
+	ok := C.JoltSetBodyObjectLayer(bi.handle, bodyID.handle, C.int(layer))
+	runtime.KeepAlive(bodyID)
+	if ok == 0 {
+		return fmt.Errorf("invalid object layer %d", layer)
+	}
+	return nil
+}
+
+// GetObjectLayer returns the object layer of a body
+func (bi *BodyInterface) GetObjectLayer(bodyID *BodyID) ObjectLayer {
+	layer := C.JoltGetBodyObjectLayer(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	return ObjectLayer(layer)
+}
+
+// SetShape changes the collision shape of a body, keeping its BodyID and velocity
 //
 // Parameters:
 //   - bodyID: The body to modify
-//   - shape: The new collision shape
+//   - shape: The new collision shape (the body keeps its own reference, the caller may destroy it)
 //   - updateMassProperties: If true, recalculates mass/inertia from the new shape
+//   - activate: If true, wakes the body up so it reacts to the new shape immediately
+//
+// Example:
+//
+//	bigCrate := jolt.CreateBox(jolt.Vec3{X: 2, Y: 2, Z: 2})
+//	defer bigCrate.Destroy()
+//	bi.SetShape(crate, bigCrate, true, true)
+func (bi *BodyInterface) SetShape(bodyID *BodyID, shape *Shape, updateMassProperties, activate bool) {
+	C.JoltSetBodyShape(bi.handle, bodyID.handle, shape.handle, C.int(boolToInt(updateMassProperties)), C.int(boolToInt(activate)))
+	runtime.KeepAlive(bodyID)
+	runtime.KeepAlive(shape)
+}
+
+// GetShape returns the current collision shape of a body, or nil if the body doesn't exist.
+// The caller must destroy the returned shape, which only releases this reference.
+func (bi *BodyInterface) GetShape(bodyID *BodyID) *Shape {
+	handle := C.JoltGetBodyShape(bi.handle, bodyID.handle)
+	runtime.KeepAlive(bodyID)
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
+}
+
+// GroupFilterTable decides which sub-groups of the same collision group collide with each other.
+// Bodies in different groups always collide. Use it to keep parts of one object
+// (e.g. a vehicle and its own wheels) from colliding while they still hit everything else.
+type GroupFilterTable struct {
+	handle       C.JoltGroupFilterTable
+	numSubGroups uint32
+}
+
+// NewGroupFilterTable creates a table for numSubGroups sub-groups, all colliding with each other
+func NewGroupFilterTable(numSubGroups uint32) *GroupFilterTable {
+	handle := C.JoltCreateGroupFilterTable(C.uint(numSubGroups))
+	return &GroupFilterTable{handle: handle, numSubGroups: numSubGroups}
+}
+
+// Destroy frees the table (decrements ref count, bodies using it keep it alive)
+func (t *GroupFilterTable) Destroy() {
+	C.JoltDestroyGroupFilterTable(t.handle)
+}
+
+// DisableCollision stops two different sub-groups of the same group from colliding.
+// Returns an error if a sub-group is out of range or both are the same sub-group.
+func (t *GroupFilterTable) DisableCollision(subGroupA, subGroupB uint32) error {
+	if err := t.checkSubGroups(subGroupA, subGroupB); err != nil {
+		return err
+	}
+	C.JoltGroupFilterTableDisableCollision(t.handle, C.uint(subGroupA), C.uint(subGroupB))
+	return nil
+}
+
+// EnableCollision lets two different sub-groups of the same group collide again.
+// Returns an error if a sub-group is out of range or both are the same sub-group.
+func (t *GroupFilterTable) EnableCollision(subGroupA, subGroupB uint32) error {
+	if err := t.checkSubGroups(subGroupA, subGroupB); err != nil {
+		return err
+	}
+	C.JoltGroupFilterTableEnableCollision(t.handle, C.uint(subGroupA), C.uint(subGroupB))
+	return nil
+}
+
+// IsCollisionEnabled returns true if two sub-groups of the same group collide.
+// A sub-group always collides with itself, out of range sub-groups return false.
+func (t *GroupFilterTable) IsCollisionEnabled(subGroupA, subGroupB uint32) bool {
+	if subGroupA >= t.numSubGroups || subGroupB >= t.numSubGroups {
+		return false
+	}
+	if subGroupA == subGroupB {
+		return true
+	}
+	return C.JoltGroupFilterTableIsCollisionEnabled(t.handle, C.uint(subGroupA), C.uint(subGroupB)) != 0
+}
+
+// checkSubGroups returns an error unless a and b are different sub-groups of the table,
+// Jolt asserts (or corrupts another pair's bit) otherwise
+func (t *GroupFilterTable) checkSubGroups(a, b uint32) error {
+	if a >= t.numSubGroups || b >= t.numSubGroups {
+		return fmt.Errorf("invalid sub-group pair (%d, %d), expected 0 to %d", a, b, int64(t.numSubGroups)-1)
+	}
+	if a == b {
+		return fmt.Errorf("sub-group %d always collides with itself", a)
+	}
+	return nil
+}
+
+// SetCollisionGroup assigns a body to a collision group
+//
+// Parameters:
+//   - bodyID: The body to modify
+//   - filter: Table deciding which sub-groups of the same group collide (shared by the group's bodies)
+//   - groupID: Bodies with different group IDs always collide
+//   - subGroupID: Sub-group of the body within its group, must be < the table's sub-group count
+//
+// Example:
 //
-// Note: This automatically activates the body
-func (bi *BodyInterface) SetShape(bodyID *BodyID, shape *Shape, updateMassProperties bool) {
-	update := C.int(0)
-	if updateMassProperties {
-		update = C.int(1)
+//	// Vehicle chassis (sub-group 0) never collides with its wheels (sub-group 1)
+//	table := jolt.NewGroupFilterTable(2)
+//	defer table.Destroy()
+//	table.DisableCollision(0, 1)
+//	bi.SetCollisionGroup(chassis, table, vehicleID, 0)
+//	bi.SetCollisionGroup(wheel, table, vehicleID, 1)
+func (bi *BodyInterface) SetCollisionGroup(bodyID *BodyID, filter *GroupFilterTable, groupID, subGroupID uint32) {
+	var table C.JoltGroupFilterTable
+	if filter != nil {
+		table = filter.handle
 	}
-	C.JoltSetBodyShape(bi.handle, bodyID.handle, shape.handle, update)
+	C.JoltSetBodyCollisionGroup(bi.ps.handle, bodyID.handle, table, C.uint(groupID), C.uint(subGroupID))
+	runtime.KeepAlive(bodyID)
 }
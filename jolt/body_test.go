@@ -0,0 +1,871 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSetPositionAndActivateWakesBody(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	body := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer body.Destroy()
+
+	// Body was never activated, so it sleeps
+	bi.DeactivateBody(body)
+
+	bi.SetPositionAndActivate(body, Vec3{X: 0, Y: 10, Z: 0})
+	for i := 0; i < 30; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	if y := bi.GetPosition(body).Y; y >= 10 {
+		t.Errorf("Body Y = %.2f, expected the teleported body to fall", y)
+	}
+}
+
+func TestSetCollisionGroup(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	table := NewGroupFilterTable(2)
+	defer table.Destroy()
+	if err := table.DisableCollision(0, 1); err != nil {
+		t.Fatalf("DisableCollision(0, 1) = %v, expected nil", err)
+	}
+	if table.IsCollisionEnabled(0, 1) {
+		t.Fatal("Sub-groups 0 and 1 should not collide")
+	}
+
+	// Falling body and floor in the same group with excluded sub-groups
+	floorA := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorA.Destroy()
+	ghost := bi.CreateBody(box, Vec3{X: 0, Y: 2, Z: 0}, MotionTypeDynamic, false)
+	defer ghost.Destroy()
+	bi.SetCollisionGroup(floorA, table, 1, 0)
+	bi.SetCollisionGroup(ghost, table, 1, 1)
+	bi.ActivateBody(ghost)
+
+	// Regular body falling on a regular floor
+	floorB := bi.CreateBody(box, Vec3{X: 5, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorB.Destroy()
+	solid := bi.CreateBody(box, Vec3{X: 5, Y: 2, Z: 0}, MotionTypeDynamic, false)
+	defer solid.Destroy()
+	bi.ActivateBody(solid)
+
+	for i := 0; i < 90; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	if y := bi.GetPosition(ghost).Y; y > -1 {
+		t.Errorf("Grouped body Y = %.2f, expected it to pass through its floor", y)
+	}
+	if y := bi.GetPosition(solid).Y; y < 0.9 {
+		t.Errorf("Regular body Y = %.2f, expected it to rest on its floor", y)
+	}
+}
+
+func TestGroupFilterTableSubGroupRange(t *testing.T) {
+	table := NewGroupFilterTable(2)
+	defer table.Destroy()
+
+	for _, pair := range [][2]uint32{{0, 2}, {2, 0}, {5, 7}, {1, 1}} {
+		if err := table.DisableCollision(pair[0], pair[1]); err == nil {
+			t.Errorf("DisableCollision(%d, %d) = nil, expected an error", pair[0], pair[1])
+		}
+		if err := table.EnableCollision(pair[0], pair[1]); err == nil {
+			t.Errorf("EnableCollision(%d, %d) = nil, expected an error", pair[0], pair[1])
+		}
+	}
+
+	// Rejected calls leave the table untouched
+	if !table.IsCollisionEnabled(0, 1) {
+		t.Error("IsCollisionEnabled(0, 1) = false, expected true")
+	}
+	if table.IsCollisionEnabled(0, 2) {
+		t.Error("IsCollisionEnabled(0, 2) = true, expected false for an out of range sub-group")
+	}
+	if !table.IsCollisionEnabled(1, 1) {
+		t.Error("IsCollisionEnabled(1, 1) = false, expected true")
+	}
+
+	if err := table.DisableCollision(1, 0); err != nil {
+		t.Fatalf("DisableCollision(1, 0) = %v, expected nil", err)
+	}
+	if table.IsCollisionEnabled(0, 1) {
+		t.Error("IsCollisionEnabled(0, 1) = true, expected false")
+	}
+	if err := table.EnableCollision(0, 1); err != nil {
+		t.Fatalf("EnableCollision(0, 1) = %v, expected nil", err)
+	}
+	if !table.IsCollisionEnabled(1, 0) {
+		t.Error("IsCollisionEnabled(1, 0) = false, expected true")
+	}
+}
+
+func TestGetCenterOfMassPosition(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+
+	// Origin at the feet, center of mass halfway up the capsule
+	const halfHeight, radius = 0.5, 0.5
+	capsule := CreateCapsuleAtFeet(halfHeight, radius)
+	defer capsule.Destroy()
+
+	bodyID := bi.CreateBody(capsule, Vec3{X: 1, Y: 2, Z: 3}, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+
+	pos := bi.GetPosition(bodyID)
+	com := bi.GetCenterOfMassPosition(bodyID)
+
+	const tolerance = 0.001
+	offset := com.Sub(pos)
+	if math.Abs(float64(offset.X)) > tolerance ||
+		math.Abs(float64(offset.Y-(halfHeight+radius))) > tolerance ||
+		math.Abs(float64(offset.Z)) > tolerance {
+		t.Errorf("COM offset = %+v, expected {0 %v 0}", offset, halfHeight+radius)
+	}
+}
+
+func TestHandleRoundTrip(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+
+	// Round-trip the shape handle before using it to create a body
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+	sameBox := ShapeFromHandle(box.Handle())
+
+	bodyID := bi.CreateBody(sameBox, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+
+	// Round-trip the body ID handle and query through it
+	sameBody := BodyIDFromHandle(bodyID.Handle())
+	if sameBody.Handle() != bodyID.Handle() {
+		t.Fatal("Expected round-tripped handle to be unchanged")
+	}
+	bi.SetUserData(sameBody, 42)
+	if got := bi.GetUserData(bodyID); got != 42 {
+		t.Errorf("GetUserData = %d, expected 42", got)
+	}
+
+	hit, ok := bi.CastRayAgainstBody(sameBody, Vec3{X: 0, Y: 5, Z: 0}, Vec3{X: 0, Y: -10, Z: 0})
+	if !ok {
+		t.Fatal("Expected ray to hit the body through the round-tripped handle")
+	}
+	defer hit.BodyID.Destroy()
+}
+
+func TestApplyBuoyancyImpulse(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	// Light crate held 5m under the water surface at Y = 0
+	crate := bi.CreateBody(box, Vec3{X: 0, Y: -5, Z: 0}, MotionTypeDynamic, false)
+	defer crate.Destroy()
+	bi.ActivateBody(crate)
+
+	surface := Vec3{}
+	up := Vec3{X: 0, Y: 1, Z: 0}
+	const dt = 1.0 / 60.0
+	for i := 0; i < 300; i++ {
+		if !bi.ApplyBuoyancyImpulse(crate, surface, up, 2, 0.5, 0.05, Vec3{}, ps.GetGravity(), dt) && i == 0 {
+			t.Fatal("Expected the crate to be submerged")
+		}
+		ps.Update(dt)
+	}
+
+	if y := bi.GetPosition(crate).Y; y < -1 || y > 1 {
+		t.Errorf("Crate Y = %.2f, expected it to float at the surface", y)
+	}
+}
+
+func TestSetInertia(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetGravity(Vec3{})
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	// Same chassis twice, far enough apart not to touch
+	rollBody := bi.CreateBody(box, Vec3{X: -5, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer rollBody.Destroy()
+	yawBody := bi.CreateBody(box, Vec3{X: 5, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer yawBody.Destroy()
+
+	// Low inertia around X, high around Y and Z
+	inertia := Vec3{X: 0.05, Y: 1, Z: 1}
+	bi.SetInertia(rollBody, 1, inertia)
+	bi.SetInertia(yawBody, 1, inertia)
+
+	torque := float32(1)
+	bi.AddTorque(rollBody, Vec3{X: torque, Y: 0, Z: 0})
+	bi.AddTorque(yawBody, Vec3{X: 0, Y: torque, Z: 0})
+	ps.Update(1.0 / 60.0)
+
+	roll := bi.GetAngularVelocity(rollBody).X
+	yaw := bi.GetAngularVelocity(yawBody).Y
+	if roll <= 0 || yaw <= 0 {
+		t.Fatalf("Angular velocities roll = %.3f, yaw = %.3f, expected both > 0", roll, yaw)
+	}
+
+	// Angular velocity = torque / inertia * dt, so the low inertia axis spins ~20x faster
+	if ratio := roll / yaw; math.Abs(float64(ratio-20)) > 2 {
+		t.Errorf("Roll/yaw angular velocity ratio = %.2f, expected ~20", ratio)
+	}
+}
+
+func TestSetCollisionEnabled(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+
+	// Upper floor with its top at Y = 0, lower floor with its top at Y = -19.5
+	upper := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer upper.Destroy()
+	lower := bi.CreateBody(floor, Vec3{X: 0, Y: -20, Z: 0}, MotionTypeStatic, false)
+	defer lower.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	ball := bi.CreateBody(sphere, Vec3{X: 0, Y: 2, Z: 0}, MotionTypeDynamic, false)
+	defer ball.Destroy()
+	bi.ActivateBody(ball)
+
+	// Ghost mode: the ball falls through the upper floor
+	bi.SetCollisionEnabled(ball, false)
+	if bi.IsCollisionEnabled(ball) {
+		t.Fatal("IsCollisionEnabled() = true after disabling collision")
+	}
+	for i := 0; i < 120 && bi.GetPosition(ball).Y > -2; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+	if y := bi.GetPosition(ball).Y; y > -2 {
+		t.Fatalf("Ball Y = %.2f, expected it to pass through the upper floor", y)
+	}
+
+	// Re-enabling keeps the velocity and the ball lands on the lower floor
+	velocity := bi.GetLinearVelocity(ball)
+	bi.SetCollisionEnabled(ball, true)
+	if !bi.IsCollisionEnabled(ball) {
+		t.Fatal("IsCollisionEnabled() = false after enabling collision")
+	}
+	if v := bi.GetLinearVelocity(ball); v != velocity {
+		t.Errorf("Velocity = %v after enabling collision, expected %v", v, velocity)
+	}
+
+	for i := 0; i < 180; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+	if y := bi.GetPosition(ball).Y; math.Abs(float64(y+19)) > 0.1 {
+		t.Errorf("Ball Y = %.2f, expected it to rest on the lower floor (~-19)", y)
+	}
+}
+
+func TestSetCollisionEnabledRestoresLayer(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	marker := bi.CreateBody(sphere, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer marker.Destroy()
+	bi.SetObjectLayer(marker, ObjectLayerQueryOnly)
+
+	// Disabling twice must not forget the original layer
+	bi.SetCollisionEnabled(marker, false)
+	bi.SetCollisionEnabled(marker, false)
+	if got := bi.GetObjectLayer(marker); got != ObjectLayerDisabled {
+		t.Errorf("GetObjectLayer() = %d after disabling collision, expected ObjectLayerDisabled", got)
+	}
+
+	bi.SetCollisionEnabled(marker, true)
+	if got := bi.GetObjectLayer(marker); got != ObjectLayerQueryOnly {
+		t.Errorf("GetObjectLayer() = %d after enabling collision, expected ObjectLayerQueryOnly", got)
+	}
+}
+
+func TestSetShape(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	small := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer small.Destroy()
+	large := CreateBox(Vec3{X: 2, Y: 2, Z: 2})
+	defer large.Destroy()
+
+	crate := bi.CreateBody(small, Vec3{}, MotionTypeDynamic, false)
+	defer crate.Destroy()
+	bi.SetLinearVelocity(crate, Vec3{X: 0, Y: 0, Z: 1})
+
+	nearFaceX := func() float32 {
+		hit, ok := ps.CastRay(Vec3{X: -10, Y: 0, Z: 0}, Vec3{X: 20, Y: 0, Z: 0})
+		if !ok {
+			t.Fatal("Ray should have hit the crate")
+		}
+		defer hit.BodyID.Destroy()
+		return hit.HitPoint.X
+	}
+
+	if x := nearFaceX(); math.Abs(float64(x+1)) > 0.01 {
+		t.Errorf("Hit X = %.2f before SetShape, expected ~-1", x)
+	}
+
+	bi.SetShape(crate, large, true, true)
+
+	if x := nearFaceX(); math.Abs(float64(x+2)) > 0.01 {
+		t.Errorf("Hit X = %.2f after SetShape, expected ~-2", x)
+	}
+	if v := bi.GetLinearVelocity(crate); v.Z != 1 {
+		t.Errorf("Velocity = %v after SetShape, expected it to be kept", v)
+	}
+
+	shape := bi.GetShape(crate)
+	if shape == nil {
+		t.Fatal("GetShape returned nil")
+	}
+	defer shape.Destroy()
+	if _, max := shape.GetLocalBounds(); math.Abs(float64(max.X-2)) > 0.01 {
+		t.Errorf("GetShape bounds max X = %.2f, expected ~2", max.X)
+	}
+}
+
+func TestGetMassProperties(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+
+	// 1 x 2 x 3 m box with Jolt's default density of 1000 kg/m³
+	box := CreateBox(Vec3{X: 0.5, Y: 1, Z: 1.5})
+	defer box.Destroy()
+	bodyID := bi.CreateBody(box, Vec3{X: 3, Y: 4, Z: 5}, MotionTypeDynamic, false)
+	defer bodyID.Destroy()
+
+	props := bi.GetMassProperties(bodyID)
+
+	const mass = 6000.0
+	if math.Abs(float64(props.Mass-mass)) > 1 {
+		t.Errorf("Mass = %.1f, expected %.1f", props.Mass, mass)
+	}
+	if props.CenterOfMass.Length() > 1e-5 {
+		t.Errorf("Center of mass = %v, expected the box center", props.CenterOfMass)
+	}
+
+	// Solid box: I = m/12 * (sum of the squares of the other two dimensions)
+	expected := Vec3{
+		X: mass / 12 * (2*2 + 3*3),
+		Y: mass / 12 * (1*1 + 3*3),
+		Z: mass / 12 * (1*1 + 2*2),
+	}
+	diagonal := Vec3{X: props.Inertia.At(0, 0), Y: props.Inertia.At(1, 1), Z: props.Inertia.At(2, 2)}
+	if diagonal.Sub(expected).Length() > 1 {
+		t.Errorf("Inertia diagonal = %v, expected %v", diagonal, expected)
+	}
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			if row != col && math.Abs(float64(props.Inertia.At(row, col))) > 1e-2 {
+				t.Errorf("Inertia(%d, %d) = %f, expected 0 for an axis aligned box", row, col, props.Inertia.At(row, col))
+			}
+		}
+	}
+
+	// Static bodies have no mass properties
+	static := bi.CreateBody(box, Vec3{}, MotionTypeStatic, false)
+	defer static.Destroy()
+	if props := bi.GetMassProperties(static); props.Mass != 0 {
+		t.Errorf("Static body mass = %f, expected 0", props.Mass)
+	}
+}
+
+func TestGetWorldTransform(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+
+	position := Vec3{X: 1, Y: 2, Z: 3}
+	rotation := QuatFromAxisAngle(Vec3{X: 0, Y: 1, Z: 0}, math.Pi/2)
+	bodyID := bi.CreateBody(box, position, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+	bi.SetRotation(bodyID, rotation)
+
+	transform := bi.GetWorldTransform(bodyID)
+
+	// Translation is stored in the last column
+	if got := (Vec3{X: transform[12], Y: transform[13], Z: transform[14]}); got.Sub(position).Length() > 1e-5 {
+		t.Errorf("Translation = %v, expected %v", got, position)
+	}
+	if transform.At(3, 3) != 1 {
+		t.Errorf("Element (3, 3) = %f, expected 1", transform.At(3, 3))
+	}
+
+	// 90 degrees around Y maps +X to -Z
+	local := Vec3{X: 1, Y: 0, Z: 0}
+	expected := Vec3{X: 1, Y: 2, Z: 2}
+	viaMatrix := transform.MulPoint(local)
+	viaLocalToWorld := bi.LocalToWorld(bodyID, local)
+	if viaMatrix.Sub(expected).Length() > 1e-4 {
+		t.Errorf("Transformed point = %v, expected %v", viaMatrix, expected)
+	}
+	if viaMatrix.Sub(viaLocalToWorld).Length() > 1e-4 {
+		t.Errorf("Transformed point = %v, LocalToWorld = %v, expected equal", viaMatrix, viaLocalToWorld)
+	}
+}
+
+func TestGetTransforms(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	var bodyIDs []*BodyID
+	for i := 0; i < 5; i++ {
+		bodyID := bi.CreateBody(box, Vec3{X: float32(i) * 2, Y: 1, Z: -float32(i)}, MotionTypeStatic, false)
+		defer bodyID.Destroy()
+		bi.SetRotation(bodyID, QuatFromAxisAngle(Vec3{X: 0, Y: 1, Z: 0}, float32(i)*0.3))
+		bodyIDs = append(bodyIDs, bodyID)
+	}
+
+	transforms := bi.GetTransforms(bodyIDs)
+	if len(transforms) != len(bodyIDs) {
+		t.Fatalf("Got %d transforms, expected %d", len(transforms), len(bodyIDs))
+	}
+	for i, bodyID := range bodyIDs {
+		if expected := bi.GetWorldTransform(bodyID); transforms[i] != expected {
+			t.Errorf("Transform %d = %v, expected %v", i, transforms[i], expected)
+		}
+	}
+
+	if got := bi.GetTransforms(nil); len(got) != 0 {
+		t.Errorf("GetTransforms(nil) returned %d transforms, expected none", len(got))
+	}
+}
+
+func BenchmarkGetTransforms(b *testing.B) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// 5000 boxes in a grid
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	bodyIDs := make([]*BodyID, 5000)
+	for i := range bodyIDs {
+		bodyIDs[i] = bi.CreateBody(box, Vec3{X: float32(i%100) * 2, Y: 1, Z: float32(i/100) * 2}, MotionTypeStatic, false)
+	}
+	defer func() {
+		for _, bodyID := range bodyIDs {
+			bodyID.Destroy()
+		}
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bi.GetTransforms(bodyIDs)
+	}
+}
+
+func BenchmarkGetWorldTransformLoop(b *testing.B) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// 5000 boxes in a grid
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	bodyIDs := make([]*BodyID, 5000)
+	for i := range bodyIDs {
+		bodyIDs[i] = bi.CreateBody(box, Vec3{X: float32(i%100) * 2, Y: 1, Z: float32(i/100) * 2}, MotionTypeStatic, false)
+	}
+	defer func() {
+		for _, bodyID := range bodyIDs {
+			bodyID.Destroy()
+		}
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, bodyID := range bodyIDs {
+			bi.GetWorldTransform(bodyID)
+		}
+	}
+}
+
+func BenchmarkCreateBodiesFromShape(b *testing.B) {
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	positions := make([]Vec3, 10000)
+	for i := range positions {
+		positions[i] = Vec3{X: float32(i%100) * 2, Y: 1, Z: float32(i/100) * 2}
+	}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ps := NewPhysicsSystem()
+		bi := ps.GetBodyInterface()
+		b.StartTimer()
+
+		bodyIDs := bi.CreateBodiesFromShape(box, positions, false)
+
+		b.StopTimer()
+		for _, bodyID := range bodyIDs {
+			bodyID.Destroy()
+		}
+		ps.Destroy()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkCreateBodyLoop(b *testing.B) {
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	positions := make([]Vec3, 10000)
+	for i := range positions {
+		positions[i] = Vec3{X: float32(i%100) * 2, Y: 1, Z: float32(i/100) * 2}
+	}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ps := NewPhysicsSystem()
+		bi := ps.GetBodyInterface()
+		bodyIDs := make([]*BodyID, len(positions))
+		b.StartTimer()
+
+		for j, pos := range positions {
+			bodyIDs[j] = bi.CreateBody(box, pos, MotionTypeStatic, false)
+		}
+
+		b.StopTimer()
+		for _, bodyID := range bodyIDs {
+			bodyID.Destroy()
+		}
+		ps.Destroy()
+		b.StartTimer()
+	}
+}
+
+func TestCreateArena(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	arena := ps.CreateArena(Vec3{X: 10, Y: 1, Z: 6}, 2)
+	defer func() {
+		for _, bodyID := range arena {
+			bodyID.Destroy()
+		}
+	}()
+	if len(arena) != 5 {
+		t.Fatalf("CreateArena returned %d bodies, expected a floor and 4 walls", len(arena))
+	}
+
+	bi := ps.GetBodyInterface()
+	sphere := CreateSphere(0.3)
+	defer sphere.Destroy()
+	ball := bi.CreateBody(sphere, Vec3{X: 0, Y: 1, Z: 0}, MotionTypeDynamic, false)
+	defer ball.Destroy()
+	bi.SetLinearVelocity(ball, Vec3{X: 12, Y: 0, Z: 7})
+
+	for i := 0; i < 300; i++ {
+		ps.Update(1.0 / 60.0)
+
+		pos := bi.GetPosition(ball)
+		if pos.X < -5 || pos.X > 5 || pos.Z < -3 || pos.Z > 3 || pos.Y < 0 {
+			t.Fatalf("Ball escaped the arena at step %d: %v", i, pos)
+		}
+	}
+}
+
+func TestSetObjectLayerQueryOnly(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	slab := CreateBox(Vec3{X: 2, Y: 0.5, Z: 2})
+	defer slab.Destroy()
+	marker := bi.CreateBody(slab, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer marker.Destroy()
+
+	if got := bi.GetObjectLayer(marker); got != ObjectLayerNonMoving {
+		t.Errorf("Static body layer = %d, expected ObjectLayerNonMoving", got)
+	}
+	if err := bi.SetObjectLayer(marker, ObjectLayerQueryOnly); err != nil {
+		t.Fatalf("SetObjectLayer failed: %v", err)
+	}
+	if got := bi.GetObjectLayer(marker); got != ObjectLayerQueryOnly {
+		t.Errorf("Layer = %d after SetObjectLayer, expected ObjectLayerQueryOnly", got)
+	}
+
+	// Unknown layers are rejected and leave the body where it was
+	if err := bi.SetObjectLayer(marker, ObjectLayer(7)); err == nil {
+		t.Error("SetObjectLayer(7) succeeded, expected an error")
+	}
+	if got := bi.GetObjectLayer(marker); got != ObjectLayerQueryOnly {
+		t.Errorf("Layer = %d after an invalid SetObjectLayer, expected ObjectLayerQueryOnly", got)
+	}
+
+	// A box falling onto the marker passes through it
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	falling := bi.CreateBody(box, Vec3{X: 0, Y: 3, Z: 0}, MotionTypeDynamic, false)
+	defer falling.Destroy()
+	bi.ActivateBody(falling)
+
+	for i := 0; i < 90; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+	if y := bi.GetPosition(falling).Y; y > -2 {
+		t.Errorf("Falling box Y = %.2f, expected it to fall through the marker", y)
+	}
+
+	// Queries still hit the marker
+	hit, ok := ps.CastRay(Vec3{X: 1, Y: 5, Z: 1}, Vec3{X: 0, Y: -10, Z: 0})
+	if !ok {
+		t.Fatal("CastRay should hit the query-only marker")
+	}
+	defer hit.BodyID.Destroy()
+	if hit.BodyID.GetIndexAndSequenceNumber() != marker.GetIndexAndSequenceNumber() {
+		t.Error("CastRay hit a different body than the marker")
+	}
+	if math.Abs(float64(hit.HitPoint.Y-0.5)) > 0.01 {
+		t.Errorf("Hit Y = %.2f, expected the top of the marker at 0.5", hit.HitPoint.Y)
+	}
+}
+
+func TestGetCombinedWorldBounds(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	positions := []Vec3{{X: -5, Y: 0, Z: 0}, {X: 3, Y: 4, Z: 1}, {X: 0, Y: -2, Z: 7}}
+	var bodyIDs []*BodyID
+	for _, position := range positions {
+		bodyID := bi.CreateBody(box, position, MotionTypeStatic, false)
+		defer bodyID.Destroy()
+		bodyIDs = append(bodyIDs, bodyID)
+	}
+
+	min, max := bi.GetCombinedWorldBounds(bodyIDs)
+	expectedMin := Vec3{X: -5.5, Y: -2.5, Z: -0.5}
+	expectedMax := Vec3{X: 3.5, Y: 4.5, Z: 7.5}
+	if min.Sub(expectedMin).Length() > 0.1 {
+		t.Errorf("Min = %v, expected %v", min, expectedMin)
+	}
+	if max.Sub(expectedMax).Length() > 0.1 {
+		t.Errorf("Max = %v, expected %v", max, expectedMax)
+	}
+	for i, position := range positions {
+		if position.Min(min) != min || position.Max(max) != max {
+			t.Errorf("Body %d at %v is outside the bounds %v - %v", i, position, min, max)
+		}
+	}
+
+	if min, max := bi.GetCombinedWorldBounds(nil); min != (Vec3{}) || max != (Vec3{}) {
+		t.Errorf("GetCombinedWorldBounds(nil) = %v, %v, expected zero vectors", min, max)
+	}
+}
+
+func TestSetMaxLinearVelocity(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetGravity(Vec3{})
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.1, Y: 0.1, Z: 0.1})
+	defer box.Destroy()
+
+	capped := bi.CreateBody(box, Vec3{X: 1, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer capped.Destroy()
+	free := bi.CreateBody(box, Vec3{X: -1, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer free.Destroy()
+
+	if err := bi.SetMaxLinearVelocity(capped, 30); err != nil {
+		t.Fatalf("SetMaxLinearVelocity(30) = %v, expected nil", err)
+	}
+	if err := bi.SetMaxAngularVelocity(capped, 2); err != nil {
+		t.Fatalf("SetMaxAngularVelocity(2) = %v, expected nil", err)
+	}
+
+	// Negative and NaN limits are rejected and keep the old limit
+	for _, max := range []float32{-1, float32(math.NaN())} {
+		if err := bi.SetMaxLinearVelocity(capped, max); err == nil {
+			t.Errorf("SetMaxLinearVelocity(%v) = nil, expected an error", max)
+		}
+		if err := bi.SetMaxAngularVelocity(capped, max); err == nil {
+			t.Errorf("SetMaxAngularVelocity(%v) = nil, expected an error", max)
+		}
+	}
+
+	if got := bi.GetMaxLinearVelocity(capped); got != 30 {
+		t.Errorf("GetMaxLinearVelocity() = %.1f, expected 30", got)
+	}
+	if got := bi.GetMaxAngularVelocity(capped); got != 2 {
+		t.Errorf("GetMaxAngularVelocity() = %.1f, expected 2", got)
+	}
+
+	// Both boxes are blasted apart with the same impulse
+	if pushed := ps.ApplyRadialImpulse(Vec3{X: 0, Y: 0, Z: 0}, 5, 500); pushed != 2 {
+		t.Fatalf("ApplyRadialImpulse pushed %d bodies, expected 2", pushed)
+	}
+	ps.Update(1.0 / 60.0)
+
+	if speed := bi.GetLinearVelocity(capped).Length(); speed > 30.01 {
+		t.Errorf("Capped speed = %.2f, expected at most 30", speed)
+	}
+	if speed := bi.GetLinearVelocity(free).Length(); speed <= 30 {
+		t.Errorf("Uncapped speed = %.2f, expected the blast to exceed 30", speed)
+	}
+}
+
+func TestGetSurfaceNormal(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer boxID.Destroy()
+
+	hit, ok := ps.CastRay(Vec3{X: 0.3, Y: 5, Z: -0.2}, Vec3{X: 0, Y: -10, Z: 0})
+	if !ok {
+		t.Fatal("CastRay missed the box")
+	}
+	defer hit.BodyID.Destroy()
+
+	normal := bi.GetSurfaceNormal(boxID, hit.SubShapeID2, hit.HitPoint)
+	if normal.Sub(Vec3{X: 0, Y: 1, Z: 0}).Length() > 1e-4 {
+		t.Errorf("GetSurfaceNormal() on the top face = %v, expected {0 1 0}", normal)
+	}
+
+	normal = bi.GetSurfaceNormal(boxID, hit.SubShapeID2, Vec3{X: 1, Y: 0.2, Z: 0.1})
+	if normal.Sub(Vec3{X: 1, Y: 0, Z: 0}).Length() > 1e-4 {
+		t.Errorf("GetSurfaceNormal() on the +X face = %v, expected {1 0 0}", normal)
+	}
+}
+
+func TestCreateDynamicBody(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	ball := bi.CreateDynamicBody(sphere, Vec3{X: 0, Y: 10, Z: 0})
+	defer ball.Destroy()
+
+	if !bi.IsActive(ball) {
+		t.Error("IsActive() = false, expected the body to be activated")
+	}
+
+	for i := 0; i < 30; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+	if y := bi.GetPosition(ball).Y; y >= 9.5 {
+		t.Errorf("Position Y = %.2f after 0.5s, expected the body to fall", y)
+	}
+}
+
+func TestCreateBodiesFromShape(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	positions := []Vec3{
+		{X: 0, Y: 10, Z: 0},
+		{X: 5, Y: 10, Z: 0},
+		{X: 10, Y: 10, Z: 0},
+	}
+	crates := bi.CreateBodiesFromShape(box, positions, true)
+	defer func() {
+		for _, bodyID := range crates {
+			bodyID.Destroy()
+		}
+	}()
+
+	if len(crates) != len(positions) {
+		t.Fatalf("len(CreateBodiesFromShape()) = %d, expected %d", len(crates), len(positions))
+	}
+	for i, crate := range crates {
+		if pos := bi.GetPosition(crate); pos != positions[i] {
+			t.Errorf("Position[%d] = %v, expected %v", i, pos, positions[i])
+		}
+		if !bi.IsActive(crate) {
+			t.Errorf("IsActive(%d) = false, expected dynamic bodies to be activated", i)
+		}
+	}
+
+	floors := bi.CreateBodiesFromShape(box, []Vec3{{X: 0, Y: 0, Z: 0}}, false)
+	defer floors[0].Destroy()
+	if bi.IsActive(floors[0]) {
+		t.Error("IsActive() = true, expected static body to be inactive")
+	}
+
+	if got := bi.CreateBodiesFromShape(nil, positions, true); got != nil {
+		t.Errorf("CreateBodiesFromShape(nil) = %v, expected nil", got)
+	}
+	if got := bi.CreateBodiesFromShape(box, nil, true); got != nil {
+		t.Errorf("CreateBodiesFromShape(empty) = %v, expected nil", got)
+	}
+}
+
+func TestCreateBodyNilShape(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+
+	// A failed shape constructor returns a nil shape
+	if shape := CreateConvexHull([]Vec3{{X: 0, Y: 0, Z: 0}}); shape != nil {
+		t.Fatal("Expected CreateConvexHull to fail with a single point")
+	}
+
+	if bodyID := bi.CreateBody(nil, Vec3{}, MotionTypeStatic, false); bodyID != nil {
+		t.Error("CreateBody(nil) returned a body, expected nil")
+	}
+	if bodyID := bi.CreateBody(&Shape{}, Vec3{}, MotionTypeDynamic, false); bodyID != nil {
+		t.Error("CreateBody with a nil handle returned a body, expected nil")
+	}
+	bodyID := bi.CreateDynamicBody(nil, Vec3{})
+	if bodyID != nil {
+		t.Error("CreateDynamicBody(nil) returned a body, expected nil")
+	}
+
+	// Destroying the nil result is a no-op
+	bodyID.Destroy()
+}
@@ -2,6 +2,11 @@ package jolt
 
 // #include "wrapper/character.h"
 import "C"
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+)
 
 // BackFaceMode controls how the character collides with back faces
 type BackFaceMode int
@@ -29,6 +34,18 @@ type CharacterContact struct {
 	Fraction float32
 	// BodyB is the ID of the body we're colliding with (nil if invalid)
 	BodyB *BodyID
+	// ContactID identifies the touched part of BodyB, it stays the same across updates
+	// while the character keeps touching it, e.g. to keep a scrape sound playing
+	ContactID uint64
+	// SurfaceMaterialIndex is the material index of the contacted triangle of a mesh created with
+	// CreateMeshWithMaterials, e.g. to pick footstep sounds. It is 0 for all other shapes.
+	SurfaceMaterialIndex int
+	// AppliedImpulse is the total impulse (N·s) the character applied at this contact (BodyB and the
+	// touched sub-shape) during the last update, e.g. to sync a pushed box over the network. It is
+	// zero for static, kinematic and sensor bodies. Jolt doesn't report the impulse, so it is
+	// recomputed when the contact is solved, without Jolt's penetration recovery term: a contact
+	// that starts out penetrating reports less than was applied.
+	AppliedImpulse Vec3
 	// UserData is the user data of the body
 	UserData uint64
 	// IsSensorB indicates if the body is a sensor
@@ -97,6 +114,11 @@ type CharacterVirtualSettings struct {
 	// EnhancedInternalEdgeRemoval removes ghost contacts with internal mesh edges.
 	// More expensive but smoother movement over convex edges. (default: false)
 	EnhancedInternalEdgeRemoval bool
+	// CreateInnerBody creates a kinematic rigid body with the character's shape that follows the character,
+	// so rays, shape queries and dynamic bodies can hit it. See CharacterVirtual.GetInnerBodyID. (default: false)
+	CreateInnerBody bool
+	// InnerBodyLayer is the object layer of the inner body (default: ObjectLayerMoving)
+	InnerBodyLayer ObjectLayer
 }
 
 // NewCharacterVirtualSettings creates settings with Jolt's default values
@@ -119,13 +141,18 @@ func NewCharacterVirtualSettings(shape *Shape) *CharacterVirtualSettings {
 		HitReductionCosMaxAngle:     0.999,
 		PenetrationRecoverySpeed:    1.0,
 		EnhancedInternalEdgeRemoval: false,
+		CreateInnerBody:             false,
+		InnerBodyLayer:              ObjectLayerMoving,
 	}
 }
 
 // CharacterVirtual represents a virtual character in the physics world
 type CharacterVirtual struct {
-	handle C.JoltCharacterVirtual
-	ps     *PhysicsSystem
+	handle        C.JoltCharacterVirtual
+	ps            *PhysicsSystem
+	settings      CharacterVirtualSettings // Copy of the creation settings, used to rebuild the character
+	lastStairWalk StairWalkInfo            // Stair walk of the last ExtendedUpdate
+	freed         atomic.Bool              // Set once the character is freed, so Destroy and the finalizer only free it once
 }
 
 // GroundState indicates the ground contact state of a CharacterVirtual
@@ -157,9 +184,8 @@ func (gs GroundState) String() string {
 	}
 }
 
-// CreateCharacterVirtual creates a virtual character with the specified settings at the initial position
-func (ps *PhysicsSystem) CreateCharacterVirtual(settings *CharacterVirtualSettings, position Vec3) *CharacterVirtual {
-	// Convert Go settings to C settings
+// toC converts Go settings to C settings
+func (settings *CharacterVirtualSettings) toC() C.JoltCharacterVirtualSettings {
 	cSettings := C.JoltCharacterVirtualSettings{
 		shape:                       settings.Shape.handle,
 		upX:                         C.float(settings.Up.X),
@@ -186,6 +212,16 @@ func (ps *PhysicsSystem) CreateCharacterVirtual(settings *CharacterVirtualSettin
 	if settings.EnhancedInternalEdgeRemoval {
 		cSettings.enhancedInternalEdgeRemoval = 1
 	}
+	if settings.CreateInnerBody {
+		cSettings.createInnerBody = 1
+		cSettings.innerBodyLayer = C.ushort(settings.InnerBodyLayer)
+	}
+	return cSettings
+}
+
+// CreateCharacterVirtual creates a virtual character with the specified settings at the initial position
+func (ps *PhysicsSystem) CreateCharacterVirtual(settings *CharacterVirtualSettings, position Vec3) *CharacterVirtual {
+	cSettings := settings.toC()
 
 	handle := C.JoltCreateCharacterVirtual(
 		ps.handle,
@@ -194,7 +230,10 @@ func (ps *PhysicsSystem) CreateCharacterVirtual(settings *CharacterVirtualSettin
 		C.float(position.Y),
 		C.float(position.Z),
 	)
-	return &CharacterVirtual{handle: handle, ps: ps}
+	runtime.KeepAlive(settings.Shape)
+	cv := &CharacterVirtual{handle: handle, ps: ps, settings: *settings}
+	ps.characters[cv] = struct{}{}
+	return cv
 }
 
 // Update advances the character simulation using the current velocity
@@ -213,11 +252,62 @@ func (cv *CharacterVirtual) Update(deltaTime float32, gravity Vec3) {
 	)
 }
 
+// SimulateMove returns the position Update would move the character to if its velocity was
+// velocity, without moving it: the move runs on a temporary copy of the character, so the
+// character and its inner body are left untouched and bodies in the way are not pushed.
+// Use it to validate paths or predict movement.
+//
+// The prediction matches Update exactly as long as the world doesn't change in between.
+// It doesn't include the stair walking and floor sticking of ExtendedUpdate.
+//
+// Example:
+//
+//	step := dir.Mul(speed)
+//	if end := character.SimulateMove(dt, step, gravity); end.Sub(character.GetPosition()).Length() < 0.5*speed*dt {
+//	    // blocked, pick another direction
+//	}
+func (cv *CharacterVirtual) SimulateMove(deltaTime float32, velocity Vec3, gravity Vec3) Vec3 {
+	var x, y, z C.float
+	cSettings := cv.settings.toC()
+	C.JoltCharacterVirtualSimulateMove(
+		cv.handle,
+		cv.ps.handle,
+		&cSettings,
+		C.float(deltaTime),
+		C.float(velocity.X),
+		C.float(velocity.Y),
+		C.float(velocity.Z),
+		C.float(gravity.X),
+		C.float(gravity.Y),
+		C.float(gravity.Z),
+		&x, &y, &z,
+	)
+	return Vec3{X: float32(x), Y: float32(y), Z: float32(z)}
+}
+
+// CharacterUpdateResult describes what happened to a character during ExtendedUpdate
+type CharacterUpdateResult struct {
+	// Moved is the actual displacement of the character, shorter than velocity * deltaTime when blocked
+	Moved Vec3
+	// HitWall indicates the character collided with a surface too steep to walk on (floors and ceilings don't count)
+	HitWall bool
+	// GroundState is the ground state after the update
+	GroundState GroundState
+}
+
 // ExtendedUpdate advances the character simulation with combined movement logic
 // Combines Update, StickToFloor, and WalkStairs into a unified operation
 // deltaTime: duration of simulation step in seconds
 // gravity: acceleration vector (e.g., Vec3{0, -9.81, 0} for Earth gravity)
-func (cv *CharacterVirtual) ExtendedUpdate(deltaTime float32, gravity Vec3) {
+//
+// Example:
+//
+//	result := character.ExtendedUpdate(dt, gravity)
+//	if result.HitWall {
+//	    playBonk()
+//	}
+func (cv *CharacterVirtual) ExtendedUpdate(deltaTime float32, gravity Vec3) CharacterUpdateResult {
+	var cResult C.JoltCharacterUpdateResult
 	C.JoltCharacterVirtualExtendedUpdate(
 		cv.handle,
 		cv.ps.handle,
@@ -225,7 +315,47 @@ func (cv *CharacterVirtual) ExtendedUpdate(deltaTime float32, gravity Vec3) {
 		C.float(gravity.X),
 		C.float(gravity.Y),
 		C.float(gravity.Z),
+		&cResult,
 	)
+	cv.lastStairWalk = StairWalkInfo{
+		Attempted:  cResult.stairWalk.attempted != 0,
+		Succeeded:  cResult.stairWalk.succeeded != 0,
+		StepHeight: float32(cResult.stairWalk.stepHeight),
+	}
+	return CharacterUpdateResult{
+		Moved: Vec3{
+			X: float32(cResult.movedX),
+			Y: float32(cResult.movedY),
+			Z: float32(cResult.movedZ),
+		},
+		HitWall:     cResult.hitWall != 0,
+		GroundState: GroundState(cResult.groundState),
+	}
+}
+
+// StairWalkInfo describes the stair walking of the last ExtendedUpdate
+type StairWalkInfo struct {
+	// Attempted is true if the character was blocked while moving horizontally
+	// against a surface too steep to walk on, and tried to step up onto it
+	Attempted bool
+	// Succeeded is true if the character found a floor within the maximum step height and stepped up
+	Succeeded bool
+	// StepHeight is how far the step up raised the character along its up vector, 0 if it didn't step up
+	StepHeight float32
+}
+
+// GetLastStairWalkResult returns whether the last ExtendedUpdate tried to walk up a step
+// and if it succeeded, e.g. to debug a character that gets stuck in front of a step.
+// Returns the zero value before the first ExtendedUpdate.
+//
+// Example:
+//
+//	character.ExtendedUpdate(dt, gravity)
+//	if info := character.GetLastStairWalkResult(); info.Attempted && !info.Succeeded {
+//	    log.Printf("step too high at %v", character.GetPosition())
+//	}
+func (cv *CharacterVirtual) GetLastStairWalkResult() StairWalkInfo {
+	return cv.lastStairWalk
 }
 
 // SetLinearVelocity sets the character's linear velocity
@@ -249,7 +379,8 @@ func (cv *CharacterVirtual) GetLinearVelocity() Vec3 {
 	}
 }
 
-// GetGroundVelocity returns the velocity clamped to the ground plane
+// GetGroundVelocity returns the velocity of the ground the character stands on
+// The value is captured during Update, call UpdateGroundVelocity to refresh it after the ground moved.
 func (cv *CharacterVirtual) GetGroundVelocity() Vec3 {
 	var x, y, z C.float
 	C.JoltCharacterVirtualGetGroundVelocity(cv.handle, &x, &y, &z)
@@ -260,6 +391,21 @@ func (cv *CharacterVirtual) GetGroundVelocity() Vec3 {
 	}
 }
 
+// UpdateGroundVelocity recalculates the ground velocity from the current velocity of the body
+// the character stands on. Call it after PhysicsSystem.Update and before computing the character's
+// new velocity, so the character rides moving platforms.
+//
+// Example:
+//
+//	ps.Update(dt)
+//	character.UpdateGroundVelocity()
+//	velocity := character.GetGroundVelocity().Add(input)
+//	character.SetLinearVelocity(velocity)
+//	character.Update(dt, gravity)
+func (cv *CharacterVirtual) UpdateGroundVelocity() {
+	C.JoltCharacterVirtualUpdateGroundVelocity(cv.handle)
+}
+
 // SetPosition sets the character's position in the world
 func (cv *CharacterVirtual) SetPosition(position Vec3) {
 	C.JoltCharacterVirtualSetPosition(
@@ -270,6 +416,25 @@ func (cv *CharacterVirtual) SetPosition(position Vec3) {
 	)
 }
 
+// SafeTeleport moves the character to position like SetPosition, but then pushes it out of any
+// geometry it overlaps, e.g. when respawning at a spot a crate was dropped on.
+// Returns false if the character couldn't be freed, in which case it is moved back to where it was.
+//
+// Example:
+//
+//	if !character.SafeTeleport(spawnPoint) {
+//	    character.SafeTeleport(fallbackSpawnPoint)
+//	}
+func (cv *CharacterVirtual) SafeTeleport(position Vec3) bool {
+	return C.JoltCharacterVirtualSafeTeleport(
+		cv.handle,
+		cv.ps.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+	) != 0
+}
+
 // GetPosition returns the current position of the character
 func (cv *CharacterVirtual) GetPosition() Vec3 {
 	var x, y, z C.float
@@ -281,9 +446,64 @@ func (cv *CharacterVirtual) GetPosition() Vec3 {
 	}
 }
 
-// Destroy frees the character resources
+// GetCenterOfMassPosition returns the world space center of the character's shape.
+// This differs from GetPosition by the rotated ShapeOffset (plus the character padding along Up).
+func (cv *CharacterVirtual) GetCenterOfMassPosition() Vec3 {
+	var x, y, z C.float
+	C.JoltCharacterVirtualGetCenterOfMassPosition(cv.handle, &x, &y, &z)
+	return Vec3{
+		X: float32(x),
+		Y: float32(y),
+		Z: float32(z),
+	}
+}
+
+// GetWorldTransform returns the position and rotation of the character as a column-major 4x4 matrix,
+// e.g. to attach a camera. Use GetCenterOfMassTransform to render the shape, it includes ShapeOffset.
+func (cv *CharacterVirtual) GetWorldTransform() Mat4 {
+	var cMatrix [16]C.float
+	C.JoltCharacterVirtualGetWorldTransform(cv.handle, &cMatrix[0])
+
+	var m Mat4
+	for i, v := range cMatrix {
+		m[i] = float32(v)
+	}
+	return m
+}
+
+// GetCenterOfMassTransform returns the world transform of the character's shape as a column-major
+// 4x4 matrix, ready to be used as a model matrix for rendering the capsule
+//
+// Example:
+//
+//	model := character.GetCenterOfMassTransform()
+//	gl.UniformMatrix4fv(modelLoc, 1, false, &model[0])
+func (cv *CharacterVirtual) GetCenterOfMassTransform() Mat4 {
+	var cMatrix [16]C.float
+	C.JoltCharacterVirtualGetCenterOfMassTransform(cv.handle, &cMatrix[0])
+
+	var m Mat4
+	for i, v := range cMatrix {
+		m[i] = float32(v)
+	}
+	return m
+}
+
+// Destroy frees the character resources. Characters that are still alive when their
+// physics system is destroyed are freed with it, calling Destroy afterwards is a no-op.
 func (cv *CharacterVirtual) Destroy() {
+	if cv.free() {
+		delete(cv.ps.characters, cv)
+	}
+}
+
+// free frees the character, returns false if it was already freed
+func (cv *CharacterVirtual) free() bool {
+	if !cv.freed.CompareAndSwap(false, true) {
+		return false
+	}
 	C.JoltDestroyCharacterVirtual(cv.handle)
+	return true
 }
 
 // GetGroundState returns the current ground contact state
@@ -308,6 +528,12 @@ func (cv *CharacterVirtual) SetShape(shape *Shape, maxPenetrationDepth float32)
 		C.float(maxPenetrationDepth),
 		cv.ps.handle,
 	)
+	runtime.KeepAlive(shape)
+
+	// Jolt keeps the old shape if the new one would penetrate more than maxPenetrationDepth
+	if C.JoltCharacterVirtualGetShape(cv.handle) == shape.handle {
+		cv.settings.Shape = shape
+	}
 }
 
 // GetShape retrieves the current collision shape of the character
@@ -316,12 +542,24 @@ func (cv *CharacterVirtual) GetShape() *Shape {
 	return &Shape{handle: handle}
 }
 
+// GetInnerBodyID returns the ID of the character's inner rigid body, or nil if
+// the character was created without CreateInnerBody. The caller must destroy the returned ID.
+// The inner body is owned by the character: it moves with it and is removed on Destroy.
+func (cv *CharacterVirtual) GetInnerBodyID() *BodyID {
+	handle := C.JoltCharacterVirtualGetInnerBodyID(cv.handle)
+	if handle == nil {
+		return nil
+	}
+	return newBodyID(handle)
+}
+
 // PhysicsSystem returns the physics system that this character belongs to
 func (cv *CharacterVirtual) PhysicsSystem() *PhysicsSystem {
 	return cv.ps
 }
 
 // GetGroundNormal returns the normal vector of the ground surface
+// Use OrthonormalBasis on the result for a stable orientation of the ground surface.
 func (cv *CharacterVirtual) GetGroundNormal() Vec3 {
 	var x, y, z C.float
 	C.JoltCharacterVirtualGetGroundNormal(cv.handle, &x, &y, &z)
@@ -343,6 +581,12 @@ func (cv *CharacterVirtual) GetGroundPosition() Vec3 {
 	}
 }
 
+// GetNumActiveContacts returns the number of active contacts without copying them,
+// e.g. to early-out or size the maxContacts argument of GetActiveContacts
+func (cv *CharacterVirtual) GetNumActiveContacts() int {
+	return int(C.JoltCharacterVirtualGetNumActiveContacts(cv.handle))
+}
+
 // GetActiveContacts returns the list of active contacts for the character
 // maxContacts specifies the maximum number of contacts to retrieve (typically 256)
 func (cv *CharacterVirtual) GetActiveContacts(maxContacts int) []CharacterContact {
@@ -363,40 +607,201 @@ func (cv *CharacterVirtual) GetActiveContacts(maxContacts int) []CharacterContac
 
 		var bodyB *BodyID
 		if c.bodyB != nil {
-			bodyB = &BodyID{handle: c.bodyB}
+			bodyB = newBodyID(c.bodyB)
 		}
+		contacts[i] = toCharacterContact(c, bodyB)
+	}
 
-		contacts[i] = CharacterContact{
-			Position: Vec3{
-				X: float32(c.positionX),
-				Y: float32(c.positionY),
-				Z: float32(c.positionZ),
-			},
-			LinearVelocity: Vec3{
-				X: float32(c.linearVelocityX),
-				Y: float32(c.linearVelocityY),
-				Z: float32(c.linearVelocityZ),
-			},
-			ContactNormal: Vec3{
-				X: float32(c.contactNormalX),
-				Y: float32(c.contactNormalY),
-				Z: float32(c.contactNormalZ),
-			},
-			SurfaceNormal: Vec3{
-				X: float32(c.surfaceNormalX),
-				Y: float32(c.surfaceNormalY),
-				Z: float32(c.surfaceNormalZ),
-			},
-			Distance:         float32(c.distance),
-			Fraction:         float32(c.fraction),
-			BodyB:            bodyB,
-			UserData:         uint64(c.userData),
-			IsSensorB:        c.isSensorB != 0,
-			HadCollision:     c.hadCollision != 0,
-			WasDiscarded:     c.wasDiscarded != 0,
-			CanPushCharacter: c.canPushCharacter != 0,
+	return contacts
+}
+
+// ForEachActiveContact calls fn for every active contact of the character, in the same order as
+// GetActiveContacts, and stops early when fn returns false. Unlike GetActiveContacts it builds no
+// slice and allocates one body ID per call instead of one per contact, which suits "find the first
+// wall" searches every frame.
+//
+// The contact's BodyB is reused for every contact and freed when ForEachActiveContact returns, so it is
+// only valid during fn. Destroy on it is a no-op; keep GetIndexAndSequenceNumber instead of the pointer.
+// fn must not update the character.
+//
+// Example:
+//
+//	var wallNormal jolt.Vec3
+//	character.ForEachActiveContact(func(contact jolt.CharacterContact) bool {
+//	    if contact.HadCollision && math.Abs(float64(contact.ContactNormal.Y)) < 0.3 {
+//	        wallNormal = contact.ContactNormal
+//	        return false
+//	    }
+//	    return true
+//	})
+func (cv *CharacterVirtual) ForEachActiveContact(fn func(contact CharacterContact) bool) {
+	// Every contact's body ID is copied into this one
+	storage := newBodyIDStorage()
+	defer storage.freeStorage()
+
+	var c C.JoltCharacterContact
+	for i := 0; C.JoltCharacterVirtualGetActiveContact(cv.handle, C.int(i), &c, storage.handle) != 0; i++ {
+		var bodyB *BodyID
+		if c.bodyB != nil {
+			bodyB = storage
+		}
+		if !fn(toCharacterContact(&c, bodyB)) {
+			return
 		}
 	}
+}
 
-	return contacts
+// toCharacterContact converts a C character contact to Go, with bodyB as its body ID
+func toCharacterContact(c *C.JoltCharacterContact, bodyB *BodyID) CharacterContact {
+	return CharacterContact{
+		Position: Vec3{
+			X: float32(c.positionX),
+			Y: float32(c.positionY),
+			Z: float32(c.positionZ),
+		},
+		LinearVelocity: Vec3{
+			X: float32(c.linearVelocityX),
+			Y: float32(c.linearVelocityY),
+			Z: float32(c.linearVelocityZ),
+		},
+		ContactNormal: Vec3{
+			X: float32(c.contactNormalX),
+			Y: float32(c.contactNormalY),
+			Z: float32(c.contactNormalZ),
+		},
+		SurfaceNormal: Vec3{
+			X: float32(c.surfaceNormalX),
+			Y: float32(c.surfaceNormalY),
+			Z: float32(c.surfaceNormalZ),
+		},
+		Distance:             float32(c.distance),
+		Fraction:             float32(c.fraction),
+		BodyB:                bodyB,
+		ContactID:            uint64(c.contactID),
+		SurfaceMaterialIndex: int(c.materialIndex),
+		AppliedImpulse: Vec3{
+			X: float32(c.impulseX),
+			Y: float32(c.impulseY),
+			Z: float32(c.impulseZ),
+		},
+		UserData:         uint64(c.userData),
+		IsSensorB:        c.isSensorB != 0,
+		HadCollision:     c.hadCollision != 0,
+		WasDiscarded:     c.wasDiscarded != 0,
+		CanPushCharacter: c.canPushCharacter != 0,
+	}
+}
+
+// GetCollidingContacts returns the active contacts the character actually collided with (HadCollision),
+// leaving out predictive contacts with nearby geometry it didn't touch.
+// maxContacts limits the number of active contacts examined (typically 256)
+func (cv *CharacterVirtual) GetCollidingContacts(maxContacts int) []CharacterContact {
+	contacts := cv.GetActiveContacts(maxContacts)
+
+	colliding := contacts[:0]
+	for _, contact := range contacts {
+		if contact.HadCollision {
+			colliding = append(colliding, contact)
+		} else if contact.BodyB != nil {
+			contact.BodyB.Destroy()
+		}
+	}
+	return colliding
+}
+
+// SaveState returns a compact snapshot of the character's physics state (position, rotation,
+// velocity, ground state and contacts), e.g. for netcode rollback. Restore it with RestoreState.
+// The format is Jolt's binary state and is only valid for the same library version.
+//
+// Example:
+//
+//	snapshots[tick] = character.SaveState()
+//	...
+//	if err := character.RestoreState(snapshots[confirmedTick]); err != nil {
+//	    log.Printf("rollback failed: %v", err)
+//	}
+func (cv *CharacterVirtual) SaveState() []byte {
+	// Most states fit in the first buffer, otherwise the returned size tells how much is needed
+	data := make([]byte, 256)
+	size := int(C.JoltCharacterVirtualSaveState(cv.handle, (*C.uchar)(&data[0]), C.int(len(data))))
+	if size > len(data) {
+		data = make([]byte, size)
+		size = int(C.JoltCharacterVirtualSaveState(cv.handle, (*C.uchar)(&data[0]), C.int(len(data))))
+	}
+	return data[:size]
+}
+
+// RestoreState restores a state returned by SaveState.
+// The state is checked before it is applied: truncated, corrupt or foreign data returns
+// an error and leaves the character unchanged.
+func (cv *CharacterVirtual) RestoreState(state []byte) error {
+	if len(state) == 0 {
+		return fmt.Errorf("empty character state")
+	}
+	if C.JoltCharacterVirtualRestoreState(cv.handle, (*C.uchar)(&state[0]), C.int(len(state))) == 0 {
+		return fmt.Errorf("invalid character state (%d bytes)", len(state))
+	}
+	return nil
+}
+
+// recreate rebuilds the character from cv.settings, keeping its position, rotation, velocity and
+// current shape. Jolt only reads some settings at construction time, so changing them requires a new
+// character. Contacts and ground state are refreshed immediately; the inner body gets a new ID.
+func (cv *CharacterVirtual) recreate() {
+	cSettings := cv.settings.toC()
+	cv.handle = C.JoltCharacterVirtualRecreate(cv.handle, cv.ps.handle, &cSettings)
+}
+
+// SetMaxNumHits sets the max number of hits to collect to avoid excess contact points
+func (cv *CharacterVirtual) SetMaxNumHits(maxNumHits uint32) {
+	cv.settings.MaxNumHits = maxNumHits
+	C.JoltCharacterVirtualSetMaxNumHits(cv.handle, C.uint(maxNumHits))
+}
+
+// GetMaxNumHits returns the max number of hits to collect
+func (cv *CharacterVirtual) GetMaxNumHits() uint32 {
+	return uint32(C.JoltCharacterVirtualGetMaxNumHits(cv.handle))
+}
+
+// DidMaxHitsExceed returns true if the last Update or ExtendedUpdate found more than MaxNumHits
+// contacts and had to discard some. The character may then walk through or get stuck on the
+// discarded geometry, so raise MaxNumHits for crowded scenes when this trips.
+func (cv *CharacterVirtual) DidMaxHitsExceed() bool {
+	return C.JoltCharacterVirtualGetMaxHitsExceeded(cv.handle) != 0
+}
+
+// SetPredictiveContactDistance sets how far to scan outside the shape for contacts
+// Note: Jolt only reads this at construction, so the character is rebuilt in place
+func (cv *CharacterVirtual) SetPredictiveContactDistance(distance float32) {
+	cv.settings.PredictiveContactDistance = distance
+	cv.recreate()
+}
+
+// GetPredictiveContactDistance returns how far to scan outside the shape for contacts
+func (cv *CharacterVirtual) GetPredictiveContactDistance() float32 {
+	return cv.settings.PredictiveContactDistance
+}
+
+// SetCollisionTolerance sets how far we're willing to penetrate geometry
+// Note: Jolt only reads this at construction, so the character is rebuilt in place
+func (cv *CharacterVirtual) SetCollisionTolerance(tolerance float32) {
+	cv.settings.CollisionTolerance = tolerance
+	cv.recreate()
+}
+
+// GetCollisionTolerance returns how far we're willing to penetrate geometry
+func (cv *CharacterVirtual) GetCollisionTolerance() float32 {
+	return cv.settings.CollisionTolerance
+}
+
+// SetCharacterPadding sets how far we try to stay away from geometry
+// Note: Jolt only reads this at construction, so the character is rebuilt in place
+func (cv *CharacterVirtual) SetCharacterPadding(padding float32) {
+	cv.settings.CharacterPadding = padding
+	cv.recreate()
+}
+
+// GetCharacterPadding returns how far we try to stay away from geometry
+func (cv *CharacterVirtual) GetCharacterPadding() float32 {
+	return float32(C.JoltCharacterVirtualGetCharacterPadding(cv.handle))
 }
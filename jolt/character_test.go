@@ -0,0 +1,1054 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCharacterVirtualCollisionSettings(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 5, Z: 0})
+	defer character.Destroy()
+
+	character.SetLinearVelocity(Vec3{X: 1, Y: 0, Z: 0})
+
+	character.SetMaxNumHits(16)
+	character.SetPredictiveContactDistance(0.05)
+	character.SetCollisionTolerance(2.0e-3)
+	character.SetCharacterPadding(0.05)
+
+	if got := character.GetMaxNumHits(); got != 16 {
+		t.Errorf("GetMaxNumHits() = %d, expected 16", got)
+	}
+	if got := character.GetPredictiveContactDistance(); got != 0.05 {
+		t.Errorf("GetPredictiveContactDistance() = %f, expected 0.05", got)
+	}
+	if got := character.GetCollisionTolerance(); got != 2.0e-3 {
+		t.Errorf("GetCollisionTolerance() = %f, expected 0.002", got)
+	}
+	if got := character.GetCharacterPadding(); math.Abs(float64(got-0.05)) > 1e-6 {
+		t.Errorf("GetCharacterPadding() = %f, expected 0.05", got)
+	}
+
+	// Rebuilding the character must not lose its state
+	if pos := character.GetPosition(); math.Abs(float64(pos.Y-5)) > 1e-4 {
+		t.Errorf("Position Y = %.2f after changing settings, expected 5", pos.Y)
+	}
+	if vel := character.GetLinearVelocity(); math.Abs(float64(vel.X-1)) > 1e-4 {
+		t.Errorf("Velocity X = %.2f after changing settings, expected 1", vel.X)
+	}
+}
+
+func TestCharacterVirtualNoTunneling(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 20, Y: 0.5, Z: 20})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// 2 cm thick wall with its near face at X = 3
+	wall := CreateBox(Vec3{X: 0.01, Y: 5, Z: 5})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 3.01, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	character.SetPredictiveContactDistance(0.05)
+	character.SetCollisionTolerance(2.0e-3)
+	character.SetCharacterPadding(0.05)
+
+	// At 120 m/s the character covers 2 m per step, 100 times the wall's thickness
+	for i := 0; i < 10; i++ {
+		character.SetLinearVelocity(Vec3{X: 120, Y: 0, Z: 0})
+		character.Update(1.0/60.0, ps.GetGravity())
+	}
+
+	if pos := character.GetPosition(); pos.X > 2.5+0.05 {
+		t.Errorf("Position X = %.2f, expected the character to stop in front of the wall at 2.5", pos.X)
+	}
+}
+
+func TestCharacterVirtualRecreateKeepsShape(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	character.SetShape(sphere, 0.1)
+	character.SetPosition(Vec3{X: 0, Y: 1.0, Z: 0}) // Rest the sphere on the floor
+	for i := 0; i < 30; i++ {
+		character.Update(1.0/60.0, ps.GetGravity())
+	}
+	if got := character.GetGroundState(); got != GroundStateOnGround {
+		t.Fatalf("GetGroundState() = %v before SetCharacterPadding, expected OnGround", got)
+	}
+
+	// Rebuilding the character must keep the shape set after creation and its ground contact
+	character.SetCharacterPadding(0.05)
+
+	if got := character.GetShape(); got.handle != sphere.handle {
+		t.Error("GetShape() after SetCharacterPadding, expected the shape from SetShape")
+	}
+	if got := character.GetGroundState(); got != GroundStateOnGround {
+		t.Errorf("GetGroundState() = %v after SetCharacterPadding, expected OnGround", got)
+	}
+	if !character.IsSupported() {
+		t.Error("IsSupported() = false after SetCharacterPadding, expected true")
+	}
+	if character.settings.Shape != sphere {
+		t.Error("settings.Shape after SetShape, expected the shape from SetShape")
+	}
+}
+
+func TestCharacterVirtualRecreateAfterShapesDestroyed(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	sphere := CreateSphere(0.5)
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 5, Z: 0})
+	defer character.Destroy()
+
+	// The character keeps its own reference to its shape, so the caller may release both
+	character.SetShape(sphere, 0.1)
+	capsule.Destroy()
+	sphere.Destroy()
+
+	// Rebuilding must only use the live shape, not the destroyed creation shape
+	character.SetCharacterPadding(0.05)
+	character.SimulateMove(1.0/60.0, Vec3{X: 1, Y: 0, Z: 0}, ps.GetGravity())
+
+	if got := character.GetShape().GetType(); got != ShapeTypeSphere {
+		t.Errorf("GetShape().GetType() = %v after SetCharacterPadding, expected Sphere", got)
+	}
+}
+
+func TestCharacterVirtualFreedWithPhysicsSystem(t *testing.T) {
+	ps := NewPhysicsSystem()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	settings := NewCharacterVirtualSettings(capsule)
+	settings.CreateInnerBody = true
+	kept := ps.CreateCharacterVirtual(settings, Vec3{X: 0, Y: 0, Z: 0})
+	destroyed := ps.CreateCharacterVirtual(settings, Vec3{X: 5, Y: 0, Z: 0})
+	destroyed.Destroy()
+
+	if got := len(ps.characters); got != 1 {
+		t.Errorf("Tracked characters = %d, expected 1 after destroying one of two", got)
+	}
+
+	// The remaining character is freed with the system, destroying it afterwards is a no-op
+	ps.Destroy()
+	if !kept.freed.Load() {
+		t.Error("Character not freed by PhysicsSystem.Destroy")
+	}
+	kept.Destroy()
+}
+
+func TestCharacterVirtualGetNumActiveContacts(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	for i := 0; i < 10; i++ {
+		character.Update(1.0/60.0, ps.GetGravity())
+	}
+
+	contacts := character.GetActiveContacts(256)
+	for _, c := range contacts {
+		if c.BodyB != nil {
+			defer c.BodyB.Destroy()
+		}
+	}
+
+	num := character.GetNumActiveContacts()
+	if num == 0 {
+		t.Fatal("Expected the character standing on the floor to have contacts")
+	}
+	if num != len(contacts) {
+		t.Errorf("GetNumActiveContacts() = %d, expected len(GetActiveContacts(256)) = %d", num, len(contacts))
+	}
+}
+
+func TestCharacterVirtualInnerBody(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	plain := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 10, Z: 0})
+	defer plain.Destroy()
+	if id := plain.GetInnerBodyID(); id != nil {
+		id.Destroy()
+		t.Error("Expected no inner body without CreateInnerBody")
+	}
+
+	settings := NewCharacterVirtualSettings(capsule)
+	settings.CreateInnerBody = true
+	character := ps.CreateCharacterVirtual(settings, Vec3{X: 0, Y: 0, Z: 0})
+	defer character.Destroy()
+
+	innerID := character.GetInnerBodyID()
+	if innerID == nil {
+		t.Fatal("Expected an inner body with CreateInnerBody")
+	}
+	defer innerID.Destroy()
+
+	bi := ps.GetBodyInterface()
+	bi.SetUserData(innerID, 42)
+
+	// A ray fired at the character hits its inner body
+	hit, ok := ps.CastRay(Vec3{X: 5, Y: 0, Z: 0}, Vec3{X: -10, Y: 0, Z: 0})
+	if !ok {
+		t.Fatal("Expected ray to hit the character's inner body")
+	}
+	defer hit.BodyID.Destroy()
+
+	if got := bi.GetUserData(hit.BodyID); got != 42 {
+		t.Errorf("Hit body user data = %d, expected the inner body (42)", got)
+	}
+}
+
+func TestCharacterVirtualSafeTeleport(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+
+	// Wall with its +X face at X = 0.5
+	wall := CreateBox(Vec3{X: 0.5, Y: 5, Z: 5})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 5, Y: 0, Z: 0})
+	defer character.Destroy()
+
+	// Halfway into the wall
+	if !character.SafeTeleport(Vec3{X: 0.7, Y: 0, Z: 0}) {
+		t.Fatal("SafeTeleport should have found a free spot next to the wall")
+	}
+
+	position := character.GetPosition()
+	if position.X < 0.99 {
+		t.Errorf("Character X = %.3f, expected to be pushed out of the wall (>= 1.0)", position.X)
+	}
+	if hit, ok := ps.CollideShapeDeepest(capsule, position); ok {
+		defer hit.BodyID.Destroy()
+		if hit.PenetrationDepth > 0.01 {
+			t.Errorf("Character still penetrates the wall by %.3f", hit.PenetrationDepth)
+		}
+	}
+}
+
+func TestCharacterVirtualUpdateGroundVelocity(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	platform := CreateBox(Vec3{X: 5, Y: 0.5, Z: 5})
+	defer platform.Destroy()
+	platformID := bi.CreateBody(platform, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeKinematic, false)
+	defer platformID.Destroy()
+	bi.SetLinearVelocity(platformID, Vec3{X: 1, Y: 0, Z: 0})
+	bi.ActivateBody(platformID)
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	gravity := Vec3{X: 0, Y: -9.81, Z: 0}
+	const dt = 1.0 / 60.0
+	for i := 0; i < 60; i++ {
+		ps.Update(dt)
+		character.UpdateGroundVelocity()
+		character.SetLinearVelocity(character.GetGroundVelocity())
+		character.Update(dt, gravity)
+	}
+
+	if character.GetGroundState() != GroundStateOnGround {
+		t.Fatalf("Ground state = %v, expected OnGround", character.GetGroundState())
+	}
+	if vel := character.GetGroundVelocity(); math.Abs(float64(vel.X-1)) > 0.05 {
+		t.Errorf("Ground velocity X = %.3f, expected 1", vel.X)
+	}
+
+	// The character rides along with the platform
+	platformX := bi.GetPosition(platformID).X
+	characterX := character.GetPosition().X
+	if math.Abs(float64(characterX-platformX)) > 0.1 {
+		t.Errorf("Character X = %.3f, expected it to follow the platform to %.3f", characterX, platformX)
+	}
+}
+
+func TestCharacterVirtualExtendedUpdateHitWall(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Wall face at X = 0.6, just beyond the capsule's radius
+	wall := CreateBox(Vec3{X: 0.5, Y: 5, Z: 5})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 1.1, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	gravity := Vec3{X: 0, Y: -9.81, Z: 0}
+	const dt = 1.0 / 60.0
+
+	// Standing still next to the wall doesn't count as hitting it
+	result := character.ExtendedUpdate(dt, gravity)
+	if result.HitWall {
+		t.Error("HitWall = true while standing still")
+	}
+
+	character.SetLinearVelocity(Vec3{X: 10, Y: 0, Z: 0})
+	result = character.ExtendedUpdate(dt, gravity)
+
+	if !result.HitWall {
+		t.Error("HitWall = false after running into the wall")
+	}
+	if requested := float32(10 * dt); result.Moved.X >= requested {
+		t.Errorf("Moved X = %.3f, expected less than the requested %.3f", result.Moved.X, requested)
+	}
+	if result.GroundState != GroundStateOnGround {
+		t.Errorf("GroundState = %v, expected OnGround", result.GroundState)
+	}
+}
+
+func TestCharacterVirtualContactIDStable(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Wall face at X = 0.6
+	wall := CreateBox(Vec3{X: 0.5, Y: 5, Z: 10})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 1.1, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0.05, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	gravity := Vec3{X: 0, Y: -9.81, Z: 0}
+	wallIndex := wallID.GetIndexAndSequenceNumber()
+
+	// Slide along the wall while pushing into it
+	var contactIDs []uint64
+	for i := 0; i < 10; i++ {
+		character.SetLinearVelocity(Vec3{X: 1, Y: 0, Z: 2})
+		character.ExtendedUpdate(1.0/60.0, gravity)
+
+		for _, contact := range character.GetActiveContacts(256) {
+			if contact.BodyB != nil && contact.BodyB.GetIndexAndSequenceNumber() == wallIndex {
+				contactIDs = append(contactIDs, contact.ContactID)
+			}
+			if contact.BodyB != nil {
+				contact.BodyB.Destroy()
+			}
+		}
+	}
+
+	if len(contactIDs) < 10 {
+		t.Fatalf("Got %d wall contacts over 10 updates, expected one per update", len(contactIDs))
+	}
+	for i, id := range contactIDs {
+		if id != contactIDs[0] {
+			t.Errorf("Wall contact %d has ID %#x, expected %#x like the first", i, id, contactIDs[0])
+		}
+	}
+
+	// The floor is a different contact
+	for _, contact := range character.GetActiveContacts(256) {
+		if contact.BodyB != nil {
+			if contact.BodyB.GetIndexAndSequenceNumber() == floorID.GetIndexAndSequenceNumber() && contact.ContactID == contactIDs[0] {
+				t.Error("Floor contact has the same ID as the wall contact")
+			}
+			contact.BodyB.Destroy()
+		}
+	}
+}
+
+func TestCharacterVirtualGetCollidingContacts(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Wall face at X = 0.55, within the predictive contact distance of the capsule
+	bi := ps.GetBodyInterface()
+	wall := CreateBox(Vec3{X: 0.5, Y: 5, Z: 5})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 1.05, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	// Floating next to the wall without moving
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 0, Z: 0})
+	defer character.Destroy()
+	character.Update(1.0/60.0, Vec3{})
+
+	all := character.GetActiveContacts(256)
+	for _, contact := range all {
+		if contact.BodyB != nil {
+			contact.BodyB.Destroy()
+		}
+	}
+	if len(all) == 0 {
+		t.Fatal("Expected a predictive contact with the nearby wall")
+	}
+
+	if colliding := character.GetCollidingContacts(256); len(colliding) != 0 {
+		t.Errorf("Got %d colliding contacts, expected none while not touching the wall", len(colliding))
+	}
+
+	// Walking into the wall makes it a colliding contact
+	character.SetLinearVelocity(Vec3{X: 5, Y: 0, Z: 0})
+	character.Update(1.0/60.0, Vec3{})
+
+	colliding := character.GetCollidingContacts(256)
+	if len(colliding) == 0 {
+		t.Fatal("Expected a colliding contact after walking into the wall")
+	}
+	for _, contact := range colliding {
+		if !contact.HadCollision {
+			t.Error("GetCollidingContacts returned a contact without HadCollision")
+		}
+		if contact.BodyB != nil {
+			contact.BodyB.Destroy()
+		}
+	}
+}
+
+func TestCharacterVirtualCenterOfMassTransform(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	settings := NewCharacterVirtualSettings(capsule)
+	settings.ShapeOffset = Vec3{X: 0, Y: 1.4, Z: 0}
+	settings.CharacterPadding = 0
+	position := Vec3{X: 1, Y: 5, Z: 2}
+	character := ps.CreateCharacterVirtual(settings, position)
+	defer character.Destroy()
+
+	com := character.GetCenterOfMassPosition()
+	if got := com.Sub(character.GetPosition()); got.Sub(settings.ShapeOffset).Length() > 1e-4 {
+		t.Errorf("Center of mass - position = %v, expected the shape offset %v", got, settings.ShapeOffset)
+	}
+
+	world := character.GetWorldTransform()
+	if got := (Vec3{X: world[12], Y: world[13], Z: world[14]}); got.Sub(position).Length() > 1e-4 {
+		t.Errorf("World transform translation = %v, expected %v", got, position)
+	}
+
+	comTransform := character.GetCenterOfMassTransform()
+	if got := (Vec3{X: comTransform[12], Y: comTransform[13], Z: comTransform[14]}); got.Sub(com).Length() > 1e-4 {
+		t.Errorf("Center of mass transform translation = %v, expected %v", got, com)
+	}
+}
+
+func TestCharacterVirtualSurfaceMaterialIndex(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Two quads meeting at X = 0: grass (material 0) on the left, rock (material 1) on the right
+	vertices := []Vec3{
+		{X: -10, Y: 0, Z: -10}, {X: -10, Y: 0, Z: 10}, {X: 0, Y: 0, Z: 10}, {X: 0, Y: 0, Z: -10},
+		{X: 10, Y: 0, Z: 10}, {X: 10, Y: 0, Z: -10},
+	}
+	indices := []int32{0, 1, 2, 0, 2, 3, 3, 2, 4, 3, 4, 5}
+	terrain, err := CreateMeshWithMaterials(vertices, indices, []int32{0, 0, 1, 1})
+	if err != nil {
+		t.Fatalf("CreateMeshWithMaterials failed: %v", err)
+	}
+	defer terrain.Destroy()
+
+	bi := ps.GetBodyInterface()
+	terrainID := bi.CreateBody(terrain, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer terrainID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: -5, Y: 1.4, Z: 0})
+	defer character.Destroy()
+
+	standingOn := func() int {
+		for i := 0; i < 10; i++ {
+			character.Update(1.0/60.0, ps.GetGravity())
+		}
+		index := -1
+		for _, c := range character.GetActiveContacts(256) {
+			if c.BodyB != nil {
+				c.BodyB.Destroy()
+			}
+			if c.HadCollision {
+				index = c.SurfaceMaterialIndex
+			}
+		}
+		return index
+	}
+
+	if got := standingOn(); got != 0 {
+		t.Errorf("SurfaceMaterialIndex = %d on the left half, expected 0", got)
+	}
+	character.SetPosition(Vec3{X: 5, Y: 1.4, Z: 0})
+	if got := standingOn(); got != 1 {
+		t.Errorf("SurfaceMaterialIndex = %d on the right half, expected 1", got)
+	}
+}
+
+func TestCharacterVirtualAppliedImpulse(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Light box just beyond the capsule's radius
+	box := CreateBox(Vec3{X: 0.25, Y: 0.25, Z: 0.25})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0.8, Y: 0.75, Z: 0}, MotionTypeDynamic, false)
+	defer boxID.Destroy()
+	bi.ActivateBody(boxID)
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	var pushed Vec3
+	for i := 0; i < 10 && pushed.Length() == 0; i++ {
+		character.SetLinearVelocity(Vec3{X: 3, Y: 0, Z: 0})
+		character.Update(1.0/60.0, ps.GetGravity())
+		for _, c := range character.GetActiveContacts(256) {
+			if c.BodyB == nil {
+				continue
+			}
+			if c.BodyB.GetIndexAndSequenceNumber() == boxID.GetIndexAndSequenceNumber() {
+				pushed = c.AppliedImpulse
+			} else if c.AppliedImpulse.Length() != 0 {
+				t.Errorf("AppliedImpulse = %v on the static floor, expected zero", c.AppliedImpulse)
+			}
+			c.BodyB.Destroy()
+		}
+		ps.Update(1.0 / 60.0)
+	}
+
+	if pushed.X <= 0 {
+		t.Errorf("AppliedImpulse = %v on the box, expected a push along +X", pushed)
+	}
+
+	// Walking away from the box applies no impulse, even though the box is still moving
+	character.SetLinearVelocity(Vec3{X: -3, Y: 0, Z: 0})
+	character.Update(1.0/60.0, ps.GetGravity())
+	for _, c := range character.GetActiveContacts(256) {
+		if c.BodyB == nil {
+			continue
+		}
+		if c.AppliedImpulse.Length() != 0 {
+			t.Errorf("AppliedImpulse = %v while walking away, expected zero", c.AppliedImpulse)
+		}
+		c.BodyB.Destroy()
+	}
+}
+
+func TestCharacterVirtualSaveRestoreState(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 1, Y: 5, Z: 2})
+	defer character.Destroy()
+	character.SetLinearVelocity(Vec3{X: 3, Y: 0, Z: -1})
+
+	state := character.SaveState()
+	if len(state) == 0 {
+		t.Fatal("SaveState returned no data")
+	}
+
+	character.SetPosition(Vec3{X: -10, Y: 0, Z: 0})
+	character.SetLinearVelocity(Vec3{X: 0, Y: 7, Z: 0})
+
+	if err := character.RestoreState(state); err != nil {
+		t.Fatalf("RestoreState failed: %v", err)
+	}
+	if pos := character.GetPosition(); pos.Sub(Vec3{X: 1, Y: 5, Z: 2}).Length() > 1e-5 {
+		t.Errorf("Position = %v after restore, expected {1 5 2}", pos)
+	}
+	if vel := character.GetLinearVelocity(); vel.Sub(Vec3{X: 3, Y: 0, Z: -1}).Length() > 1e-5 {
+		t.Errorf("Velocity = %v after restore, expected {3 0 -1}", vel)
+	}
+
+	if err := character.RestoreState(state[:len(state)/2]); err == nil {
+		t.Error("Expected an error restoring a truncated state")
+	}
+	if err := character.RestoreState(nil); err == nil {
+		t.Error("Expected an error restoring an empty state")
+	}
+
+	// Corrupt data is rejected and leaves the character where it was
+	corrupt := append([]byte(nil), state...)
+	corrupt[len(corrupt)-1] ^= 0xff
+	if err := character.RestoreState(corrupt); err == nil {
+		t.Error("Expected an error restoring a corrupt state")
+	}
+	if pos := character.GetPosition(); pos.Sub(Vec3{X: 1, Y: 5, Z: 2}).Length() > 1e-5 {
+		t.Errorf("Position = %v after a failed restore, expected it unchanged at {1 5 2}", pos)
+	}
+}
+
+func TestCharacterVirtualSimulateMove(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Wall face at X = 0.6, just beyond the capsule's radius
+	wall := CreateBox(Vec3{X: 0.5, Y: 5, Z: 5})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 1.1, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	gravity := Vec3{X: 0, Y: -9.81, Z: 0}
+	const dt = 1.0 / 60.0
+	character.Update(dt, gravity)
+
+	start := character.GetPosition()
+	velocity := Vec3{X: 30, Y: 0, Z: 0}
+	predicted := character.SimulateMove(dt, velocity, gravity)
+
+	if pos := character.GetPosition(); pos != start {
+		t.Errorf("Position = %v after SimulateMove, expected it unchanged at %v", pos, start)
+	}
+	if vel := character.GetLinearVelocity(); vel.Length() > 1e-5 {
+		t.Errorf("Velocity = %v after SimulateMove, expected it unchanged", vel)
+	}
+	if requested := start.X + 30*dt; predicted.X >= requested-0.01 {
+		t.Errorf("Predicted X = %.3f, expected the wall to stop it before %.3f", predicted.X, requested)
+	}
+
+	character.SetLinearVelocity(velocity)
+	character.Update(dt, gravity)
+	if actual := character.GetPosition(); actual.Sub(predicted).Length() > 1e-4 {
+		t.Errorf("Position = %v after Update, expected the predicted %v", actual, predicted)
+	}
+}
+
+func TestCharacterVirtualSimulateMoveInnerBody(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	settings := NewCharacterVirtualSettings(capsule)
+	settings.CreateInnerBody = true
+	character := ps.CreateCharacterVirtual(settings, Vec3{X: 0, Y: 10, Z: 0})
+	defer character.Destroy()
+
+	innerID := character.GetInnerBodyID()
+	if innerID == nil {
+		t.Fatal("Expected an inner body with CreateInnerBody")
+	}
+	defer innerID.Destroy()
+
+	bi := ps.GetBodyInterface()
+	innerStart := bi.GetPosition(innerID)
+
+	// The simulated move must neither collide with nor move the character's own inner body
+	const dt = 1.0 / 60.0
+	predicted := character.SimulateMove(dt, Vec3{X: 6, Y: 0, Z: 0}, Vec3{X: 0, Y: -9.81, Z: 0})
+	if math.Abs(float64(predicted.X-6*dt)) > 1e-3 {
+		t.Errorf("Predicted X = %.4f in open space, expected %.4f", predicted.X, 6*dt)
+	}
+	if pos := bi.GetPosition(innerID); pos.Sub(innerStart).Length() > 1e-5 {
+		t.Errorf("Inner body position = %v after SimulateMove, expected it unchanged at %v", pos, innerStart)
+	}
+}
+
+func TestCharacterVirtualGetLastStairWalkResult(t *testing.T) {
+	// Walks into a step of the given height and returns the stair walk results of all updates
+	walkIntoStep := func(height float32) []StairWalkInfo {
+		ps := NewPhysicsSystem()
+		defer ps.Destroy()
+
+		bi := ps.GetBodyInterface()
+		floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+		defer floor.Destroy()
+		floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+		defer floorID.Destroy()
+
+		// Step face at X = 0.6, just beyond the capsule's radius
+		step := CreateBox(Vec3{X: 2, Y: height / 2, Z: 5})
+		defer step.Destroy()
+		stepID := bi.CreateBody(step, Vec3{X: 2.6, Y: height / 2, Z: 0}, MotionTypeStatic, false)
+		defer stepID.Destroy()
+
+		capsule := CreateCapsule(0.9, 0.5)
+		defer capsule.Destroy()
+		character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.4, Z: 0})
+		defer character.Destroy()
+
+		if info := character.GetLastStairWalkResult(); info != (StairWalkInfo{}) {
+			t.Errorf("GetLastStairWalkResult() = %+v before any update, expected the zero value", info)
+		}
+
+		gravity := Vec3{X: 0, Y: -9.81, Z: 0}
+		var results []StairWalkInfo
+		for i := 0; i < 30; i++ {
+			character.SetLinearVelocity(Vec3{X: 3, Y: 0, Z: 0})
+			character.ExtendedUpdate(1.0/60.0, gravity)
+			results = append(results, character.GetLastStairWalkResult())
+		}
+		return results
+	}
+
+	// Below the default maximum step height of 0.4
+	var climbed bool
+	for _, info := range walkIntoStep(0.3) {
+		if info.Succeeded {
+			climbed = true
+			if !info.Attempted {
+				t.Error("Succeeded = true with Attempted = false")
+			}
+			if info.StepHeight <= 0 || info.StepHeight > 0.35 {
+				t.Errorf("StepHeight = %.3f, expected up to the 0.3 step", info.StepHeight)
+			}
+			break
+		}
+	}
+	if !climbed {
+		t.Error("Expected the character to walk up a 0.3 step")
+	}
+
+	// Above the maximum step height
+	var attempted bool
+	for _, info := range walkIntoStep(0.5) {
+		attempted = attempted || info.Attempted
+		if info.Succeeded {
+			t.Errorf("Succeeded = true for a 0.5 step (StepHeight %.3f), expected it to be too high", info.StepHeight)
+			break
+		}
+	}
+	if !attempted {
+		t.Error("Expected the character to attempt walking up the 0.5 step")
+	}
+}
+
+func TestCharacterVirtualExtendedUpdateZUp(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetWorldUp(Vec3{X: 0, Y: 0, Z: 1})
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 10, Z: 0.5})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: -0.5}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// 0.3 high step with its face at X = 0.6, just beyond the sphere's radius
+	step := CreateBox(Vec3{X: 2, Y: 5, Z: 0.15})
+	defer step.Destroy()
+	stepID := bi.CreateBody(step, Vec3{X: 2.6, Y: 0, Z: 0.15}, MotionTypeStatic, false)
+	defer stepID.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	settings := NewCharacterVirtualSettings(sphere)
+	settings.Up = ps.GetWorldUp()
+	character := ps.CreateCharacterVirtual(settings, Vec3{X: 0, Y: 0, Z: 0.5})
+	defer character.Destroy()
+
+	var climbed bool
+	for i := 0; i < 60 && !climbed; i++ {
+		character.SetLinearVelocity(Vec3{X: 3, Y: 0, Z: 0})
+		character.ExtendedUpdate(1.0/60.0, ps.GetGravity())
+		climbed = character.GetLastStairWalkResult().Succeeded
+	}
+
+	if !climbed {
+		t.Error("Expected the character to walk up a 0.3 step along Z")
+	}
+	if pos := character.GetPosition(); math.Abs(float64(pos.Y)) > 1e-3 {
+		t.Errorf("Position Y = %.3f, expected the step up to stay along Z", pos.Y)
+	}
+	if got := character.GetGroundState(); got != GroundStateOnGround {
+		t.Errorf("GetGroundState() = %v, expected OnGround", got)
+	}
+}
+
+func TestCharacterVirtualGetLastStairWalkResultOnRamp(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Walkable 20° ramp rising along +X
+	bi := ps.GetBodyInterface()
+	ramp := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer ramp.Destroy()
+	rampID := bi.CreateBody(ramp, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer rampID.Destroy()
+	bi.SetRotation(rampID, QuatFromAxisAngle(Vec3{X: 0, Y: 0, Z: 1}, 20*math.Pi/180))
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.5, Z: 0})
+	defer character.Destroy()
+
+	gravity := Vec3{X: 0, Y: -9.81, Z: 0}
+	for i := 0; i < 30; i++ {
+		character.SetLinearVelocity(Vec3{X: 3, Y: 0, Z: 0})
+		character.ExtendedUpdate(1.0/60.0, gravity)
+		if info := character.GetLastStairWalkResult(); info.Succeeded {
+			t.Fatalf("GetLastStairWalkResult() = %+v on a walkable ramp, expected no step", info)
+		}
+	}
+}
+
+// TestCharacterVirtualMethodSet exercises the ground, shape, contact and velocity methods
+// together on the single CharacterVirtual type in this package.
+func TestCharacterVirtualMethodSet(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	character.SetLinearVelocity(Vec3{X: 1, Y: 0, Z: 0})
+	if vel := character.GetLinearVelocity(); math.Abs(float64(vel.X-1)) > 1e-4 {
+		t.Errorf("GetLinearVelocity().X = %.2f, expected 1", vel.X)
+	}
+
+	for i := 0; i < 10; i++ {
+		character.Update(1.0/60.0, ps.GetGravity())
+	}
+
+	if state := character.GetGroundState(); state != GroundStateOnGround {
+		t.Fatalf("GetGroundState() = %v, expected %v", state, GroundStateOnGround)
+	}
+	if normal := character.GetGroundNormal(); normal.Y < 0.99 {
+		t.Errorf("GetGroundNormal() = %v, expected up", normal)
+	}
+	if ground := character.GetGroundPosition(); math.Abs(float64(ground.Y-0.5)) > 0.05 {
+		t.Errorf("GetGroundPosition().Y = %.2f, expected the floor top at 0.5", ground.Y)
+	}
+
+	contacts := character.GetActiveContacts(256)
+	for _, c := range contacts {
+		if c.BodyB != nil {
+			defer c.BodyB.Destroy()
+		}
+	}
+	if len(contacts) == 0 {
+		t.Error("GetActiveContacts() returned no contacts, expected the floor")
+	}
+
+	// Swap to a smaller shape that fits at the current position
+	small := CreateCapsule(0.5, 0.5)
+	defer small.Destroy()
+	character.SetShape(small, 0.1)
+	if got := character.GetShape(); got.handle != small.handle {
+		t.Error("GetShape() did not return the shape passed to SetShape()")
+	}
+}
+
+func TestCharacterVirtualDidMaxHitsExceed(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// A ring of small boxes touching the capsule
+	pebble := CreateBox(Vec3{X: 0.1, Y: 0.1, Z: 0.1})
+	defer pebble.Destroy()
+	for i := 0; i < 12; i++ {
+		angle := float64(i) * 2 * math.Pi / 12
+		pos := Vec3{X: float32(0.6 * math.Cos(angle)), Y: 1.2, Z: float32(0.6 * math.Sin(angle))}
+		bodyID := bi.CreateBody(pebble, pos, MotionTypeStatic, false)
+		defer bodyID.Destroy()
+	}
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	character.Update(1.0/60.0, ps.GetGravity())
+	if character.DidMaxHitsExceed() {
+		t.Errorf("DidMaxHitsExceed() = true with MaxNumHits %d, expected false", character.GetMaxNumHits())
+	}
+
+	character.SetMaxNumHits(2)
+	character.Update(1.0/60.0, ps.GetGravity())
+	if !character.DidMaxHitsExceed() {
+		t.Error("DidMaxHitsExceed() = false with MaxNumHits 2 and 13 bodies nearby, expected true")
+	}
+}
+
+func TestCharacterVirtualForEachActiveContact(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// A wall right next to the character gives it a second contact
+	wall := CreateBox(Vec3{X: 0.5, Y: 2, Z: 2})
+	defer wall.Destroy()
+	wallID := bi.CreateBody(wall, Vec3{X: 1.05, Y: 2, Z: 0}, MotionTypeStatic, false)
+	defer wallID.Destroy()
+
+	capsule := CreateCapsule(0.9, 0.5)
+	defer capsule.Destroy()
+
+	character := ps.CreateCharacterVirtual(NewCharacterVirtualSettings(capsule), Vec3{X: 0, Y: 1.9, Z: 0})
+	defer character.Destroy()
+
+	for i := 0; i < 10; i++ {
+		character.Update(1.0/60.0, ps.GetGravity())
+	}
+
+	contacts := character.GetActiveContacts(256)
+	for _, c := range contacts {
+		if c.BodyB != nil {
+			defer c.BodyB.Destroy()
+		}
+	}
+	if len(contacts) < 2 {
+		t.Fatalf("len(GetActiveContacts()) = %d, expected the floor and the wall", len(contacts))
+	}
+
+	// Visiting every contact matches GetActiveContacts
+	visited := 0
+	character.ForEachActiveContact(func(contact CharacterContact) bool {
+		if contact.ContactID != contacts[visited].ContactID {
+			t.Errorf("Contact %d ID = %d, expected %d", visited, contact.ContactID, contacts[visited].ContactID)
+		}
+		if contact.BodyB != nil {
+			got := contact.BodyB.GetIndexAndSequenceNumber()
+			expected := contacts[visited].BodyB.GetIndexAndSequenceNumber()
+			if got != expected {
+				t.Errorf("Contact %d BodyB = %d, expected %d", visited, got, expected)
+			}
+			// The borrowed body ID can't be destroyed by the callback
+			contact.BodyB.Destroy()
+			if contact.BodyB.GetIndexAndSequenceNumber() != expected {
+				t.Errorf("Contact %d BodyB changed after Destroy, expected Destroy to be a no-op", visited)
+			}
+		}
+		visited++
+		return true
+	})
+	if visited != len(contacts) {
+		t.Errorf("Visited %d contacts, expected %d", visited, len(contacts))
+	}
+
+	// Returning false stops after the first contact
+	calls := 0
+	character.ForEachActiveContact(func(contact CharacterContact) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("fn called %d times after returning false, expected 1", calls)
+	}
+}
@@ -0,0 +1,254 @@
+package jolt
+
+// #include "wrapper/constraint.h"
+import "C"
+import (
+	"math"
+	"runtime"
+)
+
+// Constraint connects two bodies and restricts their relative motion (a joint)
+type Constraint struct {
+	handle     C.JoltConstraint
+	ps         *PhysicsSystem
+	breakForce float32 // Force threshold in Newtons, 0 = unbreakable
+}
+
+// Destroy removes the constraint from the physics system and frees it
+func (c *Constraint) Destroy() {
+	delete(c.ps.breakableConstraints, c)
+	C.JoltDestroyConstraint(c.ps.handle, c.handle)
+}
+
+// SetBreakForce makes the constraint breakable. When the force needed to hold the bodies together
+// exceeds the threshold during Update, the constraint is disabled and the callback registered with
+// PhysicsSystem.SetConstraintBrokenCallback is invoked.
+// force: threshold in Newtons (0 makes the constraint unbreakable again)
+//
+// Note: Only swing-twist and six-DOF constraints report their applied force
+func (c *Constraint) SetBreakForce(force float32) {
+	c.breakForce = force
+	if force > 0 {
+		c.ps.breakableConstraints[c] = struct{}{}
+	} else {
+		delete(c.ps.breakableConstraints, c)
+	}
+}
+
+// GetBreakForce returns the break force threshold in Newtons (0 if unbreakable)
+func (c *Constraint) GetBreakForce() float32 {
+	return c.breakForce
+}
+
+// SetConstraintBrokenCallback sets the function called from Update when a breakable constraint
+// exceeds its break force. The constraint is already disabled when the callback runs; it can be
+// re-enabled or destroyed from within the callback.
+func (ps *PhysicsSystem) SetConstraintBrokenCallback(callback func(c *Constraint)) {
+	ps.constraintBroken = callback
+}
+
+// breakConstraints disables breakable constraints whose applied force exceeded their threshold
+// during the last step of stepTime seconds. Jolt reports the impulse of the last of the step's
+// collisionSteps, so the force is that impulse divided by the collision step duration.
+func (ps *PhysicsSystem) breakConstraints(stepTime float32, collisionSteps int) {
+	if len(ps.breakableConstraints) == 0 || stepTime <= 0 || collisionSteps < 1 {
+		return
+	}
+	collisionStepTime := stepTime / float32(collisionSteps)
+
+	var broken []*Constraint
+	for c := range ps.breakableConstraints {
+		if !c.IsEnabled() {
+			continue
+		}
+		force := float32(C.JoltConstraintGetTotalLambdaPosition(c.handle)) / collisionStepTime
+		if force > c.breakForce {
+			broken = append(broken, c)
+		}
+	}
+
+	// Disable all broken constraints before running callbacks, which may destroy them
+	for _, c := range broken {
+		c.SetEnabled(false)
+	}
+	if ps.constraintBroken != nil {
+		for _, c := range broken {
+			ps.constraintBroken(c)
+		}
+	}
+}
+
+// SetEnabled enables or disables the constraint without destroying it.
+// A disabled constraint lets the bodies move freely; enabling it again wakes up both bodies.
+func (c *Constraint) SetEnabled(enabled bool) {
+	C.JoltConstraintSetEnabled(c.ps.handle, c.handle, C.int(boolToInt(enabled)))
+}
+
+// IsEnabled returns true if the constraint is enabled
+func (c *Constraint) IsEnabled() bool {
+	return C.JoltConstraintIsEnabled(c.handle) != 0
+}
+
+// GetBodies returns the two bodies connected by the constraint.
+// The returned body IDs must be freed with Destroy.
+func (c *Constraint) GetBodies() (a, b *BodyID) {
+	var cA, cB C.JoltBodyID
+	C.JoltConstraintGetBodies(c.handle, &cA, &cB)
+	return newBodyID(cA), newBodyID(cB)
+}
+
+// CreateSwingTwistConstraint creates a cone-twist joint between two bodies, the core joint for
+// ragdoll shoulders and hips. The constraint is added to the physics system immediately.
+//
+// Parameters:
+//   - a, b: The bodies to connect
+//   - pivot: Shared attachment point in world space
+//   - twistAxis: Twist axis in world space, the swing cone is centered around it
+//   - halfConeAngle: Half angle of the swing cone in radians
+//   - minTwist, maxTwist: Twist limits around the twist axis in radians
+//
+// Returns nil if either body doesn't exist.
+//
+// Example:
+//
+//	// Upper arm hanging from the torso, can swing 30 degrees and twist +/- 45 degrees
+//	shoulder := ps.CreateSwingTwistConstraint(torso, upperArm,
+//	    jolt.Vec3{X: 0.3, Y: 1.5, Z: 0}, jolt.Vec3{X: 0, Y: -1, Z: 0},
+//	    jolt.DegreesToRadians(30), jolt.DegreesToRadians(-45), jolt.DegreesToRadians(45))
+//	defer shoulder.Destroy()
+func (ps *PhysicsSystem) CreateSwingTwistConstraint(a, b *BodyID, pivot Vec3, twistAxis Vec3, halfConeAngle, minTwist, maxTwist float32) *Constraint {
+	handle := C.JoltCreateSwingTwistConstraint(
+		ps.handle,
+		a.handle,
+		b.handle,
+		C.float(pivot.X),
+		C.float(pivot.Y),
+		C.float(pivot.Z),
+		C.float(twistAxis.X),
+		C.float(twistAxis.Y),
+		C.float(twistAxis.Z),
+		C.float(halfConeAngle),
+		C.float(minTwist),
+		C.float(maxTwist),
+	)
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+	if handle == nil {
+		return nil
+	}
+	return &Constraint{handle: handle, ps: ps}
+}
+
+// SixDOFAxis identifies one degree of freedom of a six-DOF constraint
+type SixDOFAxis int
+
+const (
+	SixDOFAxisTranslationX SixDOFAxis = C.JoltSixDOFAxisTranslationX // Translation along X
+	SixDOFAxisTranslationY SixDOFAxis = C.JoltSixDOFAxisTranslationY // Translation along Y
+	SixDOFAxisTranslationZ SixDOFAxis = C.JoltSixDOFAxisTranslationZ // Translation along Z
+	SixDOFAxisRotationX    SixDOFAxis = C.JoltSixDOFAxisRotationX    // Rotation around X (twist)
+	SixDOFAxisRotationY    SixDOFAxis = C.JoltSixDOFAxisRotationY    // Rotation around Y (swing)
+	SixDOFAxisRotationZ    SixDOFAxis = C.JoltSixDOFAxisRotationZ    // Rotation around Z (swing)
+
+	numSixDOFAxes = int(C.JoltSixDOFAxisNum)
+)
+
+// SixDOFMotor configures a velocity motor on a single six-DOF axis
+type SixDOFMotor struct {
+	// Enabled turns the motor on
+	Enabled bool
+	// TargetVelocity is the velocity the motor drives towards (m/s for translation, rad/s for rotation)
+	TargetVelocity float32
+	// MaxForce is the maximum force (N) or torque (N m) the motor can apply
+	MaxForce float32
+}
+
+// SixDOFConfig configures the limits and motors of a six-DOF constraint.
+// Arrays are indexed by SixDOFAxis. Translation limits are in meters, rotation limits in radians.
+// An axis with LimitMin >= LimitMax is locked.
+//
+// Note: Jolt requires the swing limits (RotationY, RotationZ) to be symmetric around zero.
+type SixDOFConfig struct {
+	// LimitMin is the lower limit per axis
+	LimitMin [numSixDOFAxes]float32
+	// LimitMax is the upper limit per axis
+	LimitMax [numSixDOFAxes]float32
+	// Motors are the optional velocity motors per axis
+	Motors [numSixDOFAxes]SixDOFMotor
+}
+
+// NewSixDOFConfig creates a configuration with all axes free
+func NewSixDOFConfig() SixDOFConfig {
+	var cfg SixDOFConfig
+	for i := 0; i < numSixDOFAxes; i++ {
+		cfg.MakeFreeAxis(SixDOFAxis(i))
+	}
+	return cfg
+}
+
+// MakeFreeAxis removes all limits from an axis
+func (cfg *SixDOFConfig) MakeFreeAxis(axis SixDOFAxis) {
+	cfg.LimitMin[axis] = -math.MaxFloat32
+	cfg.LimitMax[axis] = math.MaxFloat32
+}
+
+// MakeFixedAxis locks an axis
+func (cfg *SixDOFConfig) MakeFixedAxis(axis SixDOFAxis) {
+	cfg.LimitMin[axis] = math.MaxFloat32
+	cfg.LimitMax[axis] = -math.MaxFloat32
+}
+
+// SetLimitedAxis limits an axis to the range [min, max]
+func (cfg *SixDOFConfig) SetLimitedAxis(axis SixDOFAxis, min, max float32) {
+	cfg.LimitMin[axis] = min
+	cfg.LimitMax[axis] = max
+}
+
+// CreateSixDOFConstraint creates a fully configurable joint between two bodies, covering cases the
+// simpler constraints don't (suspension, custom mechanisms). The constraint is added to the physics
+// system immediately. Constraint axes are aligned with the world axes.
+//
+// Parameters:
+//   - a, b: The bodies to connect
+//   - position: Shared attachment point in world space
+//   - cfg: Limits and motors per axis
+//
+// Returns nil if either body doesn't exist.
+//
+// Example:
+//
+//	// Slider that only moves along X
+//	cfg := jolt.NewSixDOFConfig()
+//	cfg.MakeFixedAxis(jolt.SixDOFAxisTranslationY)
+//	cfg.MakeFixedAxis(jolt.SixDOFAxisTranslationZ)
+//	cfg.MakeFixedAxis(jolt.SixDOFAxisRotationX)
+//	cfg.MakeFixedAxis(jolt.SixDOFAxisRotationY)
+//	cfg.MakeFixedAxis(jolt.SixDOFAxisRotationZ)
+//	slider := ps.CreateSixDOFConstraint(rail, carriage, jolt.Vec3{X: 0, Y: 1, Z: 0}, cfg)
+//	defer slider.Destroy()
+func (ps *PhysicsSystem) CreateSixDOFConstraint(a, b *BodyID, position Vec3, cfg SixDOFConfig) *Constraint {
+	var cConfig C.JoltSixDOFConfig
+	for i := 0; i < numSixDOFAxes; i++ {
+		cConfig.limitMin[i] = C.float(cfg.LimitMin[i])
+		cConfig.limitMax[i] = C.float(cfg.LimitMax[i])
+		cConfig.motorEnabled[i] = C.int(boolToInt(cfg.Motors[i].Enabled))
+		cConfig.motorTargetVelocity[i] = C.float(cfg.Motors[i].TargetVelocity)
+		cConfig.motorMaxForce[i] = C.float(cfg.Motors[i].MaxForce)
+	}
+
+	handle := C.JoltCreateSixDOFConstraint(
+		ps.handle,
+		a.handle,
+		b.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		&cConfig,
+	)
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+	if handle == nil {
+		return nil
+	}
+	return &Constraint{handle: handle, ps: ps}
+}
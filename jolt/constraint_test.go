@@ -0,0 +1,236 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSwingTwistConstraintLimitsCone(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	sphere := CreateSphere(0.2)
+	defer sphere.Destroy()
+
+	// Static anchor with a child sticking out horizontally along +X
+	anchor := bi.CreateBody(sphere, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer anchor.Destroy()
+	child := bi.CreateBody(sphere, Vec3{X: 1, Y: 5, Z: 0}, MotionTypeDynamic, false)
+	defer child.Destroy()
+	bi.ActivateBody(child)
+
+	halfCone := DegreesToRadians(30)
+	constraint := ps.CreateSwingTwistConstraint(anchor, child,
+		Vec3{X: 0, Y: 5, Z: 0}, Vec3{X: 1, Y: 0, Z: 0}, halfCone, 0, 0)
+	if constraint == nil {
+		t.Fatal("CreateSwingTwistConstraint returned nil")
+	}
+	defer constraint.Destroy()
+
+	for i := 0; i < 120; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	// Gravity swings the child down, but the cone stops it at 30 degrees below horizontal
+	pos := bi.GetPosition(child)
+	minY := 5 - float32(math.Sin(float64(halfCone))) - 0.1
+	if pos.Y < minY {
+		t.Errorf("Child Y = %.2f, expected >= %.2f (swung past the cone)", pos.Y, minY)
+	}
+	if pos.Y > 4.9 {
+		t.Errorf("Child Y = %.2f, expected the child to swing down under gravity", pos.Y)
+	}
+}
+
+func TestSixDOFConstraintSlidesOnX(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.2, Y: 0.2, Z: 0.2})
+	defer box.Destroy()
+
+	anchor := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer anchor.Destroy()
+	slider := bi.CreateBody(box, Vec3{X: 1, Y: 5, Z: 0}, MotionTypeDynamic, false)
+	defer slider.Destroy()
+	bi.ActivateBody(slider)
+
+	// Lock everything except translation on X, and drive X with a motor
+	cfg := NewSixDOFConfig()
+	cfg.MakeFixedAxis(SixDOFAxisTranslationY)
+	cfg.MakeFixedAxis(SixDOFAxisTranslationZ)
+	cfg.MakeFixedAxis(SixDOFAxisRotationX)
+	cfg.MakeFixedAxis(SixDOFAxisRotationY)
+	cfg.MakeFixedAxis(SixDOFAxisRotationZ)
+	cfg.Motors[SixDOFAxisTranslationX] = SixDOFMotor{Enabled: true, TargetVelocity: 1, MaxForce: 1000}
+
+	constraint := ps.CreateSixDOFConstraint(anchor, slider, Vec3{X: 1, Y: 5, Z: 0}, cfg)
+	if constraint == nil {
+		t.Fatal("CreateSixDOFConstraint returned nil")
+	}
+	defer constraint.Destroy()
+
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	// Gravity must not pull the body down, the motor slides it ~1 unit along X
+	pos := bi.GetPosition(slider)
+	if math.Abs(float64(pos.Y-5)) > 0.05 || math.Abs(float64(pos.Z)) > 0.05 {
+		t.Errorf("Slider moved off the X axis: %v", pos)
+	}
+	if pos.X < 1.5 {
+		t.Errorf("Slider X = %.2f, expected it to slide along X", pos.X)
+	}
+}
+
+func TestConstraintSetEnabled(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	anchor := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer anchor.Destroy()
+	welded := bi.CreateBody(box, Vec3{X: 1, Y: 5, Z: 0}, MotionTypeDynamic, false)
+	defer welded.Destroy()
+	bi.ActivateBody(welded)
+
+	// Zero-value config locks every axis, welding the bodies together
+	weld := ps.CreateSixDOFConstraint(anchor, welded, Vec3{X: 0.5, Y: 5, Z: 0}, SixDOFConfig{})
+	if weld == nil {
+		t.Fatal("CreateSixDOFConstraint returned nil")
+	}
+	defer weld.Destroy()
+
+	a, b := weld.GetBodies()
+	defer a.Destroy()
+	defer b.Destroy()
+	if bi.GetPosition(a) != bi.GetPosition(anchor) || bi.GetPosition(b) != bi.GetPosition(welded) {
+		t.Error("GetBodies should return the anchor and welded bodies in order")
+	}
+
+	step := func(n int) {
+		for i := 0; i < n; i++ {
+			ps.Update(1.0 / 60.0)
+		}
+	}
+
+	step(30)
+	if !weld.IsEnabled() {
+		t.Fatal("Constraint should be enabled after creation")
+	}
+	if y := bi.GetPosition(welded).Y; math.Abs(float64(y-5)) > 0.05 {
+		t.Fatalf("Welded body Y = %.2f, expected it to hang at 5", y)
+	}
+
+	weld.SetEnabled(false)
+	if weld.IsEnabled() {
+		t.Fatal("Constraint should be disabled")
+	}
+	step(10)
+	if y := bi.GetPosition(welded).Y; y > 4.9 {
+		t.Errorf("Welded body Y = %.2f, expected it to fall away from the anchor while the weld is disabled", y)
+	}
+
+	weld.SetEnabled(true)
+	if !weld.IsEnabled() {
+		t.Error("Constraint should be enabled again")
+	}
+	step(60)
+	if pos := bi.GetPosition(welded); pos.Sub(Vec3{X: 1, Y: 5, Z: 0}).Length() > 0.05 {
+		t.Errorf("Welded body position = %v after re-enabling, expected the weld to hold it at {1, 5, 0}", pos)
+	}
+}
+
+func TestConstraintBreakForce(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	anchor := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer anchor.Destroy()
+	welded := bi.CreateBody(box, Vec3{X: 1, Y: 5, Z: 0}, MotionTypeDynamic, false)
+	defer welded.Destroy()
+	bi.ActivateBody(welded)
+
+	weld := ps.CreateSixDOFConstraint(anchor, welded, Vec3{X: 0.5, Y: 5, Z: 0}, SixDOFConfig{})
+	if weld == nil {
+		t.Fatal("CreateSixDOFConstraint returned nil")
+	}
+	defer weld.Destroy()
+
+	// A 1m^3 box weighs ~9810 N at default density, far beyond the threshold
+	weld.SetBreakForce(1000)
+
+	var brokenCount int
+	ps.SetConstraintBrokenCallback(func(c *Constraint) {
+		if c != weld {
+			t.Error("Callback received an unexpected constraint")
+		}
+		brokenCount++
+	})
+
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	if brokenCount != 1 {
+		t.Errorf("Broken callback fired %d times, expected exactly once", brokenCount)
+	}
+	if weld.IsEnabled() {
+		t.Error("Broken constraint should be disabled")
+	}
+	if y := bi.GetPosition(welded).Y; y > 4.5 {
+		t.Errorf("Welded body Y = %.2f, expected it to fall after the weld broke", y)
+	}
+}
+
+func TestConstraintBreakForceCollisionSteps(t *testing.T) {
+	// A 1m^3 box weighs ~9810 N at default density: a weld holding it breaks below that
+	// force and holds above it, however many collision steps an update takes
+	for _, tc := range []struct {
+		breakForce float32
+		breaks     bool
+	}{
+		{breakForce: 8000, breaks: true},
+		{breakForce: 12000, breaks: false},
+	} {
+		ps := NewPhysicsSystem()
+		bi := ps.GetBodyInterface()
+		box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+
+		anchor := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeStatic, false)
+		welded := bi.CreateBody(box, Vec3{X: 1, Y: 5, Z: 0}, MotionTypeDynamic, false)
+		bi.ActivateBody(welded)
+
+		weld := ps.CreateSixDOFConstraint(anchor, welded, Vec3{X: 0.5, Y: 5, Z: 0}, SixDOFConfig{})
+		if weld == nil {
+			t.Fatal("CreateSixDOFConstraint returned nil")
+		}
+		weld.SetBreakForce(tc.breakForce)
+
+		for i := 0; i < 30; i++ {
+			if err := ps.UpdateWithSubsteps(1.0/30.0, 2); err != nil {
+				t.Fatalf("UpdateWithSubsteps failed: %v", err)
+			}
+		}
+
+		if broken := !weld.IsEnabled(); broken != tc.breaks {
+			t.Errorf("Weld with break force %.0f broken = %v with 2 collision steps, expected %v", tc.breakForce, broken, tc.breaks)
+		}
+
+		weld.Destroy()
+		welded.Destroy()
+		anchor.Destroy()
+		box.Destroy()
+		ps.Destroy()
+	}
+}
@@ -0,0 +1,276 @@
+package jolt
+
+// #include "wrapper/contact.h"
+import "C"
+import (
+	"runtime"
+	"runtime/cgo"
+)
+
+// ContactInfo describes a contact between two bodies, reported to a ContactListener
+type ContactInfo struct {
+	BodyA            *BodyID // First body, only valid during the callback (don't destroy or keep it)
+	BodyB            *BodyID // Second body, only valid during the callback (don't destroy or keep it)
+	SubShapeIDA      uint32  // Sub-shape ID of the part of body A that is touching
+	SubShapeIDB      uint32  // Sub-shape ID of the part of body B that is touching
+	ContactID        uint64  // Identifies the contact, the same in OnContactAdded and every OnContactPersisted that follows
+	Normal           Vec3    // Contact normal in world space, the direction to move body B out of collision
+	PenetrationDepth float32 // How deep the bodies overlap
+	PointsOnA        []Vec3  // Contact manifold points on body A in world space
+	PointsOnB        []Vec3  // Contact manifold points on body B in world space (same order as PointsOnA)
+
+	// ImpactImpulse estimates the total impulse (N·s) the solver will apply along the normal to resolve
+	// this contact. Use it to scale impact sounds or damage. It is computed with the contact settings
+	// before OnContactAdded or OnContactPersisted could change them.
+	ImpactImpulse float32
+
+	// Settings can be changed by OnContactAdded and OnContactPersisted to modify the contact
+	Settings *ContactSettings
+}
+
+// EstimateImpactImpulse returns the estimated impulse (N·s) needed to resolve the contact between
+// bodies a and b (in either order), the same as ContactInfo.ImpactImpulse. It may only be called from
+// the OnContactAdded or OnContactPersisted callback reporting that contact and returns 0 otherwise.
+//
+// Example:
+//
+//	OnContactAdded: func(contact jolt.ContactInfo) {
+//	    if impulse := ps.EstimateImpactImpulse(contact.BodyA, contact.BodyB); impulse > breakImpulse {
+//	        breakQueue <- contact.BodyA.GetIndexAndSequenceNumber()
+//	    }
+//	},
+func (ps *PhysicsSystem) EstimateImpactImpulse(a, b *BodyID) float32 {
+	impulse := C.JoltContactEstimateImpactImpulse(a.handle, b.handle)
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+	return float32(impulse)
+}
+
+// ContactSettings can be modified by OnContactAdded and OnContactPersisted to change how a contact is handled
+type ContactSettings struct {
+	// IsSensor turns the contact into a report-only contact: it keeps being reported,
+	// but the bodies pass through each other without a collision response
+	IsSensor bool
+
+	// CombinedFriction is the friction of the body pair, by default the geometric mean
+	// of both bodies' friction. Set it to 0 to let the bodies slide freely.
+	// Jolt recalculates the settings every step, so set overrides in both OnContactAdded
+	// and OnContactPersisted to keep them for the lifetime of the contact.
+	CombinedFriction float32
+
+	// CombinedRestitution is the restitution (bounciness) of the body pair,
+	// by default the larger of both bodies' restitution
+	CombinedRestitution float32
+}
+
+// ValidateResult is returned by OnContactValidate to accept or reject a contact
+type ValidateResult int
+
+const (
+	ValidateResultAcceptAllContacts ValidateResult = C.JoltValidateResultAcceptAllContacts // Accept this and further contacts for the body pair (default)
+	ValidateResultAcceptContact     ValidateResult = C.JoltValidateResultAcceptContact     // Accept this contact only
+	ValidateResultRejectContact     ValidateResult = C.JoltValidateResultRejectContact     // Reject this contact only
+	ValidateResultRejectAllContacts ValidateResult = C.JoltValidateResultRejectAllContacts // Reject this and further contacts for the body pair
+)
+
+// ContactListener receives contact events between bodies during Update.
+// Leave callbacks you don't need nil: only the set callbacks are called, which avoids
+// crossing from C++ into Go for events nobody listens to.
+//
+// Jolt calls the listener from its worker threads, possibly concurrently, so the callbacks
+// must be safe for concurrent use. Bodies are locked while the callbacks run, so they must
+// not call back into the physics system (e.g. BodyInterface methods); record what you need
+// and act on it after Update returns.
+type ContactListener struct {
+	// OnContactValidate is called before a contact is created and can reject it.
+	// Rejected contacts have no collision response and are not reported to the other callbacks;
+	// use ContactSettings.IsSensor instead to keep reporting a contact without a response.
+	OnContactValidate func(bodyA, bodyB *BodyID) ValidateResult
+	// OnContactAdded is called when two bodies start touching
+	OnContactAdded func(contact ContactInfo)
+	// OnContactPersisted is called every step while two bodies keep touching
+	OnContactPersisted func(contact ContactInfo)
+	// OnContactRemoved is called when two bodies stop touching.
+	// The body IDs are only valid during the callback and the bodies may already have been removed.
+	OnContactRemoved func(bodyA, bodyB *BodyID)
+}
+
+// SetContactListener sets the listener that receives contact events during Update.
+// Pass nil to remove the current listener.
+//
+// Example:
+//
+//	var mu sync.Mutex
+//	var impacts []jolt.Vec3
+//	ps.SetContactListener(&jolt.ContactListener{
+//	    OnContactAdded: func(contact jolt.ContactInfo) {
+//	        mu.Lock()
+//	        defer mu.Unlock()
+//	        impacts = append(impacts, contact.PointsOnA...)
+//	    },
+//	})
+func (ps *PhysicsSystem) SetContactListener(listener *ContactListener) {
+	ps.listener = listener
+	ps.applyContactListener()
+}
+
+// SetRecordContacts enables keeping the contacts found during each Update for GetContacts (default: false)
+//
+// Recording copies every contact of every step, so only enable it when you need it.
+// It works alongside a ContactListener set with SetContactListener.
+func (ps *PhysicsSystem) SetRecordContacts(enabled bool) {
+	ps.recordContacts = enabled
+	ps.applyContactListener()
+}
+
+// GetContacts returns the contacts that were added or persisted during the last Update,
+// including those of all its substeps (see SetMaxDeltaTime). Requires SetRecordContacts(true).
+//
+// Unlike in ContactListener callbacks, the returned BodyA and BodyB are owned by the caller
+// (call Destroy on them when done).
+//
+// Example:
+//
+//	ps.SetRecordContacts(true)
+//	ps.Update(1.0 / 60.0)
+//	for _, contact := range ps.GetContacts() {
+//	    if contact.PenetrationDepth > 0.1 {
+//	        log.Printf("deep contact at %v", contact.PointsOnA)
+//	    }
+//	    contact.BodyA.Destroy()
+//	    contact.BodyB.Destroy()
+//	}
+func (ps *PhysicsSystem) GetContacts() []ContactInfo {
+	numContacts := int(C.JoltPhysicsSystemGetNumRecordedContacts(ps.handle))
+	if numContacts == 0 {
+		return nil
+	}
+
+	cContacts := make([]C.JoltContactInfo, numContacts)
+	numContacts = int(C.JoltPhysicsSystemGetRecordedContacts(ps.handle, &cContacts[0], C.int(numContacts)))
+
+	contacts := make([]ContactInfo, numContacts)
+	for i := 0; i < numContacts; i++ {
+		contacts[i] = toContactInfo(&cContacts[i])
+		contacts[i].BodyA = newBodyID(cContacts[i].bodyA)
+		contacts[i].BodyB = newBodyID(cContacts[i].bodyB)
+	}
+	return contacts
+}
+
+// clearRecordedContacts forgets the contacts recorded by previous updates
+func (ps *PhysicsSystem) clearRecordedContacts() {
+	C.JoltPhysicsSystemClearRecordedContacts(ps.handle)
+}
+
+// applyContactListener installs the C++ listener for the current Go listener and recording setting
+func (ps *PhysicsSystem) applyContactListener() {
+	previous := ps.contactListener
+	listener := ps.listener
+
+	var callbacks C.int
+	if listener != nil {
+		if listener.OnContactValidate != nil {
+			callbacks |= C.JoltContactCallbackValidate
+		}
+		if listener.OnContactAdded != nil {
+			callbacks |= C.JoltContactCallbackAdded
+		}
+		if listener.OnContactPersisted != nil {
+			callbacks |= C.JoltContactCallbackPersisted
+		}
+		if listener.OnContactRemoved != nil {
+			callbacks |= C.JoltContactCallbackRemoved
+		}
+	}
+
+	ps.contactListener = 0
+	if callbacks != 0 {
+		ps.contactListener = cgo.NewHandle(listener)
+	}
+	if ps.recordContacts {
+		callbacks |= C.JoltContactCallbackRecord
+	}
+	C.JoltPhysicsSystemSetContactListener(ps.handle, C.uintptr_t(ps.contactListener), callbacks)
+
+	// The C++ listener using the previous handle has been freed
+	if previous != 0 {
+		previous.Delete()
+	}
+}
+
+// toContactInfo converts a C contact to Go (the body IDs are borrowed from C, unless they were copied for GetContacts)
+func toContactInfo(cInfo *C.JoltContactInfo) ContactInfo {
+	numPoints := int(cInfo.numPoints)
+	pointsOnA := make([]Vec3, numPoints)
+	pointsOnB := make([]Vec3, numPoints)
+	for i := 0; i < numPoints; i++ {
+		pointsOnA[i] = Vec3{
+			X: float32(cInfo.pointsOnA[i*3]),
+			Y: float32(cInfo.pointsOnA[i*3+1]),
+			Z: float32(cInfo.pointsOnA[i*3+2]),
+		}
+		pointsOnB[i] = Vec3{
+			X: float32(cInfo.pointsOnB[i*3]),
+			Y: float32(cInfo.pointsOnB[i*3+1]),
+			Z: float32(cInfo.pointsOnB[i*3+2]),
+		}
+	}
+
+	return ContactInfo{
+		BodyA:       &BodyID{handle: cInfo.bodyA},
+		BodyB:       &BodyID{handle: cInfo.bodyB},
+		SubShapeIDA: uint32(cInfo.subShapeIDA),
+		SubShapeIDB: uint32(cInfo.subShapeIDB),
+		ContactID:   uint64(cInfo.contactID),
+		Normal: Vec3{
+			X: float32(cInfo.normalX),
+			Y: float32(cInfo.normalY),
+			Z: float32(cInfo.normalZ),
+		},
+		PenetrationDepth: float32(cInfo.penetrationDepth),
+		PointsOnA:        pointsOnA,
+		PointsOnB:        pointsOnB,
+		ImpactImpulse:    float32(cInfo.impactImpulse),
+		Settings: &ContactSettings{
+			IsSensor:            cInfo.isSensor != 0,
+			CombinedFriction:    float32(cInfo.combinedFriction),
+			CombinedRestitution: float32(cInfo.combinedRestitution),
+		},
+	}
+}
+
+// toC writes the settings back to the C contact so Jolt applies them
+func (settings *ContactSettings) toC(cInfo *C.JoltContactInfo) {
+	cInfo.isSensor = C.int(boolToInt(settings.IsSensor))
+	cInfo.combinedFriction = C.float(settings.CombinedFriction)
+	cInfo.combinedRestitution = C.float(settings.CombinedRestitution)
+}
+
+//export goJoltContactValidate
+func goJoltContactValidate(listener C.uintptr_t, bodyA, bodyB C.JoltBodyID) C.int {
+	l := cgo.Handle(listener).Value().(*ContactListener)
+	return C.int(l.OnContactValidate(&BodyID{handle: bodyA}, &BodyID{handle: bodyB}))
+}
+
+//export goJoltContactAdded
+func goJoltContactAdded(listener C.uintptr_t, info *C.JoltContactInfo) {
+	l := cgo.Handle(listener).Value().(*ContactListener)
+	contact := toContactInfo(info)
+	l.OnContactAdded(contact)
+	contact.Settings.toC(info)
+}
+
+//export goJoltContactPersisted
+func goJoltContactPersisted(listener C.uintptr_t, info *C.JoltContactInfo) {
+	l := cgo.Handle(listener).Value().(*ContactListener)
+	contact := toContactInfo(info)
+	l.OnContactPersisted(contact)
+	contact.Settings.toC(info)
+}
+
+//export goJoltContactRemoved
+func goJoltContactRemoved(listener C.uintptr_t, bodyA, bodyB C.JoltBodyID, subShapeIDA, subShapeIDB C.uint) {
+	l := cgo.Handle(listener).Value().(*ContactListener)
+	l.OnContactRemoved(&BodyID{handle: bodyA}, &BodyID{handle: bodyB})
+}
@@ -0,0 +1,358 @@
+package jolt
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestContactListenerManifold(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	var mu sync.Mutex
+	var added []ContactInfo
+	ps.SetContactListener(&ContactListener{
+		OnContactAdded: func(contact ContactInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			added = append(added, contact)
+		},
+	})
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Box falling flat onto the floor
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0, Y: 1, Z: 0}, MotionTypeDynamic, false)
+	defer boxID.Destroy()
+	bi.ActivateBody(boxID)
+
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(added) == 0 {
+		t.Fatal("Expected OnContactAdded to be called when the box lands")
+	}
+
+	contact := added[0]
+	if len(contact.PointsOnA) < 2 || len(contact.PointsOnB) != len(contact.PointsOnA) {
+		t.Errorf("Manifold has %d/%d points, expected at least 2 for a flat landing",
+			len(contact.PointsOnA), len(contact.PointsOnB))
+	}
+	if contact.Normal.Length() < 0.99 {
+		t.Errorf("Normal = %v, expected a unit vector", contact.Normal)
+	}
+	if n := contact.Normal.Y; n > -0.99 && n < 0.99 {
+		t.Errorf("Normal = %v, expected it to be vertical", contact.Normal)
+	}
+}
+
+func TestContactListenerValidateAndSensor(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	drop := func(x float32) *BodyID {
+		bodyID := bi.CreateBody(box, Vec3{X: x, Y: 1, Z: 0}, MotionTypeDynamic, false)
+		bi.ActivateBody(bodyID)
+		return bodyID
+	}
+	rejected := drop(-3)
+	defer rejected.Destroy()
+	reportOnly := drop(0)
+	defer reportOnly.Destroy()
+	solid := drop(3)
+	defer solid.Destroy()
+
+	rejectedValue := rejected.GetIndexAndSequenceNumber()
+	reportOnlyValue := reportOnly.GetIndexAndSequenceNumber()
+	involves := func(a, b *BodyID, value uint32) bool {
+		return a.GetIndexAndSequenceNumber() == value || b.GetIndexAndSequenceNumber() == value
+	}
+
+	var mu sync.Mutex
+	reported := map[uint32]int{}
+	ps.SetContactListener(&ContactListener{
+		OnContactValidate: func(bodyA, bodyB *BodyID) ValidateResult {
+			if involves(bodyA, bodyB, rejectedValue) {
+				return ValidateResultRejectAllContacts
+			}
+			return ValidateResultAcceptAllContacts
+		},
+		OnContactAdded: func(contact ContactInfo) {
+			if involves(contact.BodyA, contact.BodyB, reportOnlyValue) {
+				contact.Settings.IsSensor = true
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reported[contact.BodyA.GetIndexAndSequenceNumber()]++
+			reported[contact.BodyB.GetIndexAndSequenceNumber()]++
+		},
+	})
+
+	for i := 0; i < 120; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	if y := bi.GetPosition(rejected).Y; y > -1 {
+		t.Errorf("Rejected box Y = %.2f, expected it to fall through the floor", y)
+	}
+	if y := bi.GetPosition(reportOnly).Y; y > -1 {
+		t.Errorf("Report-only box Y = %.2f, expected it to fall through the floor", y)
+	}
+	if y := bi.GetPosition(solid).Y; y < 0.4 {
+		t.Errorf("Solid box Y = %.2f, expected it to rest on the floor", y)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if reported[rejectedValue] != 0 {
+		t.Error("Rejected contact should not be reported")
+	}
+	if reported[reportOnlyValue] == 0 {
+		t.Error("Report-only contact should still be reported")
+	}
+	if reported[solid.GetIndexAndSequenceNumber()] == 0 {
+		t.Error("Solid contact should be reported")
+	}
+}
+
+func TestContactListenerCombinedFriction(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 50, Y: 0.5, Z: 50})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Two boxes resting on the floor, pushed along X
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	slide := func(z float32) *BodyID {
+		bodyID := bi.CreateBody(box, Vec3{X: 0, Y: 0.5, Z: z}, MotionTypeDynamic, false)
+		bi.SetLinearVelocity(bodyID, Vec3{X: 5, Y: 0, Z: 0})
+		return bodyID
+	}
+	slippery := slide(-3)
+	defer slippery.Destroy()
+	normal := slide(3)
+	defer normal.Destroy()
+
+	// Only the slippery box loses its friction with the floor
+	slipperyID := slippery.GetIndexAndSequenceNumber()
+	override := func(contact ContactInfo) {
+		if contact.BodyA.GetIndexAndSequenceNumber() == slipperyID || contact.BodyB.GetIndexAndSequenceNumber() == slipperyID {
+			contact.Settings.CombinedFriction = 0
+		}
+	}
+	ps.SetContactListener(&ContactListener{
+		OnContactAdded:     override,
+		OnContactPersisted: override,
+	})
+
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	// Default friction 0.2 slows the normal box down by ~2 m/s per second
+	if v := bi.GetLinearVelocity(slippery).X; v < 4.8 {
+		t.Errorf("Slippery box velocity = %.2f, expected it to keep sliding at ~5", v)
+	}
+	if v := bi.GetLinearVelocity(normal).X; v > 4 {
+		t.Errorf("Normal box velocity = %.2f, expected friction to slow it down to ~3", v)
+	}
+}
+
+func TestContactEstimateImpactImpulse(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 20, Y: 0.5, Z: 20})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Same box dropped from a low and a high height
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	drop := func(x, height float32) *BodyID {
+		bodyID := bi.CreateBody(box, Vec3{X: x, Y: 0.5 + height, Z: 0}, MotionTypeDynamic, false)
+		bi.ActivateBody(bodyID)
+		return bodyID
+	}
+	low := drop(-5, 1)
+	defer low.Destroy()
+	high := drop(5, 8)
+	defer high.Destroy()
+
+	var mu sync.Mutex
+	impulses := make(map[uint32]float32)
+	ps.SetContactListener(&ContactListener{
+		OnContactAdded: func(contact ContactInfo) {
+			impulse := ps.EstimateImpactImpulse(contact.BodyA, contact.BodyB)
+			if impulse != contact.ImpactImpulse {
+				t.Errorf("EstimateImpactImpulse() = %.1f, expected ContactInfo.ImpactImpulse %.1f", impulse, contact.ImpactImpulse)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, bodyID := range []*BodyID{contact.BodyA, contact.BodyB} {
+				id := bodyID.GetIndexAndSequenceNumber()
+				if _, seen := impulses[id]; !seen {
+					impulses[id] = impulse
+				}
+			}
+		},
+	})
+
+	for i := 0; i < 120; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	lowImpulse, okLow := impulses[low.GetIndexAndSequenceNumber()]
+	highImpulse, okHigh := impulses[high.GetIndexAndSequenceNumber()]
+	if !okLow || !okHigh {
+		t.Fatal("Expected both boxes to land on the floor")
+	}
+	if lowImpulse <= 0 {
+		t.Errorf("Low drop impulse = %.1f, expected > 0", lowImpulse)
+	}
+	if highImpulse <= lowImpulse {
+		t.Errorf("High drop impulse = %.1f, expected more than the low drop impulse %.1f", highImpulse, lowImpulse)
+	}
+
+	// Outside of a callback there is no contact to estimate
+	if got := ps.EstimateImpactImpulse(low, floorID); got != 0 {
+		t.Errorf("EstimateImpactImpulse() outside a callback = %.1f, expected 0", got)
+	}
+}
+
+func TestContactListenerContactID(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	var mu sync.Mutex
+	var addedIDs []uint64
+	persistedIDs := make(map[uint64]int)
+	ps.SetContactListener(&ContactListener{
+		OnContactAdded: func(contact ContactInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			addedIDs = append(addedIDs, contact.ContactID)
+		},
+		OnContactPersisted: func(contact ContactInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			persistedIDs[contact.ContactID]++
+		},
+	})
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Box resting on the floor from the start
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0, Y: 0.5, Z: 0}, MotionTypeDynamic, false)
+	defer boxID.Destroy()
+	bi.ActivateBody(boxID)
+
+	for i := 0; i < 10; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(addedIDs) != 1 {
+		t.Fatalf("Got %d added contacts, expected 1", len(addedIDs))
+	}
+	if persistedIDs[addedIDs[0]] == 0 {
+		t.Errorf("No persisted contact with the added contact's ID %#x (persisted: %v)", addedIDs[0], persistedIDs)
+	}
+}
+
+func TestGetContacts(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	if contacts := ps.GetContacts(); len(contacts) != 0 {
+		t.Errorf("GetContacts() = %d contacts before recording, expected 0", len(contacts))
+	}
+	ps.SetRecordContacts(true)
+
+	bi := ps.GetBodyInterface()
+	floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	// Box resting on the floor
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0, Y: 0.49, Z: 0}, MotionTypeDynamic, false)
+	defer boxID.Destroy()
+	bi.ActivateBody(boxID)
+
+	ps.Update(1.0 / 60.0)
+
+	contacts := ps.GetContacts()
+	if len(contacts) == 0 {
+		t.Fatal("GetContacts() returned no contacts, expected the box touching the floor")
+	}
+
+	found := false
+	for _, contact := range contacts {
+		a := contact.BodyA.GetIndexAndSequenceNumber()
+		b := contact.BodyB.GetIndexAndSequenceNumber()
+		if (a == boxID.GetIndexAndSequenceNumber() && b == floorID.GetIndexAndSequenceNumber()) ||
+			(a == floorID.GetIndexAndSequenceNumber() && b == boxID.GetIndexAndSequenceNumber()) {
+			found = true
+			if len(contact.PointsOnA) == 0 {
+				t.Errorf("Contact has no points, expected a manifold")
+			}
+		}
+		contact.BodyA.Destroy()
+		contact.BodyB.Destroy()
+	}
+	if !found {
+		t.Errorf("GetContacts() = %d contacts, expected one between the box and the floor", len(contacts))
+	}
+
+	// Contacts persist as long as the box rests, and are replaced each Update
+	ps.Update(1.0 / 60.0)
+	contacts = ps.GetContacts()
+	if len(contacts) == 0 {
+		t.Errorf("GetContacts() returned no contacts after the second update, expected the persisted contact")
+	}
+	for _, contact := range contacts {
+		contact.BodyA.Destroy()
+		contact.BodyB.Destroy()
+	}
+
+	ps.SetRecordContacts(false)
+	if contacts := ps.GetContacts(); len(contacts) != 0 {
+		t.Errorf("GetContacts() = %d contacts after disabling recording, expected 0", len(contacts))
+	}
+}
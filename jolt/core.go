@@ -5,23 +5,100 @@ import "C"
 import (
 	"fmt"
 	"math"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	initMu   sync.Mutex
+	initRefs int // Number of Init calls not yet matched by Shutdown
 )
 
 // Init initializes Jolt Physics (call once at startup)
+//
+// Initialization is process-global: the type registry, temp allocator and job system
+// are shared by every PhysicsSystem. Init is reference counted, so independent components
+// (e.g. a library and its host application) can each call Init and Shutdown.
 func Init() error {
-	result := C.JoltInit()
-	if result == 0 {
-		return fmt.Errorf("failed to initialize Jolt")
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if initRefs == 0 {
+		result := C.JoltInit()
+		if result == 0 {
+			return fmt.Errorf("failed to initialize Jolt")
+		}
 	}
+	initRefs++
 	return nil
 }
 
 // Shutdown cleans up Jolt resources (call once at exit)
+// Resources are only freed by the Shutdown matching the first Init; extra calls are ignored.
 func Shutdown() {
-	C.JoltShutdown()
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if initRefs == 0 {
+		return
+	}
+	initRefs--
+	if initRefs == 0 {
+		C.JoltShutdown()
+	}
 }
 
 // DegreesToRadians converts degrees to radians
 func DegreesToRadians(degrees float32) float32 {
 	return degrees * math.Pi / 180.0
 }
+
+// modulePath is used to look up the jolt-go release in the build info of the binary
+const modulePath = "github.com/bbitechnologies/jolt-go"
+
+// VersionInfo describes the Jolt library and jolt-go release a binary was built with
+type VersionInfo struct {
+	JoltVersion string // Version of the linked Jolt library, e.g. "5.4.0"
+	ReleaseTag  string // jolt-go module version, e.g. "v0.3.0" ("(devel)" when built from a checkout)
+	Platform    string // GOOS/GOARCH of the pre-built libraries in use, e.g. "linux/amd64"
+}
+
+// BuildInfo returns the versions of the linked Jolt library and jolt-go release,
+// useful for bug reports (e.g. to tell whether a user is on a known-bad binary)
+func BuildInfo() VersionInfo {
+	return VersionInfo{
+		JoltVersion: C.GoString(C.JoltGetVersion()),
+		ReleaseTag:  releaseTag(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
+// Version returns a one-line summary of BuildInfo, e.g. "jolt-go v0.3.0 (Jolt v5.4.0, linux/amd64)"
+func Version() string {
+	info := BuildInfo()
+	return fmt.Sprintf("jolt-go %s (Jolt v%s, %s)", info.ReleaseTag, info.JoltVersion, info.Platform)
+}
+
+// releaseTag returns the jolt-go module version recorded in the binary's build info
+func releaseTag() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(unknown)"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			if dep.Version != "" {
+				return dep.Version
+			}
+			break
+		}
+	}
+	return "(devel)"
+}
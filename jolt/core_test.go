@@ -0,0 +1,25 @@
+package jolt
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	info := BuildInfo()
+
+	if !regexp.MustCompile(`^\d+\.\d+\.\d+$`).MatchString(info.JoltVersion) {
+		t.Errorf("JoltVersion = %q, expected major.minor.patch", info.JoltVersion)
+	}
+	if info.ReleaseTag == "" {
+		t.Error("ReleaseTag should not be empty")
+	}
+	if info.Platform == "" {
+		t.Error("Platform should not be empty")
+	}
+
+	version := Version()
+	if !regexp.MustCompile(`^jolt-go \S+ \(Jolt v\d+\.\d+\.\d+, \w+/\w+\)$`).MatchString(version) {
+		t.Errorf("Version() = %q, expected \"jolt-go <tag> (Jolt v<x.y.z>, <os>/<arch>)\"", version)
+	}
+}
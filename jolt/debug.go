@@ -0,0 +1,39 @@
+package jolt
+
+// #include "wrapper/debug.h"
+import "C"
+
+// DebugDraw emits wireframes of all bodies in the physics system as line segments.
+// Useful for overlaying colliders on top of your own renderer.
+//
+// The callback receives each segment in world space along with its color,
+// packed as RGBA bytes in little-endian order (0xAABBGGRR).
+// All lines are collected in a single CGO call before the callback is invoked.
+//
+// Example:
+//
+//	ps.DebugDraw(func(from, to jolt.Vec3, color uint32) {
+//	    renderer.Line(from, to, color)
+//	})
+func (ps *PhysicsSystem) DebugDraw(drawLine func(from, to Vec3, color uint32)) {
+	lines := C.JoltPhysicsSystemDrawBodies(ps.handle)
+	defer C.JoltDestroyDebugLines(lines)
+
+	numLines := int(C.JoltDebugLinesGetCount(lines))
+	if numLines == 0 {
+		return
+	}
+
+	// Allocate C array for results
+	cLines := make([]C.JoltDebugLine, numLines)
+	numLines = int(C.JoltDebugLinesGet(lines, &cLines[0], C.int(numLines)))
+
+	for i := 0; i < numLines; i++ {
+		l := &cLines[i]
+		drawLine(
+			Vec3{X: float32(l.fromX), Y: float32(l.fromY), Z: float32(l.fromZ)},
+			Vec3{X: float32(l.toX), Y: float32(l.toY), Z: float32(l.toZ)},
+			uint32(l.color),
+		)
+	}
+}
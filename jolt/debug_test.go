@@ -0,0 +1,24 @@
+package jolt
+
+import "testing"
+
+func TestPhysicsSystemDebugDraw(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+
+	body := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer body.Destroy()
+
+	numLines := 0
+	ps.DebugDraw(func(from, to Vec3, color uint32) {
+		numLines++
+	})
+
+	if numLines == 0 {
+		t.Error("DebugDraw should emit line segments for a box")
+	}
+}
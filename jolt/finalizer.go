@@ -0,0 +1,63 @@
+package jolt
+
+// #include "wrapper/body.h"
+// #include "wrapper/shape.h"
+import "C"
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+var (
+	finalizersEnabled atomic.Bool
+	finalizedCount    atomic.Int64 // Number of objects freed by a finalizer, for tests
+)
+
+// EnableFinalizers makes the garbage collector free shapes and body IDs that become
+// unreachable without Destroy being called (default: false). It only applies to objects
+// created after the call. Characters have no finalizer because they never become
+// unreachable on their own: the physics system tracks every character until
+// PhysicsSystem.Destroy frees them, so a finalizer could never run.
+//
+// Finalizers are a safety net against leaks in long-running servers, not a replacement
+// for Destroy: Go runs them at an unpredictable time on its own goroutine. Calling Destroy
+// after a finalizer ran, or twice, is a no-op.
+//
+// Example:
+//
+//	jolt.EnableFinalizers(true)
+//	jolt.Init()
+func EnableFinalizers(enabled bool) {
+	finalizersEnabled.Store(enabled)
+}
+
+// FinalizersEnabled returns true if EnableFinalizers(true) was called
+func FinalizersEnabled() bool {
+	return finalizersEnabled.Load()
+}
+
+// newShape wraps a shape reference owned by the caller
+func newShape(handle C.JoltShape) *Shape {
+	s := &Shape{handle: handle}
+	if finalizersEnabled.Load() {
+		runtime.SetFinalizer(s, func(s *Shape) {
+			if s.free() {
+				finalizedCount.Add(1)
+			}
+		})
+	}
+	return s
+}
+
+// newBodyID wraps a body ID copy owned by the caller
+func newBodyID(handle C.JoltBodyID) *BodyID {
+	b := &BodyID{handle: handle}
+	if finalizersEnabled.Load() {
+		runtime.SetFinalizer(b, func(b *BodyID) {
+			if b.free() {
+				finalizedCount.Add(1)
+			}
+		})
+	}
+	return b
+}
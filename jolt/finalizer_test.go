@@ -0,0 +1,45 @@
+package jolt
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestEnableFinalizers(t *testing.T) {
+	EnableFinalizers(true)
+	defer EnableFinalizers(false)
+	if !FinalizersEnabled() {
+		t.Fatal("FinalizersEnabled() = false, expected true")
+	}
+
+	// Destroyed shapes are not released again by their finalizer
+	before := finalizedCount.Load()
+	for i := 0; i < 5; i++ {
+		shape := CreateSphere(1)
+		shape.Destroy()
+		shape.Destroy()
+	}
+	for i := 0; i < 5; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if freed := finalizedCount.Load() - before; freed != 0 {
+		t.Errorf("Finalizers freed %d destroyed shapes, expected 0", freed)
+	}
+
+	// Leaked shapes are released once the garbage collector finds them
+	const leaked = 10
+	before = finalizedCount.Load()
+	for i := 0; i < leaked; i++ {
+		CreateSphere(1)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for finalizedCount.Load()-before < leaked && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if freed := finalizedCount.Load() - before; freed != leaked {
+		t.Errorf("Finalizers freed %d leaked shapes, expected %d", freed, leaked)
+	}
+}
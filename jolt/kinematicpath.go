@@ -0,0 +1,64 @@
+package jolt
+
+// KinematicPath moves a kinematic body along a list of waypoints at a constant speed,
+// e.g. for moving platforms. It uses MoveKinematic, so the body pushes and carries
+// other bodies. The body keeps its rotation.
+//
+// Call Advance before every Update with the same deltaTime. The body moves from its
+// current position to the first waypoint, then through the others in order, and stops
+// at the last one.
+//
+// Example:
+//
+//	path := jolt.NewKinematicPath([]jolt.Vec3{{X: 0, Y: 1, Z: 0}, {X: 10, Y: 1, Z: 0}}, 2)
+//	for !path.Done() {
+//	    path.Advance(bi, platform, 1.0/60.0)
+//	    ps.Update(1.0 / 60.0)
+//	}
+type KinematicPath struct {
+	waypoints []Vec3
+	speed     float32
+	next      int // Index of the waypoint the body is moving towards
+}
+
+// kinematicPathTolerance is how close (in meters) the body must get to a waypoint to count as reaching it,
+// so rounding in the simulated position can't leave the body creeping towards a waypoint forever
+const kinematicPathTolerance = 1e-3
+
+// NewKinematicPath creates a path through waypoints, followed at speed meters per second
+func NewKinematicPath(waypoints []Vec3, speed float32) *KinematicPath {
+	return &KinematicPath{
+		waypoints: append([]Vec3(nil), waypoints...),
+		speed:     speed,
+	}
+}
+
+// Advance moves bodyID speed * deltaTime meters further along the path during the next Update
+// of deltaTime seconds. Once the last waypoint is reached the body is held still there.
+// Returns an error if the body is static.
+func (p *KinematicPath) Advance(bi *BodyInterface, bodyID *BodyID, deltaTime float32) error {
+	if deltaTime <= 0 {
+		return nil
+	}
+
+	position := bi.GetPosition(bodyID)
+	remaining := p.speed * deltaTime
+	for p.next < len(p.waypoints) && remaining > 0 {
+		toWaypoint := p.waypoints[p.next].Sub(position)
+		distance := toWaypoint.Length()
+		if distance > remaining+kinematicPathTolerance {
+			position = position.Add(toWaypoint.Mul(remaining / distance))
+			break
+		}
+		position = p.waypoints[p.next]
+		remaining -= distance
+		p.next++
+	}
+
+	return bi.MoveKinematic(bodyID, position, bi.GetRotation(bodyID), deltaTime)
+}
+
+// Done returns true once the body has reached the last waypoint
+func (p *KinematicPath) Done() bool {
+	return p.next >= len(p.waypoints)
+}
@@ -0,0 +1,94 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKinematicPath(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 0.1, Z: 1})
+	defer box.Destroy()
+	platform := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeKinematic, false)
+	defer platform.Destroy()
+
+	// 4 meters at 2 m/s takes 2 seconds
+	path := NewKinematicPath([]Vec3{{X: 0, Y: 5, Z: 0}, {X: 4, Y: 5, Z: 0}}, 2)
+
+	step := func(n int) {
+		for i := 0; i < n; i++ {
+			if err := path.Advance(bi, platform, 1.0/60.0); err != nil {
+				t.Fatalf("Advance failed: %v", err)
+			}
+			ps.Update(1.0 / 60.0)
+		}
+	}
+
+	step(60)
+	if x := bi.GetPosition(platform).X; math.Abs(float64(x-2)) > 0.01 {
+		t.Errorf("Position X = %.3f after 1s, expected 2", x)
+	}
+	if path.Done() {
+		t.Error("Done() = true halfway along the path, expected false")
+	}
+
+	step(60)
+	if pos := bi.GetPosition(platform); pos.Sub(Vec3{X: 4, Y: 5, Z: 0}).Length() > 0.01 {
+		t.Errorf("Position = %v after 2s, expected {4, 5, 0}", pos)
+	}
+	if !path.Done() {
+		t.Error("Done() = false after reaching the last waypoint, expected true")
+	}
+
+	// The body stays at the end of the path
+	step(30)
+	if pos := bi.GetPosition(platform); pos.Sub(Vec3{X: 4, Y: 5, Z: 0}).Length() > 0.01 {
+		t.Errorf("Position = %v after the path ended, expected {4, 5, 0}", pos)
+	}
+}
+
+func TestKinematicPathStaticBody(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 0.1, Z: 1})
+	defer box.Destroy()
+	wall := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	path := NewKinematicPath([]Vec3{{X: 4, Y: 5, Z: 0}}, 2)
+	if err := path.Advance(bi, wall, 1.0/60.0); err == nil {
+		t.Error("Advance on a static body succeeded, expected an error")
+	}
+	if err := bi.MoveKinematic(wall, Vec3{X: 1, Y: 5, Z: 0}, QuatIdentity(), 1.0/60.0); err == nil {
+		t.Error("MoveKinematic on a static body succeeded, expected an error")
+	}
+	if pos := bi.GetPosition(wall); pos.Sub(Vec3{X: 0, Y: 5, Z: 0}).Length() > 1e-5 {
+		t.Errorf("Static body position = %v, expected it unchanged at {0, 5, 0}", pos)
+	}
+}
+
+func TestKinematicPathTolerance(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 0.1, Z: 1})
+	defer box.Destroy()
+	platform := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeKinematic, false)
+	defer platform.Destroy()
+
+	// A waypoint just out of reach of one step counts as reached
+	const dt = 1.0 / 60.0
+	path := NewKinematicPath([]Vec3{{X: 2*dt + kinematicPathTolerance/2, Y: 5, Z: 0}}, 2)
+	if err := path.Advance(bi, platform, dt); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if !path.Done() {
+		t.Error("Done() = false for a waypoint within the tolerance, expected true")
+	}
+}
@@ -0,0 +1,37 @@
+package jolt
+
+import "C"
+import (
+	"log"
+	"sync/atomic"
+)
+
+// logger receives Jolt's trace messages, nil when silenced
+var logger atomic.Pointer[func(msg string)]
+
+func init() {
+	SetLogger(func(msg string) {
+		log.Print("jolt: ", msg)
+	})
+}
+
+// SetLogger sets the function that receives Jolt's trace messages and failed assertions
+// (when Jolt is built with asserts). Failed assertions are reported instead of aborting the process.
+// Defaults to the standard library logger; pass nil to silence Jolt output.
+// SetLogger may be called at any time, including while physics systems are updating.
+//
+// Note: Jolt may report from its worker threads, so fn must be safe for concurrent use.
+func SetLogger(fn func(msg string)) {
+	if fn == nil {
+		logger.Store(nil)
+		return
+	}
+	logger.Store(&fn)
+}
+
+//export goJoltTrace
+func goJoltTrace(msg *C.char) {
+	if fn := logger.Load(); fn != nil {
+		(*fn)(C.GoString(msg))
+	}
+}
@@ -0,0 +1,42 @@
+package jolt
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLoggerReceivesShapeErrors(t *testing.T) {
+	var mu sync.Mutex
+	var messages []string
+
+	previous := logger.Load()
+	SetLogger(func(msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, msg)
+	})
+	defer logger.Store(previous)
+
+	// Half extents smaller than the convex radius are rejected inside Jolt
+	if shape := CreateBox(Vec3{X: 0.01, Y: 0.01, Z: 0.01}); shape != nil {
+		shape.Destroy()
+		t.Error("CreateBox with half extents below the convex radius returned a shape, expected nil")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(messages) == 0 {
+		t.Error("Logger should have been called for an invalid shape")
+	}
+}
+
+func TestSetLoggerNil(t *testing.T) {
+	previous := logger.Load()
+	defer logger.Store(previous)
+
+	// A silenced logger drops messages instead of crashing
+	SetLogger(nil)
+	if shape := CreateBox(Vec3{X: 0.01, Y: 0.01, Z: 0.01}); shape != nil {
+		shape.Destroy()
+	}
+}
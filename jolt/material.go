@@ -0,0 +1,32 @@
+package jolt
+
+// Material is a named combination of the surface properties of a body, applied with SetMaterial.
+// The friction and restitution of a contact combine those of both bodies (see ContactSettings),
+// so a rubber ball bounces less on ice than on concrete.
+type Material struct {
+	Friction    float32 // Usually between 0 (no friction) and 1
+	Restitution float32 // 0 = no bounce, 1 = bounces back at the speed it hit with
+}
+
+var (
+	// MaterialIce is nearly frictionless, bodies slide a long way before stopping
+	MaterialIce = Material{Friction: 0.02, Restitution: 0.05}
+
+	// MaterialRubber grips well and is bouncy
+	MaterialRubber = Material{Friction: 0.9, Restitution: 0.8}
+
+	// MaterialConcrete grips well and barely bounces
+	MaterialConcrete = Material{Friction: 0.8, Restitution: 0.1}
+)
+
+// SetMaterial sets the friction and restitution of a body to those of m.
+// It is shorthand for SetFriction and SetRestitution.
+//
+// Example:
+//
+//	bi.SetMaterial(puck, jolt.MaterialIce)
+//	bi.SetMaterial(ball, jolt.Material{Friction: 0.5, Restitution: 0.6})
+func (bi *BodyInterface) SetMaterial(bodyID *BodyID, m Material) {
+	bi.SetFriction(bodyID, m.Friction)
+	bi.SetRestitution(bodyID, m.Restitution)
+}
@@ -0,0 +1,42 @@
+package jolt
+
+import "testing"
+
+func TestSetMaterial(t *testing.T) {
+	// Returns how far a box pushed across a floor slides in 2 seconds
+	slide := func(m Material) float32 {
+		ps := NewPhysicsSystem()
+		defer ps.Destroy()
+
+		bi := ps.GetBodyInterface()
+		floorShape := CreateBox(Vec3{X: 20, Y: 0.1, Z: 20})
+		defer floorShape.Destroy()
+		floor := bi.CreateBody(floorShape, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+		defer floor.Destroy()
+
+		box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+		defer box.Destroy()
+		crate := bi.CreateDynamicBody(box, Vec3{X: -10, Y: 0.6, Z: 0})
+		defer crate.Destroy()
+
+		bi.SetMaterial(crate, m)
+		if got := bi.GetFriction(crate); got != m.Friction {
+			t.Errorf("GetFriction() = %v, expected %v", got, m.Friction)
+		}
+		if got := bi.GetRestitution(crate); got != m.Restitution {
+			t.Errorf("GetRestitution() = %v, expected %v", got, m.Restitution)
+		}
+
+		bi.SetLinearVelocity(crate, Vec3{X: 5, Y: 0, Z: 0})
+		for i := 0; i < 120; i++ {
+			ps.Update(1.0 / 60.0)
+		}
+		return bi.GetPosition(crate).X + 10
+	}
+
+	ice := slide(MaterialIce)
+	concrete := slide(MaterialConcrete)
+	if ice <= concrete {
+		t.Errorf("Slide distance on ice = %.2f, expected more than on concrete (%.2f)", ice, concrete)
+	}
+}
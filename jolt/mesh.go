@@ -0,0 +1,87 @@
+package jolt
+
+// MeshFromBox returns the vertices and indices of a box centered on the origin, for CreateMesh.
+// The triangles face outwards, so rays from outside hit the box with the default back face mode.
+//
+// Example:
+//
+//	vertices, indices := jolt.MeshFromBox(jolt.Vec3{X: 5, Y: 0.5, Z: 5})
+//	floor := jolt.CreateMesh(vertices, indices)
+//	defer floor.Destroy()
+func MeshFromBox(halfExtent Vec3) (vertices []Vec3, indices []int32) {
+	hx, hy, hz := halfExtent.X, halfExtent.Y, halfExtent.Z
+	vertices = []Vec3{
+		{X: -hx, Y: -hy, Z: -hz},
+		{X: hx, Y: -hy, Z: -hz},
+		{X: hx, Y: hy, Z: -hz},
+		{X: -hx, Y: hy, Z: -hz},
+		{X: -hx, Y: -hy, Z: hz},
+		{X: hx, Y: -hy, Z: hz},
+		{X: hx, Y: hy, Z: hz},
+		{X: -hx, Y: hy, Z: hz},
+	}
+
+	// Corners of each face, ordered so the face normal (b-a)x(c-a) points outwards
+	faces := [6][4]int32{
+		{0, 3, 2, 1}, // -Z
+		{4, 5, 6, 7}, // +Z
+		{0, 1, 5, 4}, // -Y
+		{3, 7, 6, 2}, // +Y
+		{0, 4, 7, 3}, // -X
+		{1, 2, 6, 5}, // +X
+	}
+	indices = make([]int32, 0, len(faces)*6)
+	for _, f := range faces {
+		indices = append(indices, f[0], f[1], f[2], f[0], f[2], f[3])
+	}
+	return vertices, indices
+}
+
+// MeshFromGrid returns the vertices and indices of a width x depth grid of square cells
+// for CreateMesh, e.g. a terrain for tests. The grid is centered on the origin in the XZ plane
+// and its triangles face up. heightFn gives the Y coordinate of grid point (x, z), with
+// x in [0, width] and z in [0, depth]; a nil heightFn gives a flat grid.
+// Returns nil slices if width or depth is not positive.
+//
+// Example:
+//
+//	vertices, indices := jolt.MeshFromGrid(32, 32, 1, func(x, z int) float32 {
+//	    return float32(math.Sin(float64(x)*0.3)) * 0.5
+//	})
+//	terrain := jolt.CreateMesh(vertices, indices)
+//	defer terrain.Destroy()
+func MeshFromGrid(width, depth int, cellSize float32, heightFn func(x, z int) float32) ([]Vec3, []int32) {
+	if width <= 0 || depth <= 0 {
+		return nil, nil
+	}
+
+	offsetX := float32(width) * cellSize / 2
+	offsetZ := float32(depth) * cellSize / 2
+	vertices := make([]Vec3, 0, (width+1)*(depth+1))
+	for z := 0; z <= depth; z++ {
+		for x := 0; x <= width; x++ {
+			var height float32
+			if heightFn != nil {
+				height = heightFn(x, z)
+			}
+			vertices = append(vertices, Vec3{
+				X: float32(x)*cellSize - offsetX,
+				Y: height,
+				Z: float32(z)*cellSize - offsetZ,
+			})
+		}
+	}
+
+	// Two triangles per cell, wound so they face +Y
+	indices := make([]int32, 0, width*depth*6)
+	for z := 0; z < depth; z++ {
+		for x := 0; x < width; x++ {
+			a := int32(z*(width+1) + x) // (x, z)
+			b := a + int32(width+1)     // (x, z+1)
+			c := b + 1                  // (x+1, z+1)
+			d := a + 1                  // (x+1, z)
+			indices = append(indices, a, b, c, a, c, d)
+		}
+	}
+	return vertices, indices
+}
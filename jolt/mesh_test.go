@@ -0,0 +1,79 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeshFromBox(t *testing.T) {
+	vertices, indices := MeshFromBox(Vec3{X: 1, Y: 2, Z: 3})
+	if len(vertices) != 8 || len(indices) != 36 {
+		t.Fatalf("MeshFromBox returned %d vertices and %d indices, expected 8 and 36", len(vertices), len(indices))
+	}
+
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	mesh := CreateMesh(vertices, indices)
+	defer mesh.Destroy()
+	bi := ps.GetBodyInterface()
+	bodyID := bi.CreateBody(mesh, Vec3{}, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+
+	// Rays from outside along each axis hit the outward facing triangles
+	tests := []struct {
+		origin   Vec3
+		expected Vec3
+	}{
+		{Vec3{X: 10, Y: 0, Z: 0}, Vec3{X: 1, Y: 0, Z: 0}},
+		{Vec3{X: -10, Y: 0, Z: 0}, Vec3{X: -1, Y: 0, Z: 0}},
+		{Vec3{X: 0, Y: 10, Z: 0}, Vec3{X: 0, Y: 2, Z: 0}},
+		{Vec3{X: 0, Y: -10, Z: 0}, Vec3{X: 0, Y: -2, Z: 0}},
+		{Vec3{X: 0, Y: 0, Z: 10}, Vec3{X: 0, Y: 0, Z: 3}},
+		{Vec3{X: 0, Y: 0, Z: -10}, Vec3{X: 0, Y: 0, Z: -3}},
+	}
+	for _, tt := range tests {
+		hit, ok := ps.CastRay(tt.origin, tt.origin.Mul(-1))
+		if !ok {
+			t.Errorf("CastRay from %v missed the box", tt.origin)
+			continue
+		}
+		hit.BodyID.Destroy()
+		if hit.HitPoint.Sub(tt.expected).Length() > 0.01 {
+			t.Errorf("CastRay from %v HitPoint = %v, expected %v", tt.origin, hit.HitPoint, tt.expected)
+		}
+	}
+}
+
+func TestMeshFromGrid(t *testing.T) {
+	if vertices, indices := MeshFromGrid(0, 4, 1, nil); vertices != nil || indices != nil {
+		t.Errorf("MeshFromGrid(0, 4) = %d vertices, %d indices, expected nil", len(vertices), len(indices))
+	}
+
+	// Slope rising along +X: height = x * 0.5
+	vertices, indices := MeshFromGrid(4, 2, 1, func(x, z int) float32 {
+		return float32(x) * 0.5
+	})
+	if len(vertices) != 15 || len(indices) != 48 {
+		t.Fatalf("MeshFromGrid returned %d vertices and %d indices, expected 15 and 48", len(vertices), len(indices))
+	}
+
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	mesh := CreateMesh(vertices, indices)
+	defer mesh.Destroy()
+	bi := ps.GetBodyInterface()
+	bodyID := bi.CreateBody(mesh, Vec3{}, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+
+	// The grid is centered, so X = 1 is grid point x = 3 at height 1.5
+	hit, ok := ps.CastRay(Vec3{X: 1, Y: 10, Z: 0.5}, Vec3{X: 0, Y: -20, Z: 0})
+	if !ok {
+		t.Fatal("CastRay missed the grid, expected it to hit the upward facing triangles")
+	}
+	defer hit.BodyID.Destroy()
+	if math.Abs(float64(hit.HitPoint.Y-1.5)) > 0.01 {
+		t.Errorf("HitPoint.Y = %f, expected 1.5", hit.HitPoint.Y)
+	}
+}
@@ -2,24 +2,412 @@ package jolt
 
 // #include "wrapper/physics.h"
 import "C"
+import (
+	"fmt"
+	"math"
+	"runtime/cgo"
+	"sync"
+)
+
+// ObjectLayer determines which other bodies a body can collide with
+type ObjectLayer uint16
+
+const (
+	ObjectLayerNonMoving ObjectLayer = C.JoltObjectLayerNonMoving // Static bodies, only collide with moving bodies
+	ObjectLayerMoving    ObjectLayer = C.JoltObjectLayerMoving    // Dynamic/kinematic bodies, collide with static and moving bodies
+	ObjectLayerDisabled  ObjectLayer = C.JoltObjectLayerDisabled  // Collides with nothing and isn't hit by queries (see SetCollisionEnabled)
+	ObjectLayerQueryOnly ObjectLayer = C.JoltObjectLayerQueryOnly // Markers: collides with nothing, but is hit by queries like CastRay and CollideShape
+
+	numObjectLayers = 4 // Number of object layers above, SetObjectLayer rejects others
+)
+
+// LayerMask returns a bit mask containing the given object layers, for queries such as CastRayAllFiltered
+func LayerMask(layers ...ObjectLayer) uint32 {
+	var mask uint32
+	for _, layer := range layers {
+		mask |= 1 << layer
+	}
+	return mask
+}
 
 // PhysicsSystem represents a physics simulation world
 type PhysicsSystem struct {
 	handle C.JoltPhysicsSystem
+
+	breakableConstraints map[*Constraint]struct{}
+	characters           map[*CharacterVirtual]struct{} // Characters not destroyed yet, freed by Destroy
+	constraintBroken     func(c *Constraint)
+	contactListener      cgo.Handle // Handle of the *ContactListener passed to C, 0 if none
+	listener             *ContactListener
+	recordContacts       bool // Keep the contacts of the last Update for GetContacts
+	onBodyActivated      func(bodyID *BodyID)
+	onBodyDeactivated    func(bodyID *BodyID)
+
+	simulationTime float64 // Total simulated time in seconds
+	stepCount      uint64  // Number of simulation steps taken
+	maxDeltaTime   float32 // Largest step Update takes at once, 0 for no limit
+	worldUp        Vec3    // Unit length up direction, see SetWorldUp
+
+	sphereCacheMu sync.Mutex
+	sphereCache   map[float32]*Shape // Sphere shapes used by SphereCast, by radius
+
+	disabledLayersMu sync.Mutex
+	disabledLayers   map[uint32]ObjectLayer // Layers of bodies with collision disabled, by body index and sequence number
 }
 
 // NewPhysicsSystem creates a new physics world
+//
+// Each physics system is fully isolated: it owns its own bodies, broad phase, constraints
+// and gravity, so several worlds (e.g. one per game room) can be simulated in one process.
+// Only the resources set up by Init are shared between worlds.
 func NewPhysicsSystem() *PhysicsSystem {
 	handle := C.JoltCreatePhysicsSystem()
-	return &PhysicsSystem{handle: handle}
+	return &PhysicsSystem{
+		handle:               handle,
+		breakableConstraints: make(map[*Constraint]struct{}),
+		characters:           make(map[*CharacterVirtual]struct{}),
+		worldUp:              Vec3{X: 0, Y: 1, Z: 0},
+	}
 }
 
-// Destroy frees the physics system
+// Destroy frees the physics system, along with any characters created in it that were not destroyed yet
 func (ps *PhysicsSystem) Destroy() {
+	// Characters own an inner body and a listener, free them while the system still exists
+	for cv := range ps.characters {
+		cv.free()
+	}
+	ps.characters = nil
+
 	C.JoltDestroyPhysicsSystem(ps.handle)
+	if ps.contactListener != 0 {
+		ps.contactListener.Delete()
+		ps.contactListener = 0
+	}
+	for _, sphere := range ps.sphereCache {
+		sphere.Destroy()
+	}
+	ps.sphereCache = nil
 }
 
+// MaxUpdateSubsteps is the largest number of substeps Update splits a delta into (see SetMaxDeltaTime)
+const MaxUpdateSubsteps = 120
+
 // Update advances the simulation by deltaTime seconds
+//
+// If a maximum delta time is set (see SetMaxDeltaTime), larger deltas are split into
+// equal substeps that each stay within the limit. A deltaTime that isn't positive and finite is ignored.
 func (ps *PhysicsSystem) Update(deltaTime float32) {
-	C.JoltPhysicsSystemUpdate(ps.handle, C.float(deltaTime))
+	if !(deltaTime > 0) || math.IsInf(float64(deltaTime), 1) {
+		return
+	}
+
+	steps := 1
+	if ps.maxDeltaTime > 0 && deltaTime > ps.maxDeltaTime {
+		steps = int(math.Ceil(float64(deltaTime / ps.maxDeltaTime)))
+		if steps > MaxUpdateSubsteps {
+			// Drop the excess so a long stall doesn't freeze the caller catching up
+			steps = MaxUpdateSubsteps
+			deltaTime = ps.maxDeltaTime * MaxUpdateSubsteps
+		}
+	}
+
+	if ps.recordContacts {
+		ps.clearRecordedContacts()
+	}
+
+	stepTime := deltaTime / float32(steps)
+	for i := 0; i < steps; i++ {
+		ps.step(stepTime, 1)
+	}
+}
+
+// MaxCollisionStepTime is the longest time Jolt can simulate in one collision step.
+// Larger steps make bodies tunnel and constraints explode without any error from Jolt.
+const MaxCollisionStepTime = 1.0 / 60.0
+
+// UpdateWithSubsteps advances the simulation by deltaTime seconds in a single step that runs
+// collisionSteps collision steps, each covering deltaTime / collisionSteps seconds.
+// This is cheaper than the same number of Update calls since the broad phase is updated once.
+//
+// Returns an error without simulating if collisionSteps < 1, or if a collision step would be longer
+// than MaxCollisionStepTime; the error reports how many collision steps deltaTime needs.
+// A deltaTime that isn't positive is ignored.
+//
+// Example:
+//
+//	// 30Hz game loop, two collision steps keep each one at 1/60s
+//	if err := ps.UpdateWithSubsteps(1.0/30.0, 2); err != nil {
+//	    log.Fatal(err)
+//	}
+func (ps *PhysicsSystem) UpdateWithSubsteps(deltaTime float32, collisionSteps int) error {
+	if collisionSteps < 1 {
+		return fmt.Errorf("invalid number of collision steps %d, expected 1 or more", collisionSteps)
+	}
+	if !(deltaTime > 0) {
+		return nil
+	}
+
+	// Allow for float32 rounding of deltas like 1.0 / 60.0
+	maxDeltaTime := float64(collisionSteps) * MaxCollisionStepTime * (1 + 1e-5)
+	if float64(deltaTime) > maxDeltaTime {
+		needed := int(math.Ceil(float64(deltaTime)/MaxCollisionStepTime - 1e-5))
+		return fmt.Errorf("deltaTime %.4fs is too large for %d collision steps, expected at least %d", deltaTime, collisionSteps, needed)
+	}
+
+	if ps.recordContacts {
+		ps.clearRecordedContacts()
+	}
+	ps.step(deltaTime, collisionSteps)
+	return nil
+}
+
+// step runs one Jolt update and the per-step bookkeeping done by Update and UpdateWithSubsteps
+func (ps *PhysicsSystem) step(stepTime float32, collisionSteps int) {
+	C.JoltPhysicsSystemUpdateWithCollisionSteps(ps.handle, C.float(stepTime), C.int(collisionSteps))
+	ps.simulationTime += float64(stepTime)
+	ps.stepCount++
+	ps.dispatchBodyActivations()
+	ps.breakConstraints(stepTime, collisionSteps)
+}
+
+// SetDeterministicMode makes Update produce bit-identical results for identical inputs (default: false)
+//
+// In deterministic mode every update runs single-threaded on the calling goroutine, and Jolt
+// sorts bodies and constraints so the solve order doesn't depend on timing. This gives up
+// Jolt's worker threads, so large scenes step several times slower: use it for regression
+// tests, replays and lockstep networking, not as a default.
+//
+// Results only repeat when the inputs do: create bodies in the same order, pass the same
+// deltas to Update (combine with SetMaxDeltaTime for fixed substeps) and run the same
+// binary. Matching results across CPU architectures additionally requires a Jolt build
+// with cross-platform determinism enabled.
+//
+// Example:
+//
+//	ps.SetDeterministicMode(true)
+//	for i := 0; i < 600; i++ {
+//	    ps.Update(1.0 / 60.0)
+//	}
+//	// positions match those of every other run with the same inputs
+func (ps *PhysicsSystem) SetDeterministicMode(enabled bool) {
+	cEnabled := C.int(0)
+	if enabled {
+		cEnabled = C.int(1)
+	}
+	C.JoltPhysicsSystemSetDeterministicMode(ps.handle, cEnabled)
+}
+
+// IsDeterministicMode returns true if deterministic mode is enabled (see SetDeterministicMode)
+func (ps *PhysicsSystem) IsDeterministicMode() bool {
+	return C.JoltPhysicsSystemIsDeterministicMode(ps.handle) != 0
+}
+
+// SetMaxDeltaTime sets the largest step Update simulates at once (default: 0, no limit)
+//
+// Large deltas (e.g. after a GC pause or when the window was in the background) make fast
+// bodies tunnel through thin geometry and destabilize constraints. With a limit set, Update
+// substeps oversized deltas instead, so no simulated time is lost. Every substep costs a full
+// simulation step, so Update runs at most MaxUpdateSubsteps of them and drops the rest of a
+// longer stall; the simulation then falls behind real time instead of freezing the caller.
+//
+// Example:
+//
+//	ps.SetMaxDeltaTime(1.0 / 60.0)
+//	ps.Update(frameTime) // a 0.1s hitch runs as 6 steps of 1/60s
+func (ps *PhysicsSystem) SetMaxDeltaTime(maxDeltaTime float32) {
+	ps.maxDeltaTime = maxDeltaTime
+}
+
+// GetMaxDeltaTime returns the largest step Update simulates at once, 0 if there is no limit
+func (ps *PhysicsSystem) GetMaxDeltaTime() float32 {
+	return ps.maxDeltaTime
+}
+
+// GetSimulationTime returns the total simulated time in seconds, i.e. the sum of all deltaTime
+// values passed to Update, less any time dropped by the MaxUpdateSubsteps limit. It is accumulated in double precision so it doesn't drift over long sessions.
+func (ps *PhysicsSystem) GetSimulationTime() float64 {
+	return ps.simulationTime
+}
+
+// GetStepCount returns the number of simulation steps taken since the physics system was created.
+// Substeps taken because of SetMaxDeltaTime are counted individually.
+func (ps *PhysicsSystem) GetStepCount() uint64 {
+	return ps.stepCount
+}
+
+// SetGravity sets the gravity of this physics world (default: {0, -9.81, 0})
+func (ps *PhysicsSystem) SetGravity(gravity Vec3) {
+	C.JoltPhysicsSystemSetGravity(ps.handle, C.float(gravity.X), C.float(gravity.Y), C.float(gravity.Z))
+}
+
+// GetGravity returns the gravity of this physics world
+func (ps *PhysicsSystem) GetGravity() Vec3 {
+	var x, y, z C.float
+	C.JoltPhysicsSystemGetGravity(ps.handle, &x, &y, &z)
+	return Vec3{
+		X: float32(x),
+		Y: float32(y),
+		Z: float32(z),
+	}
+}
+
+// SetWorldUp sets the up direction of this world (default: {0, 1, 0}), e.g. {0, 0, 1} for Z-up content.
+// Gravity is turned to point along -up, keeping its strength, and ProbeGround sweeps along -up.
+// Jolt itself has no up axis, so bodies, constraints and queries work the same in any orientation.
+// Characters are configured separately: set CharacterVirtualSettings.Up to GetWorldUp().
+// up doesn't need to be normalized; a zero vector is ignored.
+//
+// Example:
+//
+//	ps.SetWorldUp(jolt.Vec3{X: 0, Y: 0, Z: 1}) // gravity is now {0, 0, -9.81}
+//	settings := jolt.NewCharacterVirtualSettings(capsule)
+//	settings.Up = ps.GetWorldUp()
+func (ps *PhysicsSystem) SetWorldUp(up Vec3) {
+	up = up.Normalize()
+	if up == (Vec3{}) {
+		return
+	}
+	ps.worldUp = up
+	ps.SetGravity(up.Mul(-ps.GetGravity().Length()))
+}
+
+// GetWorldUp returns the unit length up direction of this world (see SetWorldUp)
+func (ps *PhysicsSystem) GetWorldUp() Vec3 {
+	return ps.worldUp
+}
+
+// PhysicsSettings contains world-level simulation settings
+// Start from GetPhysicsSettings and change the fields you need, since the zero value disables sleeping.
+type PhysicsSettings struct {
+	// AllowSleeping lets bodies go to sleep when they come to rest (default: true)
+	AllowSleeping bool
+
+	// PointVelocitySleepThreshold is the speed below which a body can go to sleep (default: 0.03 m/s).
+	// Jolt combines linear and angular motion by testing the velocity of points on the body's
+	// bounding sphere, so there is no separate angular threshold.
+	// Scale it with your units, e.g. 3 when working in centimeters.
+	PointVelocitySleepThreshold float32
+
+	// TimeBeforeSleep is how long a body must stay below the threshold before it sleeps (default: 0.5 s)
+	TimeBeforeSleep float32
+}
+
+// GetPhysicsSettings returns the simulation settings of this physics world
+func (ps *PhysicsSystem) GetPhysicsSettings() PhysicsSettings {
+	var cSettings C.JoltPhysicsSettings
+	C.JoltPhysicsSystemGetSettings(ps.handle, &cSettings)
+
+	return PhysicsSettings{
+		AllowSleeping:               cSettings.allowSleeping != 0,
+		PointVelocitySleepThreshold: float32(cSettings.pointVelocitySleepThreshold),
+		TimeBeforeSleep:             float32(cSettings.timeBeforeSleep),
+	}
+}
+
+// SetPhysicsSettings changes the simulation settings of this physics world
+//
+// Example:
+//
+//	// Scene modeled in centimeters
+//	settings := ps.GetPhysicsSettings()
+//	settings.PointVelocitySleepThreshold = 3
+//	ps.SetPhysicsSettings(settings)
+func (ps *PhysicsSystem) SetPhysicsSettings(settings PhysicsSettings) {
+	cSettings := C.JoltPhysicsSettings{
+		allowSleeping:               C.int(boolToInt(settings.AllowSleeping)),
+		pointVelocitySleepThreshold: C.float(settings.PointVelocitySleepThreshold),
+		timeBeforeSleep:             C.float(settings.TimeBeforeSleep),
+	}
+	C.JoltPhysicsSystemSetSettings(ps.handle, &cSettings)
+}
+
+// SolverSettings tunes the contact solver, trading stability against performance
+// Start from GetSolverSettings and change the fields you need.
+type SolverSettings struct {
+	// Baumgarte is the fraction of the position error corrected per step (default: 0.2).
+	// Higher values push overlapping bodies apart faster but can add jitter.
+	Baumgarte float32
+
+	// PenetrationSlop is how far bodies may overlap without being pushed apart (default: 0.02 m).
+	// Lower it if resting bodies visibly sink into each other.
+	PenetrationSlop float32
+
+	// NumVelocitySteps is the number of velocity solver iterations per step (default: 10, at least 2).
+	// Jolt applies friction using the contact impulse of the previous iteration, so it needs 2.
+	NumVelocitySteps int
+
+	// NumPositionSteps is the number of position solver iterations per step (default: 2, at least 1)
+	NumPositionSteps int
+}
+
+// GetSolverSettings returns the contact solver settings of this physics world
+func (ps *PhysicsSystem) GetSolverSettings() SolverSettings {
+	var cSettings C.JoltSolverSettings
+	C.JoltPhysicsSystemGetSolverSettings(ps.handle, &cSettings)
+
+	return SolverSettings{
+		Baumgarte:        float32(cSettings.baumgarte),
+		PenetrationSlop:  float32(cSettings.penetrationSlop),
+		NumVelocitySteps: int(cSettings.numVelocitySteps),
+		NumPositionSteps: int(cSettings.numPositionSteps),
+	}
+}
+
+// SetSolverSettings changes the contact solver settings of this physics world.
+// Returns an error and leaves the settings unchanged if NumVelocitySteps is less than 2
+// or NumPositionSteps is less than 1.
+//
+// Example:
+//
+//	// Tall stacks that must not sink
+//	settings := ps.GetSolverSettings()
+//	settings.PenetrationSlop = 0.005
+//	settings.NumPositionSteps = 4
+//	if err := ps.SetSolverSettings(settings); err != nil {
+//	    log.Fatal(err)
+//	}
+func (ps *PhysicsSystem) SetSolverSettings(settings SolverSettings) error {
+	if settings.NumVelocitySteps < 2 {
+		return fmt.Errorf("invalid number of velocity steps %d, expected 2 or more", settings.NumVelocitySteps)
+	}
+	if settings.NumPositionSteps < 1 {
+		return fmt.Errorf("invalid number of position steps %d, expected 1 or more", settings.NumPositionSteps)
+	}
+
+	cSettings := C.JoltSolverSettings{
+		baumgarte:        C.float(settings.Baumgarte),
+		penetrationSlop:  C.float(settings.PenetrationSlop),
+		numVelocitySteps: C.int(settings.NumVelocitySteps),
+		numPositionSteps: C.int(settings.NumPositionSteps),
+	}
+	C.JoltPhysicsSystemSetSolverSettings(ps.handle, &cSettings)
+	return nil
+}
+
+// SetNumVelocitySteps sets the number of velocity solver iterations per step (default: 10).
+// Raise it when constraints or stacks with large mass ratios feel soft.
+// Returns an error if n is less than 2.
+func (ps *PhysicsSystem) SetNumVelocitySteps(n int) error {
+	settings := ps.GetSolverSettings()
+	settings.NumVelocitySteps = n
+	return ps.SetSolverSettings(settings)
+}
+
+// GetNumVelocitySteps returns the number of velocity solver iterations per step
+func (ps *PhysicsSystem) GetNumVelocitySteps() int {
+	return ps.GetSolverSettings().NumVelocitySteps
+}
+
+// SetNumPositionSteps sets the number of position solver iterations per step (default: 2).
+// Raise it when bodies visibly overlap or constraints drift apart.
+// Returns an error if n is less than 1.
+func (ps *PhysicsSystem) SetNumPositionSteps(n int) error {
+	settings := ps.GetSolverSettings()
+	settings.NumPositionSteps = n
+	return ps.SetSolverSettings(settings)
+}
+
+// GetNumPositionSteps returns the number of position solver iterations per step
+func (ps *PhysicsSystem) GetNumPositionSteps() int {
+	return ps.GetSolverSettings().NumPositionSteps
 }
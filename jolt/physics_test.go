@@ -0,0 +1,483 @@
+package jolt
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := Init(); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	Shutdown()
+	os.Exit(code)
+}
+
+func TestPhysicsSystemsAreIsolated(t *testing.T) {
+	// Init is already called by TestMain, calling it again must be safe
+	if err := Init(); err != nil {
+		t.Fatalf("Second Init failed: %v", err)
+	}
+	defer Shutdown()
+
+	earth := NewPhysicsSystem()
+	defer earth.Destroy()
+	moon := NewPhysicsSystem()
+	defer moon.Destroy()
+
+	moon.SetGravity(Vec3{X: 0, Y: -1.62, Z: 0})
+	if g := earth.GetGravity(); g.Y > -9.8 {
+		t.Errorf("Earth gravity = %v, changing another world's gravity must not affect it", g)
+	}
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	drop := func(ps *PhysicsSystem) *BodyID {
+		bi := ps.GetBodyInterface()
+		body := bi.CreateBody(sphere, Vec3{X: 0, Y: 10, Z: 0}, MotionTypeDynamic, false)
+		bi.ActivateBody(body)
+		return body
+	}
+	earthBody := drop(earth)
+	defer earthBody.Destroy()
+	moonBody := drop(moon)
+	defer moonBody.Destroy()
+
+	for i := 0; i < 60; i++ {
+		earth.Update(1.0 / 60.0)
+		moon.Update(1.0 / 60.0)
+	}
+
+	// After 1 second: earth body fell ~4.9 units, moon body ~0.8 units
+	earthY := earth.GetBodyInterface().GetPosition(earthBody).Y
+	moonY := moon.GetBodyInterface().GetPosition(moonBody).Y
+	if earthY > 6 || earthY < 4 {
+		t.Errorf("Earth body Y = %.2f, expected ~5.1", earthY)
+	}
+	if moonY > 9.5 || moonY < 8.9 {
+		t.Errorf("Moon body Y = %.2f, expected ~9.2", moonY)
+	}
+}
+
+func TestInitShutdownRefCounting(t *testing.T) {
+	// TestMain holds one reference for the whole test run
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	Shutdown()
+
+	// Still initialized: a world must keep working
+	ps := NewPhysicsSystem()
+	sphere := CreateSphere(0.5)
+	bi := ps.GetBodyInterface()
+	body := bi.CreateBody(sphere, Vec3{X: 0, Y: 10, Z: 0}, MotionTypeDynamic, false)
+	bi.ActivateBody(body)
+	ps.Update(1.0 / 60.0)
+	if y := bi.GetPosition(body).Y; y >= 10 {
+		t.Errorf("Body Y = %.2f, expected the world to keep simulating", y)
+	}
+	body.Destroy()
+	sphere.Destroy()
+	ps.Destroy()
+
+	Shutdown()
+	if initRefs != 1 {
+		t.Errorf("initRefs = %d, expected only TestMain's reference to remain", initRefs)
+	}
+}
+
+func TestSleepThreshold(t *testing.T) {
+	// Scene in centimeters without gravity, the body drifts at 1 cm/s
+	newDrifter := func(ps *PhysicsSystem) (*BodyInterface, *BodyID) {
+		ps.SetGravity(Vec3{})
+		bi := ps.GetBodyInterface()
+		box := CreateBox(Vec3{X: 50, Y: 50, Z: 50})
+		defer box.Destroy()
+		bodyID := bi.CreateBody(box, Vec3{}, MotionTypeDynamic, false)
+		bi.SetLinearVelocity(bodyID, Vec3{X: 1, Y: 0, Z: 0})
+		return bi, bodyID
+	}
+	const steps = 120
+
+	// The default threshold (0.03 units/s) is far too low for centimeters
+	psDefault := NewPhysicsSystem()
+	defer psDefault.Destroy()
+	biDefault, awake := newDrifter(psDefault)
+	defer awake.Destroy()
+	for i := 0; i < steps; i++ {
+		psDefault.Update(1.0 / 60.0)
+	}
+	if !biDefault.IsActive(awake) {
+		t.Error("Expected the drifting body to stay awake with the default threshold")
+	}
+
+	// Scaled to centimeters the body is considered at rest
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	settings := ps.GetPhysicsSettings()
+	if !settings.AllowSleeping {
+		t.Error("Expected sleeping to be allowed by default")
+	}
+	settings.PointVelocitySleepThreshold = 3
+	ps.SetPhysicsSettings(settings)
+	if got := ps.GetPhysicsSettings(); got != settings {
+		t.Errorf("GetPhysicsSettings = %+v, expected %+v", got, settings)
+	}
+
+	bi, sleeper := newDrifter(ps)
+	defer sleeper.Destroy()
+	for i := 0; i < steps; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+	if bi.IsActive(sleeper) {
+		t.Error("Expected the drifting body to sleep with a centimeter threshold")
+	}
+}
+
+func TestSimulationTime(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	if ps.GetSimulationTime() != 0 || ps.GetStepCount() != 0 {
+		t.Fatalf("New physics system time = %v, steps = %d, expected 0", ps.GetSimulationTime(), ps.GetStepCount())
+	}
+
+	for i := 0; i < 100; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	if steps := ps.GetStepCount(); steps != 100 {
+		t.Errorf("Step count = %d, expected 100", steps)
+	}
+	if simTime := ps.GetSimulationTime(); math.Abs(simTime-100.0/60.0) > 1e-5 {
+		t.Errorf("Simulation time = %.5f, expected ~1.66667", simTime)
+	}
+}
+
+func TestMaxDeltaTime(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetMaxDeltaTime(1.0 / 60.0)
+
+	bi := ps.GetBodyInterface()
+	floorShape := CreateBox(Vec3{X: 10, Y: 0.1, Z: 10})
+	defer floorShape.Destroy()
+	floor := bi.CreateBody(floorShape, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floor.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	ball := bi.CreateBody(sphere, Vec3{X: 0, Y: 2, Z: 0}, MotionTypeDynamic, false)
+	defer ball.Destroy()
+	bi.ActivateBody(ball)
+
+	// A single 1.5 second step would move the ball far past the thin floor
+	ps.Update(1.5)
+
+	if steps := ps.GetStepCount(); steps != 90 {
+		t.Errorf("Step count = %d, expected 90 substeps", steps)
+	}
+	if simTime := ps.GetSimulationTime(); math.Abs(simTime-1.5) > 1e-3 {
+		t.Errorf("Simulation time = %.4f, expected ~1.5", simTime)
+	}
+	if y := bi.GetPosition(ball).Y; y < 0.5 {
+		t.Errorf("Ball Y = %.2f, expected it to rest on the floor (~0.6)", y)
+	}
+
+	// A stall longer than MaxUpdateSubsteps substeps only simulates that many
+	ps.Update(1e9)
+
+	if steps := ps.GetStepCount(); steps != 90+MaxUpdateSubsteps {
+		t.Errorf("Step count = %d, expected %d", steps, 90+MaxUpdateSubsteps)
+	}
+	if simTime := ps.GetSimulationTime(); math.Abs(simTime-1.5-MaxUpdateSubsteps/60.0) > 1e-3 {
+		t.Errorf("Simulation time = %.4f, expected ~%.4f", simTime, 1.5+MaxUpdateSubsteps/60.0)
+	}
+}
+
+func TestUpdateIgnoresInvalidDeltaTime(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	for _, dt := range []float32{0, -1, float32(math.NaN()), float32(math.Inf(1))} {
+		ps.Update(dt)
+	}
+	if steps := ps.GetStepCount(); steps != 0 {
+		t.Errorf("Step count = %d after invalid deltas, expected 0", steps)
+	}
+	if simTime := ps.GetSimulationTime(); simTime != 0 {
+		t.Errorf("Simulation time = %v after invalid deltas, expected 0", simTime)
+	}
+}
+
+func TestUpdateWithSubsteps(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// 1/30s is too large for a single collision step
+	if err := ps.UpdateWithSubsteps(1.0/30.0, 1); err == nil {
+		t.Error("UpdateWithSubsteps(1/30, 1) error = nil, expected the step to be rejected")
+	}
+	if steps := ps.GetStepCount(); steps != 0 {
+		t.Errorf("Step count = %d after a rejected step, expected 0", steps)
+	}
+
+	if err := ps.UpdateWithSubsteps(1.0/60.0, 0); err == nil {
+		t.Error("UpdateWithSubsteps(1/60, 0) error = nil, expected an error for 0 collision steps")
+	}
+
+	if err := ps.UpdateWithSubsteps(1.0/60.0, 1); err != nil {
+		t.Errorf("UpdateWithSubsteps(1/60, 1) error = %v, expected nil", err)
+	}
+	if err := ps.UpdateWithSubsteps(1.0/30.0, 2); err != nil {
+		t.Errorf("UpdateWithSubsteps(1/30, 2) error = %v, expected nil", err)
+	}
+
+	if steps := ps.GetStepCount(); steps != 2 {
+		t.Errorf("Step count = %d, expected 2", steps)
+	}
+	if simTime := ps.GetSimulationTime(); math.Abs(simTime-3.0/60.0) > 1e-5 {
+		t.Errorf("Simulation time = %.5f, expected ~0.05", simTime)
+	}
+}
+
+func TestSetWorldUpZ(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	ps.SetWorldUp(Vec3{X: 0, Y: 0, Z: 2})
+
+	if up := ps.GetWorldUp(); up != (Vec3{X: 0, Y: 0, Z: 1}) {
+		t.Errorf("GetWorldUp() = %v, expected {0, 0, 1}", up)
+	}
+	gravity := ps.GetGravity()
+	if math.Abs(float64(gravity.Z+9.81)) > 1e-4 || gravity.X != 0 || gravity.Y != 0 {
+		t.Errorf("GetGravity() = %v, expected {0, 0, -9.81}", gravity)
+	}
+
+	// A Z-up floor with a body dropped onto it
+	bi := ps.GetBodyInterface()
+	floorShape := CreateBox(Vec3{X: 10, Y: 10, Z: 0.1})
+	defer floorShape.Destroy()
+	floor := bi.CreateBody(floorShape, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floor.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	ball := bi.CreateDynamicBody(sphere, Vec3{X: 0, Y: 0, Z: 5})
+	defer ball.Destroy()
+
+	for i := 0; i < 30; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+	pos := bi.GetPosition(ball)
+	if pos.Z >= 4.5 {
+		t.Errorf("Ball Z = %.2f after 0.5s, expected it to fall along -Z", pos.Z)
+	}
+	if math.Abs(float64(pos.X)) > 1e-3 || math.Abs(float64(pos.Y)) > 1e-3 {
+		t.Errorf("Ball moved sideways to %v, expected only Z to change", pos)
+	}
+
+	// ProbeGround follows the world up direction
+	_, normal, _, hit := ps.ProbeGround(Vec3{X: 0, Y: 0, Z: 1}, 0.25, 2)
+	if !hit {
+		t.Fatal("ProbeGround() found no ground below a Z-up position")
+	}
+	if normal.Z < 0.99 {
+		t.Errorf("Ground normal = %v, expected {0, 0, 1}", normal)
+	}
+
+	// A zero vector is ignored
+	ps.SetWorldUp(Vec3{})
+	if up := ps.GetWorldUp(); up != (Vec3{X: 0, Y: 0, Z: 1}) {
+		t.Errorf("GetWorldUp() = %v after SetWorldUp(zero), expected {0, 0, 1}", up)
+	}
+}
+
+func TestSolverSettingsPenetrationSlop(t *testing.T) {
+	// Returns how far the top of a resting stack of three boxes sank below its ideal height
+	sink := func(configure func(ps *PhysicsSystem)) float32 {
+		ps := NewPhysicsSystem()
+		defer ps.Destroy()
+		configure(ps)
+
+		bi := ps.GetBodyInterface()
+		floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+		defer floor.Destroy()
+		floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+		defer floorID.Destroy()
+
+		box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+		defer box.Destroy()
+		var top *BodyID
+		for i := 0; i < 3; i++ {
+			top = bi.CreateBody(box, Vec3{X: 0, Y: 0.5 + float32(i), Z: 0}, MotionTypeDynamic, false)
+			defer top.Destroy()
+			bi.ActivateBody(top)
+		}
+
+		for i := 0; i < 120; i++ {
+			ps.Update(1.0 / 60.0)
+		}
+		return 2.5 - bi.GetPosition(top).Y
+	}
+
+	defaultSink := sink(func(ps *PhysicsSystem) {
+		settings := ps.GetSolverSettings()
+		if settings.PenetrationSlop <= 0 || settings.NumVelocitySteps <= 0 || settings.NumPositionSteps <= 0 {
+			t.Errorf("Default solver settings = %+v, expected positive values", settings)
+		}
+	})
+	tightSink := sink(func(ps *PhysicsSystem) {
+		settings := ps.GetSolverSettings()
+		settings.PenetrationSlop = 0.001
+		settings.NumPositionSteps = 4
+		if err := ps.SetSolverSettings(settings); err != nil {
+			t.Fatalf("SetSolverSettings = %v, expected nil", err)
+		}
+		if got := ps.GetSolverSettings(); got != settings {
+			t.Errorf("GetSolverSettings = %+v, expected %+v", got, settings)
+		}
+	})
+
+	if tightSink >= defaultSink {
+		t.Errorf("Stack sank %.4f with tight slop, expected less than %.4f with the default", tightSink, defaultSink)
+	}
+}
+
+func TestSetSolverSettingsRejectsTooFewSteps(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	defaults := ps.GetSolverSettings()
+
+	for _, n := range []int{-1, 0, 1} {
+		settings := defaults
+		settings.NumVelocitySteps = n
+		if err := ps.SetSolverSettings(settings); err == nil {
+			t.Errorf("SetSolverSettings with NumVelocitySteps = %d = nil, expected an error", n)
+		}
+		if err := ps.SetNumVelocitySteps(n); err == nil {
+			t.Errorf("SetNumVelocitySteps(%d) = nil, expected an error", n)
+		}
+	}
+	for _, n := range []int{-1, 0} {
+		settings := defaults
+		settings.NumPositionSteps = n
+		if err := ps.SetSolverSettings(settings); err == nil {
+			t.Errorf("SetSolverSettings with NumPositionSteps = %d = nil, expected an error", n)
+		}
+		if err := ps.SetNumPositionSteps(n); err == nil {
+			t.Errorf("SetNumPositionSteps(%d) = nil, expected an error", n)
+		}
+	}
+
+	if got := ps.GetSolverSettings(); got != defaults {
+		t.Errorf("GetSolverSettings = %+v after rejected calls, expected %+v", got, defaults)
+	}
+}
+
+func TestSetNumVelocitySteps(t *testing.T) {
+	// Returns how far a heavy box resting on a light box sank below its ideal height
+	sink := func(velocitySteps int) float32 {
+		ps := NewPhysicsSystem()
+		defer ps.Destroy()
+		if err := ps.SetNumVelocitySteps(velocitySteps); err != nil {
+			t.Fatalf("SetNumVelocitySteps(%d) = %v, expected nil", velocitySteps, err)
+		}
+		if got := ps.GetNumVelocitySteps(); got != velocitySteps {
+			t.Errorf("GetNumVelocitySteps() = %d, expected %d", got, velocitySteps)
+		}
+
+		bi := ps.GetBodyInterface()
+		floor := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+		defer floor.Destroy()
+		floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+		defer floorID.Destroy()
+
+		box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+		defer box.Destroy()
+		light := bi.CreateBody(box, Vec3{X: 0, Y: 0.5, Z: 0}, MotionTypeDynamic, false)
+		defer light.Destroy()
+		heavy := bi.CreateBody(box, Vec3{X: 0, Y: 1.5, Z: 0}, MotionTypeDynamic, false)
+		defer heavy.Destroy()
+
+		// 1000:1 mass ratio, inertia of a unit cube is mass/6
+		bi.SetInertia(light, 1, Vec3{X: 1.0 / 6, Y: 1.0 / 6, Z: 1.0 / 6})
+		bi.SetInertia(heavy, 1000, Vec3{X: 1000.0 / 6, Y: 1000.0 / 6, Z: 1000.0 / 6})
+		bi.ActivateBody(light)
+		bi.ActivateBody(heavy)
+
+		for i := 0; i < 60; i++ {
+			ps.Update(1.0 / 60.0)
+		}
+		return 1.5 - bi.GetPosition(heavy).Y
+	}
+
+	lowSink := sink(2)
+	highSink := sink(40)
+	if highSink >= lowSink {
+		t.Errorf("Heavy box sank %.4f with 40 velocity steps, expected less than %.4f with 2", highSink, lowSink)
+	}
+}
+
+func TestDeterministicMode(t *testing.T) {
+	// Drops a pile of boxes that tumble into each other and returns their final positions
+	simulate := func() []Vec3 {
+		ps := NewPhysicsSystem()
+		defer ps.Destroy()
+		ps.SetDeterministicMode(true)
+		if !ps.IsDeterministicMode() {
+			t.Errorf("IsDeterministicMode() = false, expected true")
+		}
+
+		bi := ps.GetBodyInterface()
+		floor := CreateBox(Vec3{X: 20, Y: 0.5, Z: 20})
+		defer floor.Destroy()
+		floorID := bi.CreateBody(floor, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+		defer floorID.Destroy()
+
+		box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+		defer box.Destroy()
+		var boxIDs []*BodyID
+		for i := 0; i < 20; i++ {
+			position := Vec3{X: float32(i%3) * 0.3, Y: 1 + float32(i)*1.1, Z: float32(i%2) * 0.4}
+			boxID := bi.CreateBody(box, position, MotionTypeDynamic, false)
+			defer boxID.Destroy()
+			bi.ActivateBody(boxID)
+			boxIDs = append(boxIDs, boxID)
+		}
+
+		for i := 0; i < 180; i++ {
+			ps.Update(1.0 / 60.0)
+		}
+
+		positions := make([]Vec3, len(boxIDs))
+		for i, boxID := range boxIDs {
+			positions[i] = bi.GetPosition(boxID)
+		}
+		return positions
+	}
+
+	first := simulate()
+	second := simulate()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Box %d position = %v in the second run, expected %v", i, second[i], first[i])
+		}
+	}
+
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetDeterministicMode(true)
+	ps.SetDeterministicMode(false)
+	if ps.IsDeterministicMode() {
+		t.Errorf("IsDeterministicMode() = true after disabling, expected false")
+	}
+}
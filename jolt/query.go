@@ -2,20 +2,87 @@ package jolt
 
 // #include "wrapper/query.h"
 import "C"
+import (
+	"runtime"
+	"runtime/cgo"
+)
 
 // CollisionHit contains information about a single collision detected during a shape query
 type CollisionHit struct {
 	BodyID           *BodyID // The body that was hit
 	ContactPoint     Vec3    // The contact point in world space
 	PenetrationDepth float32 // How deep the shapes overlap (negative if separated)
+	Normal           Vec3    // Contact normal, the direction to push the query shape out of the hit body
+	SubShapeID2      uint32  // Sub-shape ID of the part of the hit body that was touched
+	IsSensor         bool    // True if the hit body is a sensor (trigger) rather than a solid body
+	IsBackFaceHit    bool    // True if the query shape touched the back of a mesh triangle (requires BackfaceModeCollideWithAll)
 }
 
 // RaycastHit contains information about a single raycast hit
 type RaycastHit struct {
-	BodyID   *BodyID // The body that was hit (nil if no hit)
-	HitPoint Vec3    // The position where the ray hit the surface
-	Normal   Vec3    // The surface normal at the hit point
-	Fraction float32 // The fraction along the ray where the hit occurred [0, 1]
+	Hit         bool    // True if the ray hit a body, false for the zero value of a ray that missed
+	BodyID      *BodyID // The body that was hit (nil if no hit)
+	HitPoint    Vec3    // The position where the ray hit the surface
+	Normal      Vec3    // The surface normal at the hit point
+	Fraction    float32 // The fraction along the ray where the hit occurred [0, 1]
+	Distance    float32 // Distance from the ray origin to the hit point in world units (Fraction * direction length)
+	SubShapeID2 uint32  // Sub-shape ID of the part of the hit body that was hit (see BodyInterface.GetSurfaceNormal)
+}
+
+// ShapeCastHit contains information about the first hit of a swept shape
+type ShapeCastHit struct {
+	BodyID           *BodyID // The body that was hit
+	ContactPoint     Vec3    // The contact point on the hit body in world space
+	Normal           Vec3    // Contact normal, the direction to push the cast shape out of the hit body
+	Fraction         float32 // The fraction along the sweep where the hit occurred [0, 1]
+	PenetrationDepth float32 // Penetration depth at the hit, only non-zero if the shape starts in collision
+}
+
+// ActiveEdgeMode determines how shape queries treat internal edges of meshes
+type ActiveEdgeMode int
+
+const (
+	// ActiveEdgeModeCollideOnlyWithActive treats contacts on edges between coplanar triangles
+	// as contacts with the face, which avoids ghost contacts on internal mesh edges (default)
+	ActiveEdgeModeCollideOnlyWithActive ActiveEdgeMode = 0
+	// ActiveEdgeModeCollideWithAll reports the real contact normal for every edge
+	ActiveEdgeModeCollideWithAll ActiveEdgeMode = 1
+)
+
+// CollideShapeSettings contains settings for shape collision queries
+type CollideShapeSettings struct {
+	ActiveEdgeMode       ActiveEdgeMode // How to handle internal mesh edges
+	BackfaceMode         BackfaceMode   // How to handle back facing triangles
+	PenetrationTolerance float32        // Accuracy of the penetration depth calculation (see CollideShapeGetHits)
+
+	// MaxSeparationDistance also reports bodies that are up to this distance away without touching,
+	// with a negative PenetrationDepth (minus the separation). 0 only reports overlapping bodies.
+	MaxSeparationDistance float32
+}
+
+// DefaultCollideShapeSettings returns the default settings used by Jolt for shape collision queries
+func DefaultCollideShapeSettings() CollideShapeSettings {
+	return CollideShapeSettings{
+		ActiveEdgeMode:       ActiveEdgeModeCollideOnlyWithActive,
+		BackfaceMode:         BackfaceModeIgnore,
+		PenetrationTolerance: 1.0e-4,
+	}
+}
+
+// collideShapeWithMaxDistanceHits is the number of hits CollideShapeWithMaxDistance returns at most
+const collideShapeWithMaxDistanceHits = 64
+
+// transformedShapeQueryHits is the number of hits CollideShapesTransformed and CastRayTransformed return at most
+const transformedShapeQueryHits = 64
+
+// toC converts the settings to their C representation
+func (settings CollideShapeSettings) toC() C.JoltCollideShapeSettings {
+	return C.JoltCollideShapeSettings{
+		activeEdgeMode:        C.int(settings.ActiveEdgeMode),
+		backFaceMode:          C.int(settings.BackfaceMode),
+		penetrationTolerance:  C.float(settings.PenetrationTolerance),
+		maxSeparationDistance: C.float(settings.MaxSeparationDistance),
+	}
 }
 
 // CollideShape checks if a shape at the given position collides with any bodies in the physics system.
@@ -45,9 +112,55 @@ func (ps *PhysicsSystem) CollideShape(shape *Shape, position Vec3, penetrationTo
 		C.float(position.Z),
 		C.float(penetrationTolerance),
 	)
+	runtime.KeepAlive(shape)
+	return result != 0
+}
+
+// CollideShapeWithSettings checks for collisions like CollideShape, but with explicit collide settings.
+//
+// Example usage:
+//
+//	settings := jolt.DefaultCollideShapeSettings()
+//	settings.BackfaceMode = jolt.BackfaceModeCollideWithAll
+//	inside := ps.CollideShapeWithSettings(probe, position, settings)
+func (ps *PhysicsSystem) CollideShapeWithSettings(shape *Shape, position Vec3, settings CollideShapeSettings) bool {
+	cSettings := settings.toC()
+	result := C.JoltCollideShapeWithSettings(
+		ps.handle,
+		shape.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		&cSettings,
+	)
+	runtime.KeepAlive(shape)
 	return result != 0
 }
 
+// CountOverlaps returns the number of bodies a shape at the given position overlaps, using the
+// default collide settings. It skips building hit results, so it is cheaper than
+// len(CollideShapeGetHits(...)) in hot loops, e.g. to check whether a character is stuck.
+// A body is counted once, even if the shape touches several of its triangles or sub shapes.
+//
+// Example usage:
+//
+//	if ps.CountOverlaps(capsule, character.GetPosition()) > 1 {
+//	    character.SafeTeleport(lastSafePosition)
+//	}
+func (ps *PhysicsSystem) CountOverlaps(shape *Shape, position Vec3) int {
+	cSettings := DefaultCollideShapeSettings().toC()
+	result := C.JoltCountOverlaps(
+		ps.handle,
+		shape.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		&cSettings,
+	)
+	runtime.KeepAlive(shape)
+	return int(result)
+}
+
 // CollideShapeGetHits performs a shape collision query and returns detailed information about all hits.
 // This is useful when you need to know which specific bodies were hit and where.
 //
@@ -89,25 +202,299 @@ func (ps *PhysicsSystem) CollideShapeGetHits(shape *Shape, position Vec3, maxHit
 		C.int(maxHits),
 		C.float(penetrationTolerance),
 	)
+	runtime.KeepAlive(shape)
 
 	// Convert C results to Go
 	hits := make([]CollisionHit, int(numHits))
 	for i := 0; i < int(numHits); i++ {
-		cHit := cHits[i]
-		hits[i] = CollisionHit{
-			BodyID: &BodyID{handle: cHit.bodyID},
-			ContactPoint: Vec3{
-				X: float32(cHit.contactPointX),
-				Y: float32(cHit.contactPointY),
-				Z: float32(cHit.contactPointZ),
-			},
-			PenetrationDepth: float32(cHit.penetrationDepth),
-		}
+		hits[i] = toCollisionHit(&cHits[i])
+	}
+
+	return hits
+}
+
+// CollideShapeGetHitsWithSettings returns all hits like CollideShapeGetHits, but with explicit collide settings.
+// Use ActiveEdgeModeCollideWithAll to get the real normals on internal mesh edges, or the default
+// ActiveEdgeModeCollideOnlyWithActive to avoid ghost contacts when sliding over a mesh.
+//
+// Example usage:
+//
+//	settings := jolt.DefaultCollideShapeSettings()
+//	settings.ActiveEdgeMode = jolt.ActiveEdgeModeCollideWithAll
+//	hits := ps.CollideShapeGetHitsWithSettings(sphere, position, 10, settings)
+func (ps *PhysicsSystem) CollideShapeGetHitsWithSettings(shape *Shape, position Vec3, maxHits int, settings CollideShapeSettings) []CollisionHit {
+	if maxHits <= 0 {
+		return []CollisionHit{}
+	}
+
+	// Allocate C array for results
+	cHits := make([]C.JoltCollisionHit, maxHits)
+	cSettings := settings.toC()
+
+	numHits := C.JoltCollideShapeGetHitsWithSettings(
+		ps.handle,
+		shape.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		&cSettings,
+		&cHits[0],
+		C.int(maxHits),
+	)
+	runtime.KeepAlive(shape)
+
+	// Convert C results to Go
+	hits := make([]CollisionHit, int(numHits))
+	for i := 0; i < int(numHits); i++ {
+		hits[i] = toCollisionHit(&cHits[i])
 	}
 
 	return hits
 }
 
+// CollideShapeWithMaxDistance returns the bodies that overlap the shape at position, and those
+// that are within maxSeparation of it without touching, e.g. for proximity warnings.
+// Separated hits have a negative PenetrationDepth: -0.3 means the shape is 0.3 units away.
+// Returns at most 64 hits, use CollideShapeGetHitsWithSettings with MaxSeparationDistance for more.
+//
+// Example usage:
+//
+//	for _, hit := range ps.CollideShapeWithMaxDistance(probe, position, 2) {
+//	    if hit.PenetrationDepth < 0 {
+//	        warnProximity(hit.BodyID, -hit.PenetrationDepth)
+//	    }
+//	    hit.BodyID.Destroy()
+//	}
+func (ps *PhysicsSystem) CollideShapeWithMaxDistance(shape *Shape, position Vec3, maxSeparation float32) []CollisionHit {
+	settings := DefaultCollideShapeSettings()
+	settings.MaxSeparationDistance = maxSeparation
+	return ps.CollideShapeGetHitsWithSettings(shape, position, collideShapeWithMaxDistanceHits, settings)
+}
+
+// CollideShapeDeepest performs a shape collision query and returns only the contact with the
+// largest penetration depth. This is cheaper than CollideShapeGetHits since shallower hits
+// are skipped early, and is convenient for depenetration ("am I stuck and how deep").
+//
+// Returns the deepest hit and true, or false if the shape doesn't collide with anything.
+//
+// Example usage:
+//
+//	if hit, ok := ps.CollideShapeDeepest(sphere, position); ok {
+//	    defer hit.BodyID.Destroy()
+//	    position = position.Add(hit.Normal.Mul(hit.PenetrationDepth))
+//	}
+func (ps *PhysicsSystem) CollideShapeDeepest(shape *Shape, position Vec3) (CollisionHit, bool) {
+	var cHit C.JoltCollisionHit
+
+	hit := C.JoltCollideShapeDeepest(
+		ps.handle,
+		shape.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		&cHit,
+	)
+	runtime.KeepAlive(shape)
+	if hit == 0 {
+		return CollisionHit{}, false
+	}
+
+	return toCollisionHit(&cHit), true
+}
+
+// ApplyRadialImpulse pushes all dynamic bodies within radius of center outwards, e.g. for explosions.
+// Each body receives an impulse from center towards its center of mass, with magnitude strength
+// at the center falling off linearly to zero at radius. Sleeping bodies are woken up.
+//
+// Returns the number of bodies that received a non-zero impulse, so bodies that only touch the edge
+// of the sphere with their center of mass outside radius are not counted. Returns 0 if radius is
+// not positive.
+//
+// Example usage:
+//
+//	// Grenade: 5m blast radius, up to 500 N·s impulse
+//	ps.ApplyRadialImpulse(grenadePos, 5, 500)
+func (ps *PhysicsSystem) ApplyRadialImpulse(center Vec3, radius, strength float32) int {
+	if !(radius > 0) {
+		return 0
+	}
+
+	return int(C.JoltApplyRadialImpulse(
+		ps.handle,
+		C.float(center.X),
+		C.float(center.Y),
+		C.float(center.Z),
+		C.float(radius),
+		C.float(strength),
+	))
+}
+
+// CastShape sweeps a shape from position along direction and returns the first hit.
+// The direction vector does not need to be normalized - its length determines the sweep distance.
+// The shape stops at position + direction * hit.Fraction.
+//
+// Example usage:
+//
+//	hit, ok := ps.CastShape(crate, start, jolt.Vec3{X: 10, Y: 0, Z: 0})
+//	if ok {
+//	    defer hit.BodyID.Destroy()
+//	    stop := start.Add(jolt.Vec3{X: 10, Y: 0, Z: 0}.Mul(hit.Fraction))
+//	}
+func (ps *PhysicsSystem) CastShape(shape *Shape, position, direction Vec3) (ShapeCastHit, bool) {
+	var cHit C.JoltShapeCastHit
+
+	hit := C.JoltCastShape(
+		ps.handle,
+		shape.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		&cHit,
+	)
+	runtime.KeepAlive(shape)
+	if hit == 0 {
+		return ShapeCastHit{}, false
+	}
+
+	return ShapeCastHit{
+		BodyID: newBodyID(cHit.bodyID),
+		ContactPoint: Vec3{
+			X: float32(cHit.contactPointX),
+			Y: float32(cHit.contactPointY),
+			Z: float32(cHit.contactPointZ),
+		},
+		Normal: Vec3{
+			X: float32(cHit.normalX),
+			Y: float32(cHit.normalY),
+			Z: float32(cHit.normalZ),
+		},
+		Fraction:         float32(cHit.fraction),
+		PenetrationDepth: float32(cHit.penetrationDepth),
+	}, true
+}
+
+// SphereCast sweeps a sphere of the given radius from origin along direction and returns the first hit.
+// This is CastShape for the common case of sweeping a sphere (projectiles, camera collision).
+// Sphere shapes are cached per radius by the physics system, so repeated casts don't allocate.
+// Only the first maxCachedSpheres radii are cached, casts with other radii create a temporary sphere.
+// Returns false if radius <= 0.
+//
+// Example usage:
+//
+//	hit, ok := ps.SphereCast(camTarget, camOffset, 0.2)
+//	if ok {
+//	    defer hit.BodyID.Destroy()
+//	    camOffset = camOffset.Mul(hit.Fraction)
+//	}
+func (ps *PhysicsSystem) SphereCast(origin, direction Vec3, radius float32) (ShapeCastHit, bool) {
+	if !(radius > 0) {
+		return ShapeCastHit{}, false
+	}
+
+	sphere, cached := ps.cachedSphere(radius)
+	if sphere == nil || sphere.handle == nil {
+		return ShapeCastHit{}, false
+	}
+	if !cached {
+		defer sphere.Destroy()
+	}
+	return ps.CastShape(sphere, origin, direction)
+}
+
+// maxCachedSpheres bounds the number of sphere shapes cached by SphereCast
+const maxCachedSpheres = 32
+
+// cachedSphere returns the sphere shape with the given radius, creating it on first use.
+// Once the cache is full it returns a new sphere that is not cached (cached = false) and must be destroyed.
+func (ps *PhysicsSystem) cachedSphere(radius float32) (sphere *Shape, cached bool) {
+	ps.sphereCacheMu.Lock()
+	defer ps.sphereCacheMu.Unlock()
+
+	if sphere, ok := ps.sphereCache[radius]; ok {
+		return sphere, true
+	}
+
+	sphere = CreateSphere(radius)
+	if sphere == nil || sphere.handle == nil || len(ps.sphereCache) >= maxCachedSpheres {
+		return sphere, false
+	}
+	if ps.sphereCache == nil {
+		ps.sphereCache = make(map[float32]*Shape)
+	}
+	ps.sphereCache[radius] = sphere
+	return sphere, true
+}
+
+// ProbeGround sweeps a sphere of the given radius straight down from position to find the ground
+// below it, e.g. for a custom character controller that doesn't use CharacterVirtual.
+// The sphere is centered at position and moves at most maxDistance against the world up direction
+// (-Y unless changed with SetWorldUp).
+//
+// Returns:
+//   - groundPos: The contact point on the ground
+//   - groundNormal: The ground surface normal (pointing up for flat ground)
+//   - distance: How far the sphere moved down before touching the ground
+//   - hit: true if ground was found within maxDistance, false otherwise or if radius isn't
+//     positive or maxDistance is negative
+//
+// Example usage:
+//
+//	_, normal, distance, hit := ps.ProbeGround(feetPos.Add(jolt.Vec3{Y: 0.3}), 0.3, 0.1)
+//	grounded := hit && distance < 0.05 && normal.Y > 0.7
+func (ps *PhysicsSystem) ProbeGround(position Vec3, radius, maxDistance float32) (groundPos, groundNormal Vec3, distance float32, hit bool) {
+	if !(radius > 0) || !(maxDistance >= 0) {
+		return Vec3{}, Vec3{}, 0, false
+	}
+
+	var posX, posY, posZ, normalX, normalY, normalZ, dist C.float
+
+	result := C.JoltProbeGround(
+		ps.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		C.float(ps.worldUp.X),
+		C.float(ps.worldUp.Y),
+		C.float(ps.worldUp.Z),
+		C.float(radius),
+		C.float(maxDistance),
+		&posX, &posY, &posZ,
+		&normalX, &normalY, &normalZ,
+		&dist,
+	)
+	if result == 0 {
+		return Vec3{}, Vec3{}, 0, false
+	}
+
+	groundPos = Vec3{X: float32(posX), Y: float32(posY), Z: float32(posZ)}
+	groundNormal = Vec3{X: float32(normalX), Y: float32(normalY), Z: float32(normalZ)}
+	return groundPos, groundNormal, float32(dist), true
+}
+
+// toCollisionHit converts a C collision hit to Go (takes ownership of the body ID)
+func toCollisionHit(cHit *C.JoltCollisionHit) CollisionHit {
+	return CollisionHit{
+		BodyID: newBodyID(cHit.bodyID),
+		ContactPoint: Vec3{
+			X: float32(cHit.contactPointX),
+			Y: float32(cHit.contactPointY),
+			Z: float32(cHit.contactPointZ),
+		},
+		PenetrationDepth: float32(cHit.penetrationDepth),
+		Normal: Vec3{
+			X: float32(cHit.normalX),
+			Y: float32(cHit.normalY),
+			Z: float32(cHit.normalZ),
+		},
+		SubShapeID2:   uint32(cHit.subShapeID2),
+		IsSensor:      cHit.isSensor != 0,
+		IsBackFaceHit: cHit.isBackFaceHit != 0,
+	}
+}
+
 // CastRay performs a raycast from origin in the specified direction and returns the closest hit.
 // The direction vector does not need to be normalized - its length determines the maximum ray distance.
 //
@@ -130,9 +517,21 @@ func (ps *PhysicsSystem) CollideShapeGetHits(shape *Shape, position Vec3, maxHit
 //	        hit.HitPoint.X, hit.HitPoint.Y, hit.HitPoint.Z, hit.Fraction)
 //	}
 func (ps *PhysicsSystem) CastRay(origin, direction Vec3) (RaycastHit, bool) {
+	return ps.CastRayWithSettings(origin, direction, DefaultRayCastSettings())
+}
+
+// CastRayWithSettings performs a raycast like CastRay, but with explicit raycast settings.
+// Use BackfaceModeCollideWithAll to hit back faces, e.g. when casting from inside a mesh room.
+//
+// Example usage:
+//
+//	settings := jolt.DefaultRayCastSettings()
+//	settings.BackfaceMode = jolt.BackfaceModeCollideWithAll
+//	hit, ok := ps.CastRayWithSettings(insideRoom, jolt.Vec3{X: 0, Y: -10, Z: 0}, settings)
+func (ps *PhysicsSystem) CastRayWithSettings(origin, direction Vec3, settings RayCastSettings) (RaycastHit, bool) {
 	var cHit C.JoltRaycastHit
 
-	result := C.JoltCastRay(
+	result := C.JoltCastRayWithSettings(
 		ps.handle,
 		C.float(origin.X),
 		C.float(origin.Y),
@@ -140,6 +539,8 @@ func (ps *PhysicsSystem) CastRay(origin, direction Vec3) (RaycastHit, bool) {
 		C.float(direction.X),
 		C.float(direction.Y),
 		C.float(direction.Z),
+		C.int(settings.BackfaceMode),
+		C.int(boolToInt(settings.TreatConvexAsSolid)),
 		&cHit,
 	)
 
@@ -147,8 +548,88 @@ func (ps *PhysicsSystem) CastRay(origin, direction Vec3) (RaycastHit, bool) {
 		return RaycastHit{}, false
 	}
 
-	hit := RaycastHit{
-		BodyID: &BodyID{handle: cHit.bodyID},
+	return toRaycastHit(&cHit, direction), true
+}
+
+// CastRayFiltered performs a raycast like CastRay, but only hits bodies for which accept returns true.
+// This allows arbitrary Go logic on top of the collision layers (e.g. only enemies below a health threshold).
+//
+// accept is called for every candidate body whose bounding box the ray passes through.
+// Each call crosses from C++ into Go, which costs far more than a layer check, so prefer
+// layers for coarse filtering and keep the predicate cheap.
+// The bodyID passed to accept is only valid during the call and must not be destroyed or kept.
+// accept is called while the body is locked, so it must not call back into the physics system.
+//
+// Example usage:
+//
+//	hit, ok := ps.CastRayFiltered(origin, direction, func(bodyID *jolt.BodyID, userData uint64) bool {
+//	    return enemies[userData].Health < 20
+//	})
+func (ps *PhysicsSystem) CastRayFiltered(origin, direction Vec3, accept func(bodyID *BodyID, userData uint64) bool) (RaycastHit, bool) {
+	filter := cgo.NewHandle(accept)
+	defer filter.Delete()
+
+	var cHit C.JoltRaycastHit
+
+	result := C.JoltCastRayFiltered(
+		ps.handle,
+		C.float(origin.X),
+		C.float(origin.Y),
+		C.float(origin.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		C.uintptr_t(filter),
+		&cHit,
+	)
+
+	if result == 0 {
+		return RaycastHit{}, false
+	}
+
+	return toRaycastHit(&cHit, direction), true
+}
+
+// CastRaySolid performs a raycast like CastRay, but passes through sensor bodies (triggers),
+// e.g. for line-of-sight checks that trigger volumes must never block.
+// This is cheaper than CastRayFiltered since the filter runs without calling into Go.
+//
+// Example usage:
+//
+//	_, blocked := ps.CastRaySolid(eyes, target.Sub(eyes))
+//	canSee := !blocked
+func (ps *PhysicsSystem) CastRaySolid(origin, direction Vec3) (RaycastHit, bool) {
+	var cHit C.JoltRaycastHit
+
+	result := C.JoltCastRaySolid(
+		ps.handle,
+		C.float(origin.X),
+		C.float(origin.Y),
+		C.float(origin.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		&cHit,
+	)
+
+	if result == 0 {
+		return RaycastHit{}, false
+	}
+
+	return toRaycastHit(&cHit, direction), true
+}
+
+//export goJoltBodyFilterAccept
+func goJoltBodyFilterAccept(filter C.uintptr_t, bodyID C.JoltBodyID, userData C.ulonglong) C.int {
+	accept := cgo.Handle(filter).Value().(func(bodyID *BodyID, userData uint64) bool)
+	return C.int(boolToInt(accept(&BodyID{handle: bodyID}, uint64(userData))))
+}
+
+// toRaycastHit converts a C raycast hit of a ray with the given direction to Go (takes ownership of the body ID)
+func toRaycastHit(cHit *C.JoltRaycastHit, direction Vec3) RaycastHit {
+	return RaycastHit{
+		Hit:    true,
+		BodyID: newBodyID(cHit.bodyID),
 		HitPoint: Vec3{
 			X: float32(cHit.hitPointX),
 			Y: float32(cHit.hitPointY),
@@ -159,10 +640,56 @@ func (ps *PhysicsSystem) CastRay(origin, direction Vec3) (RaycastHit, bool) {
 			Y: float32(cHit.normalY),
 			Z: float32(cHit.normalZ),
 		},
-		Fraction: float32(cHit.fraction),
+		Fraction:    float32(cHit.fraction),
+		Distance:    float32(cHit.fraction) * direction.Length(),
+		SubShapeID2: uint32(cHit.subShapeID2),
+	}
+}
+
+// CastRaysBatch casts many rays in a single call and returns the closest hit of each ray.
+// This is much cheaper than calling CastRay in a loop, since the cost of crossing from Go
+// into C is paid once instead of once per ray (e.g. for AI sensor fans).
+//
+// hits[i] is the result of ray i, its Hit flag is false (and BodyID nil) if the ray missed.
+// origins and directions must have the same length, otherwise no rays are cast and the result is empty.
+//
+// Example usage:
+//
+//	hits := ps.CastRaysBatch(eyes, fan)
+//	for i, hit := range hits {
+//	    if hit.Hit {
+//	        defer hit.BodyID.Destroy()
+//	        fmt.Printf("Ray %d hit at distance %.2f\n", i, hit.Distance)
+//	    }
+//	}
+func (ps *PhysicsSystem) CastRaysBatch(origins, directions []Vec3) []RaycastHit {
+	if len(origins) != len(directions) || len(origins) == 0 {
+		return []RaycastHit{}
+	}
+
+	// Flatten Vec3 slices to float arrays
+	cOrigins := make([]C.float, len(origins)*3)
+	cDirections := make([]C.float, len(directions)*3)
+	for i := range origins {
+		cOrigins[i*3] = C.float(origins[i].X)
+		cOrigins[i*3+1] = C.float(origins[i].Y)
+		cOrigins[i*3+2] = C.float(origins[i].Z)
+		cDirections[i*3] = C.float(directions[i].X)
+		cDirections[i*3+1] = C.float(directions[i].Y)
+		cDirections[i*3+2] = C.float(directions[i].Z)
+	}
+
+	cHits := make([]C.JoltRaycastHit, len(origins))
+	C.JoltCastRaysBatch(ps.handle, &cOrigins[0], &cDirections[0], C.int(len(origins)), &cHits[0])
+
+	hits := make([]RaycastHit, len(origins))
+	for i := range cHits {
+		if cHits[i].bodyID != nil {
+			hits[i] = toRaycastHit(&cHits[i], directions[i])
+		}
 	}
 
-	return hit, true
+	return hits
 }
 
 // CastRayGetHits performs a raycast and returns all hits along the ray, sorted by distance.
@@ -208,22 +735,234 @@ func (ps *PhysicsSystem) CastRayGetHits(origin, direction Vec3, maxHits int) []R
 	// Convert C results to Go
 	hits := make([]RaycastHit, int(numHits))
 	for i := 0; i < int(numHits); i++ {
-		cHit := cHits[i]
-		hits[i] = RaycastHit{
-			BodyID: &BodyID{handle: cHit.bodyID},
-			HitPoint: Vec3{
-				X: float32(cHit.hitPointX),
-				Y: float32(cHit.hitPointY),
-				Z: float32(cHit.hitPointZ),
-			},
-			Normal: Vec3{
-				X: float32(cHit.normalX),
-				Y: float32(cHit.normalY),
-				Z: float32(cHit.normalZ),
-			},
-			Fraction: float32(cHit.fraction),
+		hits[i] = toRaycastHit(&cHits[i], direction)
+	}
+
+	return hits
+}
+
+// CastRayAllFiltered performs a raycast like CastRayGetHits, but passes through the bodies in exclude
+// and only hits bodies whose object layer is in layerMask (see LayerMask).
+// Filtering happens during the query, so excluded bodies never take up one of the maxHits slots.
+//
+// Example usage:
+//
+//	// Everything the bullet passes through, except the shooter and query-only markers
+//	mask := jolt.LayerMask(jolt.ObjectLayerNonMoving, jolt.ObjectLayerMoving)
+//	hits := ps.CastRayAllFiltered(muzzle, aim.Mul(100), 16, []*jolt.BodyID{shooter}, mask)
+//	for _, hit := range hits {
+//	    defer hit.BodyID.Destroy()
+//	}
+func (ps *PhysicsSystem) CastRayAllFiltered(origin, direction Vec3, maxHits int, exclude []*BodyID, layerMask uint32) []RaycastHit {
+	if maxHits <= 0 {
+		return []RaycastHit{}
+	}
+
+	var excludeHandles *C.JoltBodyID
+	if len(exclude) > 0 {
+		handles := make([]C.JoltBodyID, len(exclude))
+		for i, bodyID := range exclude {
+			handles[i] = bodyID.handle
 		}
+		excludeHandles = &handles[0]
+	}
+
+	cHits := make([]C.JoltRaycastHit, maxHits)
+
+	numHits := C.JoltCastRayGetHitsFiltered(
+		ps.handle,
+		C.float(origin.X),
+		C.float(origin.Y),
+		C.float(origin.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		excludeHandles,
+		C.int(len(exclude)),
+		C.uint(layerMask),
+		&cHits[0],
+		C.int(maxHits),
+	)
+	runtime.KeepAlive(exclude)
+
+	hits := make([]RaycastHit, int(numHits))
+	for i := range hits {
+		hits[i] = toRaycastHit(&cHits[i], direction)
 	}
 
 	return hits
 }
+
+// transformedShapeHandles returns the handles of targets, leaving out nil targets
+func transformedShapeHandles(targets []*TransformedShape) []C.JoltTransformedShape {
+	handles := make([]C.JoltTransformedShape, 0, len(targets))
+	for _, target := range targets {
+		if target != nil && target.handle != nil {
+			handles = append(handles, target.handle)
+		}
+	}
+	return handles
+}
+
+// CollideShapesTransformed checks a shape at a position against a list of transformed shapes
+// instead of the bodies of a physics system, e.g. for decoration kept outside the simulation.
+// Returns up to 64 hits with the default collide settings.
+//
+// The BodyID of each hit is the bodyID the target was created with (see CreateTransformedShape),
+// so give targets distinct IDs to tell them apart with GetIndexAndSequenceNumber.
+// The caller must destroy the returned body IDs.
+//
+// Example usage:
+//
+//	hits := jolt.CollideShapesTransformed(probe, position, decorations)
+//	for _, hit := range hits {
+//	    fmt.Printf("Touching decoration %d\n", hit.BodyID.GetIndexAndSequenceNumber())
+//	    hit.BodyID.Destroy()
+//	}
+func CollideShapesTransformed(query *Shape, position Vec3, targets []*TransformedShape) []CollisionHit {
+	handles := transformedShapeHandles(targets)
+	if query == nil || len(handles) == 0 {
+		return []CollisionHit{}
+	}
+
+	cSettings := DefaultCollideShapeSettings().toC()
+	cHits := make([]C.JoltCollisionHit, transformedShapeQueryHits)
+
+	numHits := C.JoltCollideShapeTransformed(
+		query.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		&handles[0],
+		C.int(len(handles)),
+		&cSettings,
+		&cHits[0],
+		C.int(len(cHits)),
+	)
+	runtime.KeepAlive(query)
+
+	hits := make([]CollisionHit, int(numHits))
+	for i := range hits {
+		hits[i] = toCollisionHit(&cHits[i])
+	}
+	return hits
+}
+
+// CastRayTransformed casts a ray against a list of transformed shapes instead of the bodies of
+// a physics system, and returns up to 64 hits sorted by distance (closest first).
+// The direction vector does not need to be normalized - its length determines the maximum ray distance.
+//
+// The BodyID of each hit is the bodyID the target was created with (see CreateTransformedShape).
+// The caller must destroy the returned body IDs.
+//
+// Example usage:
+//
+//	hits := jolt.CastRayTransformed(eye, look.Mul(50), decorations)
+//	if len(hits) > 0 {
+//	    fmt.Printf("Looking at decoration %d\n", hits[0].BodyID.GetIndexAndSequenceNumber())
+//	}
+func CastRayTransformed(origin, direction Vec3, targets []*TransformedShape) []RaycastHit {
+	handles := transformedShapeHandles(targets)
+	if len(handles) == 0 {
+		return []RaycastHit{}
+	}
+
+	cHits := make([]C.JoltRaycastHit, transformedShapeQueryHits)
+
+	numHits := C.JoltCastRayTransformed(
+		&handles[0],
+		C.int(len(handles)),
+		C.float(origin.X),
+		C.float(origin.Y),
+		C.float(origin.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		&cHits[0],
+		C.int(len(cHits)),
+	)
+
+	hits := make([]RaycastHit, int(numHits))
+	for i := range hits {
+		hits[i] = toRaycastHit(&cHits[i], direction)
+	}
+	return hits
+}
+
+// CastRayAgainstBody performs a raycast against a single body, ignoring every other body in the world.
+// Useful for tracking one specific target (e.g. a laser sight following a tracked enemy).
+// The direction vector does not need to be normalized - its length determines the maximum ray distance.
+//
+// Parameters:
+//   - bodyID: The only body the ray can hit
+//   - origin: Starting position of the ray in world space
+//   - direction: Direction and length of the ray (ray goes from origin to origin + direction)
+//
+// Returns:
+//   - hit: Information about the hit (HitPoint, Normal, Fraction, BodyID)
+//   - hasHit: true if the ray hit the body, false otherwise
+//
+// Example usage:
+//
+//	hit, hasHit := bi.CastRayAgainstBody(enemy, muzzle, aim.Mul(100))
+//	if hasHit {
+//	    fmt.Printf("Laser dot at %.2f, %.2f, %.2f\n", hit.HitPoint.X, hit.HitPoint.Y, hit.HitPoint.Z)
+//	}
+func (bi *BodyInterface) CastRayAgainstBody(bodyID *BodyID, origin, direction Vec3) (RaycastHit, bool) {
+	var cHit C.JoltRaycastHit
+
+	result := C.JoltCastRayAgainstBody(
+		bi.handle,
+		bodyID.handle,
+		C.float(origin.X),
+		C.float(origin.Y),
+		C.float(origin.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		&cHit,
+	)
+	runtime.KeepAlive(bodyID)
+
+	if result == 0 {
+		return RaycastHit{}, false
+	}
+
+	return toRaycastHit(&cHit, direction), true
+}
+
+// CastRayThrough casts a ray through the first solid body it hits (passing through sensors)
+// and returns where the ray enters and leaves that body, e.g. for bullet exit wounds or refraction.
+// The exit normal is the outward surface normal, so it points along the ray.
+// Returns ok=false if nothing is hit or the ray ends inside the body.
+//
+// Example usage:
+//
+//	entry, exit, ok := ps.CastRayThrough(muzzle, aim.Mul(100))
+//	if ok {
+//	    defer entry.BodyID.Destroy()
+//	    defer exit.BodyID.Destroy()
+//	    thickness := exit.HitPoint.Sub(entry.HitPoint).Length()
+//	    fmt.Printf("Bullet went through %.2f m\n", thickness)
+//	}
+func (ps *PhysicsSystem) CastRayThrough(origin, direction Vec3) (entry, exit RaycastHit, ok bool) {
+	var cEntry, cExit C.JoltRaycastHit
+
+	result := C.JoltCastRayThrough(
+		ps.handle,
+		C.float(origin.X),
+		C.float(origin.Y),
+		C.float(origin.Z),
+		C.float(direction.X),
+		C.float(direction.Y),
+		C.float(direction.Z),
+		&cEntry,
+		&cExit,
+	)
+
+	if result == 0 {
+		return RaycastHit{}, RaycastHit{}, false
+	}
+
+	return toRaycastHit(&cEntry, direction), toRaycastHit(&cExit, direction), true
+}
@@ -0,0 +1,938 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCastRayAgainstBody(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+
+	// Two overlapping boxes along the X axis, the near one covers X in [-1, 1]
+	near := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer near.Destroy()
+	far := bi.CreateBody(box, Vec3{X: 1.5, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer far.Destroy()
+
+	origin := Vec3{X: -10, Y: 0, Z: 0}
+	direction := Vec3{X: 20, Y: 0, Z: 0}
+
+	hit, hasHit := bi.CastRayAgainstBody(far, origin, direction)
+	if !hasHit {
+		t.Fatal("Ray should have hit the targeted body")
+	}
+	defer hit.BodyID.Destroy()
+
+	// Far box starts at X = 0.5, even though the near box is hit first in world order
+	if math.Abs(float64(hit.HitPoint.X-0.5)) > 0.01 {
+		t.Errorf("Hit point X = %.2f, expected ~0.5 (near face of the far body)", hit.HitPoint.X)
+	}
+	if hit.Normal.X > -0.99 {
+		t.Errorf("Normal = %v, expected to point along -X", hit.Normal)
+	}
+}
+
+func TestCollideShapeGetHitsNormal(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 5, Y: 0.5, Z: 5})
+	defer box.Destroy()
+
+	// Box top face is at Y = 0.5
+	ground := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer ground.Destroy()
+
+	sphere := CreateSphere(1.0)
+	defer sphere.Destroy()
+
+	// Sphere sunk 0.5 units into the top face
+	hits := ps.CollideShapeGetHits(sphere, Vec3{X: 0, Y: 1, Z: 0}, 10, 0)
+	if len(hits) == 0 {
+		t.Fatal("Sphere should overlap the box")
+	}
+	for _, hit := range hits {
+		hit.BodyID.Destroy()
+	}
+
+	normal := hits[0].Normal
+	if math.Abs(float64(normal.Y-1)) > 0.01 {
+		t.Errorf("Normal = %v, expected to point up along the face axis", normal)
+	}
+}
+
+func TestCountOverlaps(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	// Two boxes side by side with a gap of 0.2 between them
+	left := bi.CreateBody(box, Vec3{X: -0.6, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer left.Destroy()
+	right := bi.CreateBody(box, Vec3{X: 0.6, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer right.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	if got := ps.CountOverlaps(sphere, Vec3{X: 0, Y: 0, Z: 0}); got != 2 {
+		t.Errorf("CountOverlaps() between the boxes = %d, expected 2", got)
+	}
+	if got := ps.CountOverlaps(sphere, Vec3{X: 1.5, Y: 0, Z: 0}); got != 1 {
+		t.Errorf("CountOverlaps() touching the right box = %d, expected 1", got)
+	}
+	if got := ps.CountOverlaps(sphere, Vec3{X: 0, Y: 5, Z: 0}); got != 0 {
+		t.Errorf("CountOverlaps() above the boxes = %d, expected 0", got)
+	}
+}
+
+func TestCollideShapeDeepest(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer boxID.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	// Sphere center on the top face, so half of it is inside the box
+	hit, ok := ps.CollideShapeDeepest(sphere, Vec3{X: 0, Y: 1, Z: 0})
+	if !ok {
+		t.Fatal("Expected sphere to collide with box")
+	}
+	defer hit.BodyID.Destroy()
+
+	if math.Abs(float64(hit.PenetrationDepth-0.5)) > 0.01 {
+		t.Errorf("PenetrationDepth = %f, expected ~0.5", hit.PenetrationDepth)
+	}
+
+	if _, ok := ps.CollideShapeDeepest(sphere, Vec3{X: 0, Y: 5, Z: 0}); ok {
+		t.Error("Expected no collision above the box")
+	}
+}
+
+func TestCastRayWithSettingsBackfaces(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+
+	// Floor of a mesh room, facing outwards (down), so it is a back face when seen from inside
+	vertices := []Vec3{
+		{X: -5, Y: 0, Z: -5},
+		{X: 5, Y: 0, Z: -5},
+		{X: 5, Y: 0, Z: 5},
+		{X: -5, Y: 0, Z: 5},
+	}
+	indices := []int32{0, 1, 2, 0, 2, 3}
+	floor := CreateMesh(vertices, indices)
+	defer floor.Destroy()
+	floorID := bi.CreateBody(floor, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer floorID.Destroy()
+
+	origin := Vec3{X: 0, Y: 2, Z: 0}
+	direction := Vec3{X: 0, Y: -10, Z: 0}
+
+	if _, ok := ps.CastRay(origin, direction); ok {
+		t.Error("Expected default CastRay to ignore the back face")
+	}
+
+	settings := DefaultRayCastSettings()
+	settings.BackfaceMode = BackfaceModeCollideWithAll
+	hit, ok := ps.CastRayWithSettings(origin, direction, settings)
+	if !ok {
+		t.Fatal("Expected back face hit with BackfaceModeCollideWithAll")
+	}
+	defer hit.BodyID.Destroy()
+
+	if math.Abs(float64(hit.HitPoint.Y)) > 0.01 {
+		t.Errorf("HitPoint.Y = %f, expected ~0", hit.HitPoint.Y)
+	}
+}
+
+func TestCastRayFiltered(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	// Three boxes in a row along +X, only the farthest one is accepted
+	var boxes []*BodyID
+	for i := 0; i < 3; i++ {
+		bodyID := bi.CreateBody(box, Vec3{X: float32(2 + i*2), Y: 0, Z: 0}, MotionTypeStatic, false)
+		defer bodyID.Destroy()
+		bi.SetUserData(bodyID, uint64(100+i))
+		boxes = append(boxes, bodyID)
+	}
+	if got := bi.GetUserData(boxes[1]); got != 101 {
+		t.Fatalf("GetUserData = %d, expected 101", got)
+	}
+
+	calls := 0
+	hit, ok := ps.CastRayFiltered(Vec3{}, Vec3{X: 20, Y: 0, Z: 0}, func(bodyID *BodyID, userData uint64) bool {
+		calls++
+		return userData == 102
+	})
+	if !ok {
+		t.Fatal("Expected ray to hit the accepted box")
+	}
+	defer hit.BodyID.Destroy()
+
+	if calls < 3 {
+		t.Errorf("Predicate called %d times, expected at least once per box", calls)
+	}
+	if got := bi.GetUserData(hit.BodyID); got != 102 {
+		t.Errorf("Hit body user data = %d, expected 102", got)
+	}
+	if math.Abs(float64(hit.HitPoint.X-5.5)) > 0.01 {
+		t.Errorf("HitPoint.X = %f, expected ~5.5", hit.HitPoint.X)
+	}
+
+	if _, ok := ps.CastRayFiltered(Vec3{}, Vec3{X: 20, Y: 0, Z: 0}, func(*BodyID, uint64) bool { return false }); ok {
+		t.Error("Expected no hit when every body is rejected")
+	}
+}
+
+func TestCastRayAllFiltered(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	// Three boxes in a row along +X
+	var boxes []*BodyID
+	for i := 0; i < 3; i++ {
+		bodyID := bi.CreateBody(box, Vec3{X: float32(2 + i*2), Y: 0, Z: 0}, MotionTypeStatic, false)
+		defer bodyID.Destroy()
+		bi.SetUserData(bodyID, uint64(100+i))
+		boxes = append(boxes, bodyID)
+	}
+
+	origin := Vec3{}
+	direction := Vec3{X: 20, Y: 0, Z: 0}
+	all := LayerMask(ObjectLayerNonMoving, ObjectLayerMoving, ObjectLayerQueryOnly)
+
+	hits := ps.CastRayAllFiltered(origin, direction, 10, nil, all)
+	for _, hit := range hits {
+		defer hit.BodyID.Destroy()
+	}
+	if len(hits) != 3 {
+		t.Fatalf("len(hits) = %d without filtering, expected 3", len(hits))
+	}
+
+	// Exclude the middle box and mask out the layer of the first one
+	bi.SetObjectLayer(boxes[0], ObjectLayerQueryOnly)
+	hits = ps.CastRayAllFiltered(origin, direction, 10, []*BodyID{boxes[1]}, LayerMask(ObjectLayerNonMoving, ObjectLayerMoving))
+	for _, hit := range hits {
+		defer hit.BodyID.Destroy()
+	}
+	if len(hits) != 1 {
+		t.Fatalf("len(hits) = %d, expected 1", len(hits))
+	}
+	if got := bi.GetUserData(hits[0].BodyID); got != 102 {
+		t.Errorf("Hit body user data = %d, expected 102", got)
+	}
+	if math.Abs(float64(hits[0].HitPoint.X-5.5)) > 0.01 {
+		t.Errorf("HitPoint.X = %f, expected ~5.5", hits[0].HitPoint.X)
+	}
+
+	if hits := ps.CastRayAllFiltered(origin, direction, 10, nil, 0); len(hits) != 0 {
+		t.Errorf("len(hits) = %d with an empty layer mask, expected 0", len(hits))
+	}
+}
+
+func TestCollideShapeGetHitsIsSensor(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+
+	wall := bi.CreateBody(box, Vec3{X: -1, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer wall.Destroy()
+	bi.SetUserData(wall, 1)
+	trigger := bi.CreateBody(box, Vec3{X: 1, Y: 0, Z: 0}, MotionTypeStatic, true)
+	defer trigger.Destroy()
+	bi.SetUserData(trigger, 2)
+
+	// Sphere at the origin overlaps both bodies
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	hits := ps.CollideShapeGetHits(sphere, Vec3{}, 10, 0)
+	if len(hits) != 2 {
+		t.Fatalf("Got %d hits, expected 2", len(hits))
+	}
+	for _, hit := range hits {
+		defer hit.BodyID.Destroy()
+
+		expected := bi.GetUserData(hit.BodyID) == 2
+		if hit.IsSensor != expected {
+			t.Errorf("Hit on body %d: IsSensor = %v, expected %v", bi.GetUserData(hit.BodyID), hit.IsSensor, expected)
+		}
+	}
+}
+
+func TestApplyRadialImpulse(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetGravity(Vec3{})
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.25, Y: 0.25, Z: 0.25})
+	defer box.Destroy()
+
+	// Ring of boxes around the explosion
+	const numBoxes = 8
+	var boxes []*BodyID
+	for i := 0; i < numBoxes; i++ {
+		angle := 2 * math.Pi * float64(i) / numBoxes
+		pos := Vec3{X: float32(3 * math.Cos(angle)), Y: 0, Z: float32(3 * math.Sin(angle))}
+		bodyID := bi.CreateBody(box, pos, MotionTypeDynamic, false)
+		defer bodyID.Destroy()
+		boxes = append(boxes, bodyID)
+	}
+
+	// Static bodies in range are not pushed
+	wall := bi.CreateBody(box, Vec3{X: 0, Y: 2, Z: 0}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	// Out of range
+	far := bi.CreateBody(box, Vec3{X: 20, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer far.Destroy()
+
+	if n := ps.ApplyRadialImpulse(Vec3{}, 5, 50); n != numBoxes {
+		t.Errorf("ApplyRadialImpulse pushed %d bodies, expected %d", n, numBoxes)
+	}
+	ps.Update(1.0 / 60.0)
+
+	for i, bodyID := range boxes {
+		outward := bi.GetPosition(bodyID).Normalize()
+		if v := bi.GetLinearVelocity(bodyID).Dot(outward); v <= 0 {
+			t.Errorf("Box %d outward velocity = %.2f, expected > 0", i, v)
+		}
+	}
+	if v := bi.GetLinearVelocity(far).Length(); v != 0 {
+		t.Errorf("Far box velocity = %.2f, expected 0", v)
+	}
+}
+
+func TestApplyRadialImpulseCountsOnlyPushedBodies(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+	ps.SetGravity(Vec3{})
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+
+	// The box overlaps the sphere but its center of mass is outside the radius
+	edge := bi.CreateBody(box, Vec3{X: 5.5, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer edge.Destroy()
+
+	if n := ps.ApplyRadialImpulse(Vec3{}, 5, 50); n != 0 {
+		t.Errorf("ApplyRadialImpulse pushed %d bodies, expected 0", n)
+	}
+	if v := bi.GetLinearVelocity(edge).Length(); v != 0 {
+		t.Errorf("Edge box velocity = %.2f, expected 0", v)
+	}
+
+	// A zero strength pushes nothing
+	inside := bi.CreateBody(box, Vec3{X: 2, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer inside.Destroy()
+	if n := ps.ApplyRadialImpulse(Vec3{}, 5, 0); n != 0 {
+		t.Errorf("ApplyRadialImpulse with zero strength pushed %d bodies, expected 0", n)
+	}
+
+	for _, radius := range []float32{0, -1, float32(math.NaN())} {
+		if n := ps.ApplyRadialImpulse(Vec3{}, radius, 50); n != 0 {
+			t.Errorf("ApplyRadialImpulse(radius %v) pushed %d bodies, expected 0", radius, n)
+		}
+	}
+}
+
+func TestProbeGround(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floorShape := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floorShape.Destroy()
+
+	// Floor top surface at Y = 0
+	floor := bi.CreateBody(floorShape, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floor.Destroy()
+
+	// Sphere bottom starts at Y = 1.75, so it touches the floor after moving 1.75
+	groundPos, groundNormal, distance, hit := ps.ProbeGround(Vec3{X: 0, Y: 2, Z: 0}, 0.25, 5)
+	if !hit {
+		t.Fatal("Probe should have found the floor")
+	}
+	if math.Abs(float64(distance-1.75)) > 0.01 {
+		t.Errorf("Distance = %.3f, expected ~1.75", distance)
+	}
+	if math.Abs(float64(groundPos.Y)) > 0.01 {
+		t.Errorf("Ground position Y = %.3f, expected ~0", groundPos.Y)
+	}
+	if groundNormal.Y < 0.99 {
+		t.Errorf("Ground normal = %v, expected to point up", groundNormal)
+	}
+
+	// Floor is out of reach
+	if _, _, _, hit := ps.ProbeGround(Vec3{X: 0, Y: 2, Z: 0}, 0.25, 1); hit {
+		t.Error("Probe should not reach the floor with a short max distance")
+	}
+}
+
+func TestProbeGroundInvalidArguments(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	floorShape := CreateBox(Vec3{X: 10, Y: 0.5, Z: 10})
+	defer floorShape.Destroy()
+	floor := bi.CreateBody(floorShape, Vec3{X: 0, Y: -0.5, Z: 0}, MotionTypeStatic, false)
+	defer floor.Destroy()
+
+	nan := float32(math.NaN())
+	tests := []struct {
+		name        string
+		radius      float32
+		maxDistance float32
+	}{
+		{"zero radius", 0, 5},
+		{"negative radius", -0.25, 5},
+		{"NaN radius", nan, 5},
+		{"negative max distance", 0.25, -1},
+		{"NaN max distance", 0.25, nan},
+	}
+	for _, tt := range tests {
+		if _, _, _, hit := ps.ProbeGround(Vec3{X: 0, Y: 2, Z: 0}, tt.radius, tt.maxDistance); hit {
+			t.Errorf("%s: hit = true, expected false", tt.name)
+		}
+	}
+}
+
+func TestSphereCast(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	wallShape := CreateBox(Vec3{X: 0.5, Y: 5, Z: 5})
+	defer wallShape.Destroy()
+
+	// Wall front face at X = 4.5
+	wall := bi.CreateBody(wallShape, Vec3{X: 5, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	origin := Vec3{X: 0, Y: 0, Z: 0}
+	direction := Vec3{X: 10, Y: 0, Z: 0}
+
+	hit, ok := ps.SphereCast(origin, direction, 0.5)
+	if !ok {
+		t.Fatal("Sphere cast should have hit the wall")
+	}
+	defer hit.BodyID.Destroy()
+
+	// Sphere touches the wall when its center reaches X = 4
+	if math.Abs(float64(hit.Fraction*direction.X-4)) > 0.01 {
+		t.Errorf("Stop distance = %.3f, expected ~4", hit.Fraction*direction.X)
+	}
+	if hit.Normal.X > -0.99 {
+		t.Errorf("Normal = %v, expected to point along -X", hit.Normal)
+	}
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	manual, ok := ps.CastShape(sphere, origin, direction)
+	if !ok {
+		t.Fatal("Manual shape cast should have hit the wall")
+	}
+	defer manual.BodyID.Destroy()
+
+	if math.Abs(float64(hit.Fraction-manual.Fraction)) > 1e-5 {
+		t.Errorf("SphereCast fraction = %.5f, CastShape fraction = %.5f, expected equal", hit.Fraction, manual.Fraction)
+	}
+
+	// Second cast with the same radius reuses the cached shape
+	again, ok := ps.SphereCast(origin, direction, 0.5)
+	if !ok {
+		t.Fatal("Repeated sphere cast should have hit the wall")
+	}
+	defer again.BodyID.Destroy()
+	if len(ps.sphereCache) != 1 {
+		t.Errorf("Sphere cache has %d shapes, expected 1", len(ps.sphereCache))
+	}
+
+	if _, ok := ps.SphereCast(origin, direction, 0); ok {
+		t.Error("SphereCast with radius 0 hit, expected false")
+	}
+	if _, ok := ps.SphereCast(origin, direction, -1); ok {
+		t.Error("SphereCast with radius -1 hit, expected false")
+	}
+
+	// Many distinct radii still hit, but the cache stays bounded
+	for i := 0; i < 2*maxCachedSpheres; i++ {
+		hit, ok := ps.SphereCast(origin, direction, 0.1+float32(i)*0.01)
+		if !ok {
+			t.Fatalf("SphereCast with radius %.2f missed, expected a hit", 0.1+float32(i)*0.01)
+		}
+		hit.BodyID.Destroy()
+	}
+	if len(ps.sphereCache) != maxCachedSpheres {
+		t.Errorf("Sphere cache has %d shapes, expected at most %d", len(ps.sphereCache), maxCachedSpheres)
+	}
+}
+
+func TestCastRaysBatch(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Wall in front of part of the fan
+	bi := ps.GetBodyInterface()
+	wallShape := CreateBox(Vec3{X: 5, Y: 1, Z: 0.5})
+	defer wallShape.Destroy()
+	wall := bi.CreateBody(wallShape, Vec3{X: 5, Y: 0, Z: 5}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	// 64 rays from the origin spread in a half circle in the XZ plane
+	var origins, directions []Vec3
+	for i := 0; i < 64; i++ {
+		angle := math.Pi * float64(i) / 63
+		origins = append(origins, Vec3{X: 0, Y: 0, Z: 0})
+		directions = append(directions, Vec3{X: float32(math.Cos(angle)) * 20, Y: 0, Z: float32(math.Sin(angle)) * 20})
+	}
+
+	hits := ps.CastRaysBatch(origins, directions)
+	if len(hits) != len(origins) {
+		t.Fatalf("Got %d results, expected one per ray (%d)", len(hits), len(origins))
+	}
+
+	numHits := 0
+	for i := range origins {
+		single, ok := ps.CastRay(origins[i], directions[i])
+		batched := hits[i]
+
+		if ok != batched.Hit {
+			t.Errorf("Ray %d: CastRay hit = %v, batch hit = %v", i, ok, batched.Hit)
+			continue
+		}
+		if !ok {
+			if batched.BodyID != nil {
+				t.Errorf("Ray %d: BodyID = %v for a miss, expected nil", i, batched.BodyID)
+			}
+			continue
+		}
+		numHits++
+
+		if math.Abs(float64(single.Fraction-batched.Fraction)) > 1e-5 {
+			t.Errorf("Ray %d: CastRay fraction = %.5f, batch fraction = %.5f", i, single.Fraction, batched.Fraction)
+		}
+		if single.BodyID.GetIndexAndSequenceNumber() != batched.BodyID.GetIndexAndSequenceNumber() {
+			t.Errorf("Ray %d: CastRay and batch hit different bodies", i)
+		}
+		single.BodyID.Destroy()
+		batched.BodyID.Destroy()
+	}
+
+	// The wall covers part of the fan, some rays must hit and some must miss
+	if numHits == 0 || numHits == len(origins) {
+		t.Errorf("%d of %d rays hit, expected a mix of hits and misses", numHits, len(origins))
+	}
+
+	// Mismatched lengths cast nothing instead of panicking
+	if hits := ps.CastRaysBatch(origins, directions[:10]); len(hits) != 0 {
+		t.Errorf("CastRaysBatch with 64 origins and 10 directions returned %d results, expected none", len(hits))
+	}
+}
+
+func BenchmarkCastRaysBatch(b *testing.B) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	wallShape := CreateBox(Vec3{X: 5, Y: 1, Z: 0.5})
+	defer wallShape.Destroy()
+	wall := bi.CreateBody(wallShape, Vec3{X: 5, Y: 0, Z: 5}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	var origins, directions []Vec3
+	for i := 0; i < 64; i++ {
+		angle := math.Pi * float64(i) / 63
+		origins = append(origins, Vec3{X: 0, Y: 0, Z: 0})
+		directions = append(directions, Vec3{X: float32(math.Cos(angle)) * 20, Y: 0, Z: float32(math.Sin(angle)) * 20})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, hit := range ps.CastRaysBatch(origins, directions) {
+			if hit.Hit {
+				hit.BodyID.Destroy()
+			}
+		}
+	}
+}
+
+func BenchmarkCastRayLoop(b *testing.B) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	wallShape := CreateBox(Vec3{X: 5, Y: 1, Z: 0.5})
+	defer wallShape.Destroy()
+	wall := bi.CreateBody(wallShape, Vec3{X: 5, Y: 0, Z: 5}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	var origins, directions []Vec3
+	for i := 0; i < 64; i++ {
+		angle := math.Pi * float64(i) / 63
+		origins = append(origins, Vec3{X: 0, Y: 0, Z: 0})
+		directions = append(directions, Vec3{X: float32(math.Cos(angle)) * 20, Y: 0, Z: float32(math.Sin(angle)) * 20})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range origins {
+			if hit, ok := ps.CastRay(origins[j], directions[j]); ok {
+				hit.BodyID.Destroy()
+			}
+		}
+	}
+}
+
+func TestCollideShapeGetHitsWithSettingsActiveEdges(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Flat quad made of two triangles sharing an internal edge along the Z axis
+	mesh := CreateMesh(
+		[]Vec3{
+			{X: 0, Y: 0, Z: -2},
+			{X: -4, Y: 0, Z: 0},
+			{X: 0, Y: 0, Z: 2},
+			{X: 4, Y: 0, Z: 0},
+		},
+		[]int32{0, 1, 2, 0, 2, 3},
+	)
+	defer mesh.Destroy()
+
+	bi := ps.GetBodyInterface()
+	ground := bi.CreateBody(mesh, Vec3{}, MotionTypeStatic, false)
+	defer ground.Destroy()
+
+	// Sphere resting on the right triangle, also touching the internal edge of the left one
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	position := Vec3{X: 0.2, Y: 0.4, Z: 0}
+
+	// Counts contacts whose normal isn't the face normal of the flat quad
+	countGhostContacts := func(mode ActiveEdgeMode) int {
+		settings := DefaultCollideShapeSettings()
+		settings.ActiveEdgeMode = mode
+		hits := ps.CollideShapeGetHitsWithSettings(sphere, position, 10, settings)
+
+		ghosts := 0
+		for _, hit := range hits {
+			if hit.Normal.Y < 0.99 {
+				ghosts++
+			}
+			hit.BodyID.Destroy()
+		}
+		return ghosts
+	}
+
+	if n := countGhostContacts(ActiveEdgeModeCollideWithAll); n == 0 {
+		t.Error("Expected a slanted contact on the internal edge when colliding with all edges")
+	}
+	if n := countGhostContacts(ActiveEdgeModeCollideOnlyWithActive); n != 0 {
+		t.Errorf("Got %d slanted contacts with active edge filtering, expected 0", n)
+	}
+}
+
+func TestCastRaySolid(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.5, Y: 2, Z: 2})
+	defer box.Destroy()
+
+	// Trigger volume in front of a wall
+	trigger := bi.CreateBody(box, Vec3{X: 3, Y: 0, Z: 0}, MotionTypeStatic, true)
+	defer trigger.Destroy()
+	wall := bi.CreateBody(box, Vec3{X: 6, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	origin := Vec3{X: 0, Y: 0, Z: 0}
+	direction := Vec3{X: 10, Y: 0, Z: 0}
+
+	hit, ok := ps.CastRay(origin, direction)
+	if !ok {
+		t.Fatal("CastRay should have hit the trigger")
+	}
+	defer hit.BodyID.Destroy()
+	if math.Abs(float64(hit.HitPoint.X-2.5)) > 0.01 {
+		t.Errorf("CastRay hit X = %.2f, expected the trigger at ~2.5", hit.HitPoint.X)
+	}
+
+	solid, ok := ps.CastRaySolid(origin, direction)
+	if !ok {
+		t.Fatal("CastRaySolid should have hit the wall")
+	}
+	defer solid.BodyID.Destroy()
+	if math.Abs(float64(solid.HitPoint.X-5.5)) > 0.01 {
+		t.Errorf("CastRaySolid hit X = %.2f, expected the wall at ~5.5", solid.HitPoint.X)
+	}
+	if solid.BodyID.GetIndexAndSequenceNumber() != wall.GetIndexAndSequenceNumber() {
+		t.Error("CastRaySolid hit a different body than the wall")
+	}
+}
+
+func TestCastRayThrough(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 2, Z: 2})
+	defer box.Destroy()
+	wall := bi.CreateBody(box, Vec3{X: 5, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer wall.Destroy()
+
+	entry, exit, ok := ps.CastRayThrough(Vec3{X: 0, Y: 0, Z: 0}, Vec3{X: 10, Y: 0, Z: 0})
+	if !ok {
+		t.Fatal("CastRayThrough should have gone through the box")
+	}
+	defer entry.BodyID.Destroy()
+	defer exit.BodyID.Destroy()
+
+	if math.Abs(float64(entry.HitPoint.X-4)) > 0.01 {
+		t.Errorf("Entry X = %.3f, expected the near face at 4", entry.HitPoint.X)
+	}
+	if math.Abs(float64(exit.HitPoint.X-6)) > 0.01 {
+		t.Errorf("Exit X = %.3f, expected the far face at 6", exit.HitPoint.X)
+	}
+	if entry.Normal.X > -0.99 {
+		t.Errorf("Entry normal = %v, expected it to face the ray origin", entry.Normal)
+	}
+	if exit.Normal.X < 0.99 {
+		t.Errorf("Exit normal = %v, expected it to point along the ray", exit.Normal)
+	}
+	if exit.BodyID.GetIndexAndSequenceNumber() != wall.GetIndexAndSequenceNumber() {
+		t.Error("Exit hit a different body than the box")
+	}
+
+	// A ray ending inside the box has no exit
+	if _, _, ok := ps.CastRayThrough(Vec3{X: 0, Y: 0, Z: 0}, Vec3{X: 5, Y: 0, Z: 0}); ok {
+		t.Error("CastRayThrough should fail when the ray ends inside the box")
+	}
+}
+
+func TestCollideShapeBackFaceHit(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Single one-sided triangle facing up
+	mesh := CreateMesh(
+		[]Vec3{
+			{X: 0, Y: 0, Z: -2},
+			{X: -2, Y: 0, Z: 1},
+			{X: 2, Y: 0, Z: 1},
+		},
+		[]int32{0, 1, 2},
+	)
+	defer mesh.Destroy()
+
+	bi := ps.GetBodyInterface()
+	platform := bi.CreateBody(mesh, Vec3{}, MotionTypeStatic, false)
+	defer platform.Destroy()
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	settings := DefaultCollideShapeSettings()
+	settings.BackfaceMode = BackfaceModeCollideWithAll
+
+	collide := func(y float32) []CollisionHit {
+		hits := ps.CollideShapeGetHitsWithSettings(sphere, Vec3{X: 0, Y: y, Z: 0}, 10, settings)
+		for _, hit := range hits {
+			hit.BodyID.Destroy()
+		}
+		return hits
+	}
+
+	above := collide(0.3)
+	if len(above) == 0 {
+		t.Fatal("Expected a hit with the sphere overlapping the triangle from above")
+	}
+	for _, hit := range above {
+		if hit.IsBackFaceHit {
+			t.Error("IsBackFaceHit = true for a sphere above the triangle")
+		}
+	}
+
+	below := collide(-0.3)
+	if len(below) == 0 {
+		t.Fatal("Expected a hit with the sphere overlapping the triangle from below")
+	}
+	for _, hit := range below {
+		if !hit.IsBackFaceHit {
+			t.Error("IsBackFaceHit = false for a sphere below the triangle")
+		}
+	}
+
+	// Convex bodies never report back face hits
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+	crate := bi.CreateBody(box, Vec3{X: 10, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer crate.Destroy()
+	for _, hit := range ps.CollideShapeGetHitsWithSettings(sphere, Vec3{X: 10, Y: 1.2, Z: 0}, 10, settings) {
+		if hit.IsBackFaceHit {
+			t.Error("IsBackFaceHit = true for a box")
+		}
+		hit.BodyID.Destroy()
+	}
+}
+
+func TestCastRayDistance(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+	bodyID := bi.CreateBody(box, Vec3{X: 10, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+
+	// The near face of the box is 9 units from the origin
+	hit, ok := ps.CastRay(Vec3{X: 0, Y: 0, Z: 0}, Vec3{X: 20, Y: 0, Z: 0})
+	if !ok {
+		t.Fatal("CastRay should hit the box")
+	}
+	defer hit.BodyID.Destroy()
+	if math.Abs(float64(hit.Distance-9)) > 1e-3 {
+		t.Errorf("Distance = %.3f, expected 9", hit.Distance)
+	}
+	if math.Abs(float64(hit.Fraction-0.45)) > 1e-4 {
+		t.Errorf("Fraction = %.3f, expected 0.45", hit.Fraction)
+	}
+
+	hits := ps.CastRaysBatch([]Vec3{{X: 0, Y: 0, Z: 0}}, []Vec3{{X: 40, Y: 0, Z: 0}})
+	if !hits[0].Hit {
+		t.Fatal("CastRaysBatch should hit the box")
+	}
+	defer hits[0].BodyID.Destroy()
+	if math.Abs(float64(hits[0].Distance-9)) > 1e-3 {
+		t.Errorf("Batch Distance = %.3f, expected 9", hits[0].Distance)
+	}
+}
+
+func TestCollideShapeWithMaxDistance(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 1, Y: 1, Z: 1})
+	defer box.Destroy()
+	boxID := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer boxID.Destroy()
+
+	// Sphere surface 0.3 away from the box's +X face
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+	position := Vec3{X: 1.8, Y: 0, Z: 0}
+
+	if hits := ps.CollideShapeGetHits(sphere, position, 10, 0); len(hits) != 0 {
+		t.Errorf("CollideShapeGetHits returned %d hits, expected none for separated shapes", len(hits))
+	}
+
+	hits := ps.CollideShapeWithMaxDistance(sphere, position, 0.5)
+	if len(hits) != 1 {
+		t.Fatalf("CollideShapeWithMaxDistance returned %d hits, expected 1", len(hits))
+	}
+	defer hits[0].BodyID.Destroy()
+
+	if hits[0].BodyID.GetIndexAndSequenceNumber() != boxID.GetIndexAndSequenceNumber() {
+		t.Error("Hit body is not the box")
+	}
+	if depth := hits[0].PenetrationDepth; math.Abs(float64(depth+0.3)) > 0.01 {
+		t.Errorf("PenetrationDepth = %.3f, expected -0.3", depth)
+	}
+
+	if hits := ps.CollideShapeWithMaxDistance(sphere, position, 0.2); len(hits) != 0 {
+		t.Errorf("CollideShapeWithMaxDistance(0.2) returned %d hits, expected none", len(hits))
+		for _, hit := range hits {
+			hit.BodyID.Destroy()
+		}
+	}
+}
+
+func TestCollideShapesTransformed(t *testing.T) {
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+
+	left := CreateTransformedShape(box, Vec3{X: -3, Y: 0, Z: 0}, QuatIdentity(), 1)
+	defer left.Destroy()
+	right := CreateTransformedShape(box, Vec3{X: 3, Y: 0, Z: 0}, QuatIdentity(), 2)
+	defer right.Destroy()
+	targets := []*TransformedShape{left, right}
+
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	hits := CollideShapesTransformed(sphere, Vec3{X: 2.2, Y: 0, Z: 0}, targets)
+	for _, hit := range hits {
+		defer hit.BodyID.Destroy()
+	}
+	if len(hits) != 1 {
+		t.Fatalf("len(hits) = %d, expected 1", len(hits))
+	}
+	if id := hits[0].BodyID.GetIndexAndSequenceNumber(); id != 2 {
+		t.Errorf("Hit body ID = %d, expected the right box (2)", id)
+	}
+	if hits[0].Normal.X > -0.99 {
+		t.Errorf("Normal = %v, expected {-1, 0, 0} pushing the sphere out of the right box", hits[0].Normal)
+	}
+
+	if hits := CollideShapesTransformed(sphere, Vec3{X: 0, Y: 0, Z: 0}, targets); len(hits) != 0 {
+		t.Errorf("len(hits) = %d between the boxes, expected 0", len(hits))
+	}
+
+	// A ray along +X from the far left passes through both boxes, closest first
+	rayHits := CastRayTransformed(Vec3{X: -10, Y: 0, Z: 0}, Vec3{X: 20, Y: 0, Z: 0}, targets)
+	for _, hit := range rayHits {
+		defer hit.BodyID.Destroy()
+	}
+	if len(rayHits) != 2 {
+		t.Fatalf("len(rayHits) = %d, expected 2", len(rayHits))
+	}
+	if id := rayHits[0].BodyID.GetIndexAndSequenceNumber(); id != 1 {
+		t.Errorf("First ray hit body ID = %d, expected the left box (1)", id)
+	}
+	if math.Abs(float64(rayHits[0].HitPoint.X+3.5)) > 0.01 {
+		t.Errorf("First HitPoint.X = %.3f, expected -3.5", rayHits[0].HitPoint.X)
+	}
+	if rayHits[0].Normal.X > -0.99 {
+		t.Errorf("First ray hit normal = %v, expected {-1, 0, 0}", rayHits[0].Normal)
+	}
+	if id := rayHits[1].BodyID.GetIndexAndSequenceNumber(); id != 2 {
+		t.Errorf("Second ray hit body ID = %d, expected the right box (2)", id)
+	}
+}
@@ -2,48 +2,170 @@ package jolt
 
 // #include "wrapper/shape.h"
 import "C"
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
 
 // Shape represents collision geometry that can be used to create bodies
 type Shape struct {
 	handle C.JoltShape
+	freed  atomic.Bool // Set once the reference is released, so Destroy and the finalizer only release it once
 }
 
-// Destroy frees the shape (decrements ref count)
+// Destroy frees the shape (decrements ref count). Calling Destroy on a nil shape is a no-op.
 func (s *Shape) Destroy() {
+	if s == nil {
+		return
+	}
+	s.free()
+}
+
+// free releases the reference, returns false if it was already released
+func (s *Shape) free() bool {
+	if !s.freed.CompareAndSwap(false, true) {
+		return false
+	}
 	C.JoltDestroyShape(s.handle)
+	return true
+}
+
+// Handle returns the raw C handle (a pointer to a JPH::Shape) for passing to your own cgo code.
+//
+// This is an unsafe escape hatch: the shape is ref-counted and the reference is owned by this Shape,
+// so the pointer becomes invalid once the shape is destroyed and no body uses it.
+func (s *Shape) Handle() unsafe.Pointer {
+	return unsafe.Pointer(s.handle)
+}
+
+// ShapeFromHandle wraps a raw C handle (a pointer to a JPH::Shape) obtained from Handle or your own cgo code.
+//
+// This is an unsafe escape hatch: the handle is not validated and no reference is added,
+// so Destroy on the result releases the reference owned by the original Shape.
+func ShapeFromHandle(handle unsafe.Pointer) *Shape {
+	return &Shape{handle: C.JoltShape(handle)}
 }
 
 // CreateSphereShape creates a sphere collision shape
+// Returns nil if radius is not positive
 func CreateSphere(radius float32) *Shape {
+	if !(radius > 0) {
+		return nil
+	}
 	handle := C.JoltCreateSphere(C.float(radius))
-	return &Shape{handle: handle}
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
 }
 
 // CreateBoxShape creates a box collision shape
 // halfExtent: half-size of the box in each dimension (e.g., Vec3{5,0.5,5} creates 10x1x10 box)
+// Returns nil if a half extent is not positive or Jolt rejects the box (e.g. smaller than its convex radius)
 func CreateBox(halfExtent Vec3) *Shape {
+	if !(halfExtent.X > 0 && halfExtent.Y > 0 && halfExtent.Z > 0) {
+		return nil
+	}
 	handle := C.JoltCreateBox(
 		C.float(halfExtent.X),
 		C.float(halfExtent.Y),
 		C.float(halfExtent.Z),
 	)
-	return &Shape{handle: handle}
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
 }
 
 // CreateCapsuleShape creates a capsule collision shape (cylinder with hemispherical caps)
 // halfHeight: half-height of the cylindrical part (not including the caps)
 // radius: radius of the capsule
+// Returns nil if radius is not positive or halfHeight is negative
 func CreateCapsule(halfHeight, radius float32) *Shape {
+	if !(radius > 0 && halfHeight >= 0) {
+		return nil
+	}
 	handle := C.JoltCreateCapsule(
 		C.float(halfHeight),
 		C.float(radius),
 	)
-	return &Shape{handle: handle}
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
+}
+
+// CreateCapsuleAtFeet creates a capsule collision shape with its origin at the bottom of the lower cap
+// instead of the center, which is the usual pivot for characters
+// halfHeight: half-height of the cylindrical part (not including the caps)
+// radius: radius of the capsule
+// The shape spans Y = 0 to Y = 2*(halfHeight+radius) in local space
+// Returns nil if radius is not positive or halfHeight is negative
+func CreateCapsuleAtFeet(halfHeight, radius float32) *Shape {
+	if !(radius > 0 && halfHeight >= 0) {
+		return nil
+	}
+	handle := C.JoltCreateCapsuleAtFeet(
+		C.float(halfHeight),
+		C.float(radius),
+	)
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
+}
+
+// CreateScaledShape creates a shape that scales another shape, e.g. to reuse one crate shape at several sizes.
+// The scaled shape keeps its own reference to shape, so shape may be destroyed afterwards.
+// Dynamic bodies get mass from the scaled volume: a box scaled by 2 weighs 8 times as much.
+// Returns an error if Jolt rejects the scale (e.g. a zero component).
+//
+// Example:
+//
+//	bigCrate, err := jolt.CreateScaledShape(crate, jolt.Vec3{X: 2, Y: 2, Z: 2})
+func CreateScaledShape(shape *Shape, scale Vec3) (*Shape, error) {
+	handle := C.JoltCreateScaledShape(
+		shape.handle,
+		C.float(scale.X),
+		C.float(scale.Y),
+		C.float(scale.Z),
+	)
+	runtime.KeepAlive(shape)
+	if handle == nil {
+		return nil, fmt.Errorf("invalid scale %v for shape", scale)
+	}
+	return newShape(handle), nil
 }
 
 // CreateConvexHullShape creates a convex hull collision shape from a set of points
 // points: slice of Vec3 vertices that define the convex hull
+// Returns nil if the points can't form a hull, use CreateConvexHullChecked to get the reason
 func CreateConvexHull(points []Vec3) *Shape {
+	shape, err := CreateConvexHullChecked(points)
+	if err != nil {
+		return nil
+	}
+	return shape
+}
+
+// CreateConvexHullChecked creates a convex hull collision shape like CreateConvexHull, but returns an error
+// if the points can't form a hull (fewer than 4 points, or all coincident, collinear or coplanar)
+// or if Jolt fails to build it
+//
+// Example:
+//
+//	rock, err := jolt.CreateConvexHullChecked(scannedPoints)
+//	if err != nil {
+//	    return fmt.Errorf("rock collider: %w", err)
+//	}
+func CreateConvexHullChecked(points []Vec3) (*Shape, error) {
+	if err := validateHullPoints(points); err != nil {
+		return nil, err
+	}
+
 	// Flatten Vec3 slice to float array
 	floatPoints := make([]C.float, len(points)*3)
 	for i, p := range points {
@@ -56,14 +178,112 @@ func CreateConvexHull(points []Vec3) *Shape {
 		&floatPoints[0],
 		C.int(len(points)),
 	)
-	return &Shape{handle: handle}
+	if handle == nil {
+		return nil, fmt.Errorf("failed to create convex hull")
+	}
+	return newShape(handle), nil
+}
+
+// CreateConvexHullWithMaxVertices creates a convex hull collision shape that keeps at most maxVertices vertices.
+// Use it for dense inputs such as scanned point clouds: the hull is simplified by keeping the points
+// that contribute most to its volume, trading accuracy for faster collision detection.
+// points: slice of Vec3 vertices that define the convex hull
+// maxVertices: maximum number of vertices of the resulting hull, at least 4
+// Returns nil if maxVertices is less than 4 or the points can't form a hull, use CreateConvexHullChecked
+// to get the reason
+func CreateConvexHullWithMaxVertices(points []Vec3, maxVertices int) *Shape {
+	if maxVertices < 4 || validateHullPoints(points) != nil {
+		return nil
+	}
+
+	// Flatten Vec3 slice to float array
+	floatPoints := make([]C.float, len(points)*3)
+	for i, p := range points {
+		floatPoints[i*3] = C.float(p.X)
+		floatPoints[i*3+1] = C.float(p.Y)
+		floatPoints[i*3+2] = C.float(p.Z)
+	}
+
+	handle := C.JoltCreateConvexHullWithMaxVertices(
+		&floatPoints[0],
+		C.int(len(points)),
+		C.int(maxVertices),
+	)
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
+}
+
+// hullTolerance matches Jolt's default hull tolerance: points closer than this
+// to a line or plane are considered to be on it
+const hullTolerance = 1e-3
+
+// validateHullPoints checks that points span a volume, so Jolt can build a valid hull from them
+func validateHullPoints(points []Vec3) error {
+	if len(points) < 4 {
+		return fmt.Errorf("convex hull needs at least 4 points, got %d", len(points))
+	}
+
+	// Farthest point from the first point
+	a := points[0]
+	b, bestDist := a, float32(0)
+	for _, p := range points {
+		if d := p.Sub(a).Length(); d > bestDist {
+			b, bestDist = p, d
+		}
+	}
+	if bestDist <= hullTolerance {
+		return fmt.Errorf("convex hull points are degenerate: all points coincide")
+	}
+
+	// Farthest point from the line through a and b
+	ab := b.Sub(a).Normalize()
+	c, bestDist := a, float32(0)
+	for _, p := range points {
+		if d := p.Sub(a).ProjectOnPlane(ab).Length(); d > bestDist {
+			c, bestDist = p, d
+		}
+	}
+	if bestDist <= hullTolerance {
+		return fmt.Errorf("convex hull points are degenerate: all points are collinear")
+	}
+
+	// Farthest point from the plane through a, b and c
+	ac := c.Sub(a)
+	normal := Vec3{
+		X: ab.Y*ac.Z - ab.Z*ac.Y,
+		Y: ab.Z*ac.X - ab.X*ac.Z,
+		Z: ab.X*ac.Y - ab.Y*ac.X,
+	}.Normalize()
+	bestDist = 0
+	for _, p := range points {
+		if d := float32(math.Abs(float64(p.Sub(a).Dot(normal)))); d > bestDist {
+			bestDist = d
+		}
+	}
+	if bestDist <= hullTolerance {
+		return fmt.Errorf("convex hull points are degenerate: all points are coplanar")
+	}
+
+	return nil
 }
 
 // CreateMeshShape creates a mesh collision shape from vertices and triangle indices
 // vertices: slice of Vec3 vertices
 // indices: slice of triangle indices (must be multiple of 3, each triangle is 3 indices)
 // Note: Mesh shapes are typically used for static geometry (e.g., terrain, buildings)
+// Returns nil if there are no triangles, an index is out of range or Jolt fails to build the mesh
 func CreateMesh(vertices []Vec3, indices []int32) *Shape {
+	if len(vertices) == 0 || len(indices) == 0 || len(indices)%3 != 0 {
+		return nil
+	}
+	for _, idx := range indices {
+		if idx < 0 || int(idx) >= len(vertices) {
+			return nil
+		}
+	}
+
 	// Flatten Vec3 slice to float array
 	floatVertices := make([]C.float, len(vertices)*3)
 	for i, v := range vertices {
@@ -84,7 +304,139 @@ func CreateMesh(vertices []Vec3, indices []int32) *Shape {
 		&cIndices[0],
 		C.int(len(indices)),
 	)
-	return &Shape{handle: handle}
+	if handle == nil {
+		return nil
+	}
+	return newShape(handle)
+}
+
+// MaxMeshMaterials is the number of material indices a mesh created with CreateMeshWithMaterials supports
+const MaxMeshMaterials = C.JOLT_MAX_MESH_MATERIALS
+
+// CreateMeshWithMaterials creates a mesh collision shape where each triangle has a material index,
+// e.g. to tell grass from rock within one terrain mesh. The index of the triangle a character stands on
+// is reported in CharacterContact.SurfaceMaterialIndex.
+// vertices: slice of Vec3 vertices
+// indices: slice of triangle indices (must be multiple of 3, each less than len(vertices))
+// materialIndices: material index of each triangle (len(indices)/3 entries, 0 to MaxMeshMaterials-1)
+//
+// Example:
+//
+//	terrain, err := jolt.CreateMeshWithMaterials(vertices, indices, materials)
+//	...
+//	for _, c := range character.GetActiveContacts(256) {
+//	    if c.SurfaceMaterialIndex == materialGrass {
+//	        playGrassFootstep()
+//	    }
+//	}
+func CreateMeshWithMaterials(vertices []Vec3, indices []int32, materialIndices []int32) (*Shape, error) {
+	if len(vertices) == 0 {
+		return nil, fmt.Errorf("mesh has no vertices")
+	}
+	if len(indices) == 0 || len(indices)%3 != 0 {
+		return nil, fmt.Errorf("mesh needs a multiple of 3 indices, got %d", len(indices))
+	}
+	if len(materialIndices) != len(indices)/3 {
+		return nil, fmt.Errorf("mesh has %d triangles but %d material indices", len(indices)/3, len(materialIndices))
+	}
+	for i, idx := range indices {
+		if idx < 0 || int(idx) >= len(vertices) {
+			return nil, fmt.Errorf("mesh index %d is %d, expected 0 to %d", i, idx, len(vertices)-1)
+		}
+	}
+
+	// Flatten Vec3 slice to float array
+	floatVertices := make([]C.float, len(vertices)*3)
+	for i, v := range vertices {
+		floatVertices[i*3] = C.float(v.X)
+		floatVertices[i*3+1] = C.float(v.Y)
+		floatVertices[i*3+2] = C.float(v.Z)
+	}
+
+	cIndices := make([]C.int, len(indices))
+	for i, idx := range indices {
+		cIndices[i] = C.int(idx)
+	}
+
+	cMaterials := make([]C.int, len(materialIndices))
+	for i, material := range materialIndices {
+		if material < 0 || material >= MaxMeshMaterials {
+			return nil, fmt.Errorf("triangle %d has material index %d, expected 0 to %d", i, material, MaxMeshMaterials-1)
+		}
+		cMaterials[i] = C.int(material)
+	}
+
+	handle := C.JoltCreateMeshWithMaterials(
+		&floatVertices[0],
+		C.int(len(vertices)),
+		&cIndices[0],
+		C.int(len(indices)),
+		&cMaterials[0],
+	)
+	if handle == nil {
+		return nil, fmt.Errorf("failed to create mesh")
+	}
+	return newShape(handle), nil
+}
+
+// GetConvexHullPoints returns the vertices of a convex hull shape in local space, i.e. the points
+// Jolt kept after removing interior and redundant input points.
+// Returns an empty slice if the shape is not a convex hull.
+func (s *Shape) GetConvexHullPoints() []Vec3 {
+	numPoints := int(C.JoltConvexHullShapeGetNumPoints(s.handle))
+	runtime.KeepAlive(s)
+	if numPoints == 0 {
+		return []Vec3{}
+	}
+
+	cPoints := make([]C.float, numPoints*3)
+	count := int(C.JoltConvexHullShapeGetPoints(s.handle, &cPoints[0], C.int(numPoints)))
+	runtime.KeepAlive(s)
+
+	points := make([]Vec3, count)
+	for i := range points {
+		points[i] = Vec3{
+			X: float32(cPoints[i*3]),
+			Y: float32(cPoints[i*3+1]),
+			Z: float32(cPoints[i*3+2]),
+		}
+	}
+	return points
+}
+
+// ShapeType identifies the kind of a shape
+type ShapeType int
+
+const (
+	ShapeTypeSphere     ShapeType = C.JoltShapeTypeSphere
+	ShapeTypeBox        ShapeType = C.JoltShapeTypeBox
+	ShapeTypeCapsule    ShapeType = C.JoltShapeTypeCapsule
+	ShapeTypeConvexHull ShapeType = C.JoltShapeTypeConvexHull
+	ShapeTypeMesh       ShapeType = C.JoltShapeTypeMesh      // Triangle mesh, can only be used for static and kinematic bodies
+	ShapeTypeCompound   ShapeType = C.JoltShapeTypeCompound  // Combination of several shapes
+	ShapeTypeDecorated  ShapeType = C.JoltShapeTypeDecorated // Wraps another shape, e.g. CreateCapsuleAtFeet
+	ShapeTypeOther      ShapeType = C.JoltShapeTypeOther     // Any other shape type
+)
+
+// GetType returns the kind of the shape
+//
+// Example:
+//
+//	if motionType == jolt.MotionTypeDynamic && shape.GetType() == jolt.ShapeTypeMesh {
+//	    return errors.New("mesh shapes can't be dynamic")
+//	}
+func (s *Shape) GetType() ShapeType {
+	shapeType := C.JoltShapeGetType(s.handle)
+	runtime.KeepAlive(s)
+	return ShapeType(shapeType)
+}
+
+// IsConvex returns true if the shape is convex. Decorated shapes are convex if the shape they wrap is.
+// Dynamic bodies need a convex or compound shape, meshes can only be used for static and kinematic bodies.
+func (s *Shape) IsConvex() bool {
+	convex := C.JoltShapeIsConvex(s.handle)
+	runtime.KeepAlive(s)
+	return convex != 0
 }
 
 // RRayCast represents a ray for raycasting against shapes
@@ -118,6 +470,10 @@ func DefaultRayCastSettings() RayCastSettings {
 // RayCastResult contains the result of a shape raycast
 type RayCastResult struct {
 	Fraction float32 // Fraction along the ray where hit occurred [0, 1]
+
+	// Normal is the unit length surface normal at the hit point, pointing out of the shape.
+	// Shape.CastRay returns it in the shape's local space, TransformedShape.CastRay in world space.
+	Normal Vec3
 }
 
 // CastRay casts a ray against this shape and returns the hit result
@@ -137,7 +493,7 @@ type RayCastResult struct {
 //	    fmt.Printf("Hit at distance: %f\n", hitDistance)
 //	}
 func (s *Shape) CastRay(ray RRayCast, settings RayCastSettings, result *RayCastResult) bool {
-	var cFraction C.float
+	var cFraction, normalX, normalY, normalZ C.float
 
 	hit := C.JoltShapeCastRay(
 		s.handle,
@@ -150,10 +506,13 @@ func (s *Shape) CastRay(ray RRayCast, settings RayCastSettings, result *RayCastR
 		C.int(settings.BackfaceMode),
 		C.int(boolToInt(settings.TreatConvexAsSolid)),
 		&cFraction,
+		&normalX, &normalY, &normalZ,
 	)
+	runtime.KeepAlive(s)
 
 	if hit != 0 {
 		result.Fraction = float32(cFraction)
+		result.Normal = Vec3{X: float32(normalX), Y: float32(normalY), Z: float32(normalZ)}
 		return true
 	}
 	return false
@@ -207,6 +566,7 @@ func CreateTransformedShape(shape *Shape, position Vec3, rotation Quat, bodyID u
 		C.float(rotation.W),
 		C.uint(bodyID),
 	)
+	runtime.KeepAlive(shape)
 	return &TransformedShape{handle: handle}
 }
 
@@ -230,7 +590,7 @@ func (ts *TransformedShape) Destroy() {
 //	    fmt.Printf("Hit at distance: %f\n", hitDistance)
 //	}
 func (ts *TransformedShape) CastRay(ray RRayCast, result *RayCastResult) bool {
-	var cFraction C.float
+	var cFraction, normalX, normalY, normalZ C.float
 
 	hit := C.JoltTransformedShapeCastRay(
 		ts.handle,
@@ -241,11 +601,186 @@ func (ts *TransformedShape) CastRay(ray RRayCast, result *RayCastResult) bool {
 		C.float(ray.Direction.Y),
 		C.float(ray.Direction.Z),
 		&cFraction,
+		&normalX, &normalY, &normalZ,
 	)
 
 	if hit != 0 {
 		result.Fraction = float32(cFraction)
+		result.Normal = Vec3{X: float32(normalX), Y: float32(normalY), Z: float32(normalZ)}
 		return true
 	}
 	return false
 }
+
+// GetDebugTriangles returns a triangulated representation of the shape in local space,
+// intended for rendering colliders (e.g. to debug mismatches with render meshes)
+// Vertices are deduplicated; every 3 consecutive indices form one triangle
+//
+// Example:
+//
+//	box := jolt.CreateBox(jolt.Vec3{X: 1, Y: 1, Z: 1})
+//	defer box.Destroy()
+//
+//	vertices, indices := box.GetDebugTriangles()
+//	fmt.Printf("%d vertices, %d triangles\n", len(vertices), len(indices)/3)
+func (s *Shape) GetDebugTriangles() ([]Vec3, []uint32) {
+	numTriangles := int(C.JoltShapeGetTriangleCount(s.handle))
+	runtime.KeepAlive(s)
+	if numTriangles == 0 {
+		return []Vec3{}, []uint32{}
+	}
+
+	// Allocate C array for results (3 vertices per triangle)
+	floatVertices := make([]C.float, numTriangles*9)
+	numTriangles = int(C.JoltShapeGetTriangles(
+		s.handle,
+		&floatVertices[0],
+		C.int(numTriangles),
+	))
+	runtime.KeepAlive(s)
+
+	// Convert to indexed form, merging identical vertices
+	vertices := make([]Vec3, 0, numTriangles*3)
+	indices := make([]uint32, numTriangles*3)
+	lookup := make(map[Vec3]uint32, numTriangles*3)
+	for i := range indices {
+		v := Vec3{
+			X: float32(floatVertices[i*3]),
+			Y: float32(floatVertices[i*3+1]),
+			Z: float32(floatVertices[i*3+2]),
+		}
+		index, ok := lookup[v]
+		if !ok {
+			index = uint32(len(vertices))
+			lookup[v] = index
+			vertices = append(vertices, v)
+		}
+		indices[i] = index
+	}
+
+	return vertices, indices
+}
+
+// GetLocalBounds returns the bounding box of the shape in local space
+func (s *Shape) GetLocalBounds() (min, max Vec3) {
+	var minX, minY, minZ, maxX, maxY, maxZ C.float
+	C.JoltShapeGetLocalBounds(s.handle, &minX, &minY, &minZ, &maxX, &maxY, &maxZ)
+	runtime.KeepAlive(s)
+
+	min = Vec3{X: float32(minX), Y: float32(minY), Z: float32(minZ)}
+	max = Vec3{X: float32(maxX), Y: float32(maxY), Z: float32(maxZ)}
+	return min, max
+}
+
+// GetMassProperties returns the mass, center of mass and inertia tensor a dynamic body
+// created with this shape gets, derived from the shape's volume and density (1000 kg/m³ by default)
+func (s *Shape) GetMassProperties() MassProperties {
+	var mass C.float
+	var com [3]C.float
+	var inertia [9]C.float
+	C.JoltShapeGetMassProperties(s.handle, &mass, &com[0], &inertia[0])
+	runtime.KeepAlive(s)
+
+	props := MassProperties{
+		Mass:         float32(mass),
+		CenterOfMass: Vec3{X: float32(com[0]), Y: float32(com[1]), Z: float32(com[2])},
+	}
+	for i, v := range inertia {
+		props.Inertia[i] = float32(v)
+	}
+	return props
+}
+
+// MutableCompoundShape is a compound shape whose sub-shapes can be added, removed and moved
+// after creation, e.g. a vehicle that loses parts. For geometry that never changes a static
+// shape is faster to query.
+//
+// The center of mass stays at the origin of the compound. When the compound is used by a body,
+// call BodyInterface.SetShape with the compound after editing it so the body picks up the change,
+// and don't edit it while PhysicsSystem.Update is running.
+type MutableCompoundShape struct {
+	shape *Shape
+}
+
+// NewMutableCompoundShape creates an empty mutable compound shape
+//
+// Example:
+//
+//	vehicle := jolt.NewMutableCompoundShape()
+//	defer vehicle.Destroy()
+//	chassis := vehicle.AddShape(chassisBox, jolt.Vec3{}, jolt.QuatIdentity())
+//	turret := vehicle.AddShape(turretBox, jolt.Vec3{Y: 1}, jolt.QuatIdentity())
+//	bodyID := bi.CreateBody(vehicle.AsShape(), position, jolt.MotionTypeDynamic, false)
+func NewMutableCompoundShape() *MutableCompoundShape {
+	handle := C.JoltCreateMutableCompoundShape()
+	return &MutableCompoundShape{shape: newShape(handle)}
+}
+
+// Destroy frees the compound shape (decrements ref count)
+func (m *MutableCompoundShape) Destroy() {
+	m.shape.Destroy()
+}
+
+// AsShape returns the compound as a Shape, e.g. to create a body with it.
+// The Shape shares the compound's reference and must not be destroyed separately.
+func (m *MutableCompoundShape) AsShape() *Shape {
+	return m.shape
+}
+
+// AddShape adds a sub-shape at position and rotation relative to the compound and returns its index.
+// The compound keeps its own reference to shape, so the caller can destroy it afterwards.
+func (m *MutableCompoundShape) AddShape(shape *Shape, position Vec3, rotation Quat) int {
+	index := C.JoltMutableCompoundShapeAddShape(
+		m.shape.handle,
+		shape.handle,
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		C.float(rotation.X),
+		C.float(rotation.Y),
+		C.float(rotation.Z),
+		C.float(rotation.W),
+	)
+	runtime.KeepAlive(m.shape)
+	runtime.KeepAlive(shape)
+	return int(index)
+}
+
+// RemoveShape removes the sub-shape at index, the indices of later sub-shapes shift down by one.
+// Returns an error if index is out of range.
+func (m *MutableCompoundShape) RemoveShape(index int) error {
+	ok := C.JoltMutableCompoundShapeRemoveShape(m.shape.handle, C.int(index))
+	runtime.KeepAlive(m.shape)
+	if ok == 0 {
+		return fmt.Errorf("sub-shape index %d out of range [0, %d)", index, m.NumShapes())
+	}
+	return nil
+}
+
+// ModifyShape moves the sub-shape at index to a new position and rotation relative to the compound.
+// Returns an error if index is out of range.
+func (m *MutableCompoundShape) ModifyShape(index int, position Vec3, rotation Quat) error {
+	ok := C.JoltMutableCompoundShapeModifyShape(
+		m.shape.handle,
+		C.int(index),
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+		C.float(rotation.X),
+		C.float(rotation.Y),
+		C.float(rotation.Z),
+		C.float(rotation.W),
+	)
+	runtime.KeepAlive(m.shape)
+	if ok == 0 {
+		return fmt.Errorf("sub-shape index %d out of range [0, %d)", index, m.NumShapes())
+	}
+	return nil
+}
+
+// NumShapes returns the number of sub-shapes in the compound
+func (m *MutableCompoundShape) NumShapes() int {
+	numShapes := C.JoltMutableCompoundShapeGetNumShapes(m.shape.handle)
+	runtime.KeepAlive(m.shape)
+	return int(numShapes)
+}
@@ -2,6 +2,7 @@ package jolt
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -104,6 +105,38 @@ func TestShapeCastRayBox(t *testing.T) {
 	})
 }
 
+func TestShapeCastRayNormal(t *testing.T) {
+	box := CreateBox(Vec3{X: 1, Y: 2, Z: 3})
+	defer box.Destroy()
+
+	// Ray at the +X face of the box in local space
+	result := RayCastResult{}
+	ray := RRayCast{Origin: Vec3{X: 5, Y: 0.5, Z: 0.5}, Direction: Vec3{X: -10, Y: 0, Z: 0}}
+	if !box.CastRay(ray, DefaultRayCastSettings(), &result) {
+		t.Fatal("Ray should have hit the box")
+	}
+	if result.Normal.Sub(Vec3{X: 1, Y: 0, Z: 0}).Length() > 1e-4 {
+		t.Errorf("Normal = %v, expected {1, 0, 0} pointing back along the ray", result.Normal)
+	}
+
+	// Rotated 90 degrees around Y, the local -Z face faces the ray in world space
+	halfSqrt2 := float32(math.Sqrt(0.5))
+	transformedShape := CreateTransformedShape(box, Vec3{X: 5, Y: 0, Z: 0}, Quat{X: 0, Y: halfSqrt2, Z: 0, W: halfSqrt2}, 0)
+	defer transformedShape.Destroy()
+
+	result = RayCastResult{}
+	ray = RRayCast{Origin: Vec3{X: 0, Y: 0.5, Z: 0.5}, Direction: Vec3{X: 10, Y: 0, Z: 0}}
+	if !transformedShape.CastRay(ray, &result) {
+		t.Fatal("Ray should have hit the transformed box")
+	}
+	if math.Abs(float64(result.Fraction-0.2)) > 1e-3 {
+		t.Errorf("Fraction = %.3f, expected 0.2", result.Fraction)
+	}
+	if result.Normal.Sub(Vec3{X: -1, Y: 0, Z: 0}).Length() > 1e-4 {
+		t.Errorf("Normal = %v, expected {-1, 0, 0} pointing back along the ray", result.Normal)
+	}
+}
+
 func TestShapeCastRayCapsule(t *testing.T) {
 	// Create a capsule shape centered at origin
 	capsule := CreateCapsule(2.0, 0.5) // halfHeight=2.0, radius=0.5
@@ -276,3 +309,362 @@ func TestTransformedShapeWithBox(t *testing.T) {
 		}
 	})
 }
+
+func TestShapeGetDebugTriangles(t *testing.T) {
+	box := CreateBox(Vec3{X: 1, Y: 2, Z: 3})
+	defer box.Destroy()
+
+	vertices, indices := box.GetDebugTriangles()
+
+	if len(indices)%3 != 0 {
+		t.Fatalf("Index count %d is not a multiple of 3", len(indices))
+	}
+	if numTriangles := len(indices) / 3; numTriangles != 12 {
+		t.Errorf("Box triangle count = %d, expected 12 (two per face)", numTriangles)
+	}
+	if len(vertices) != 8 {
+		t.Errorf("Box vertex count = %d, expected 8", len(vertices))
+	}
+
+	for _, v := range vertices {
+		if math.Abs(float64(v.X)) > 1.01 || math.Abs(float64(v.Y)) > 2.01 || math.Abs(float64(v.Z)) > 3.01 {
+			t.Errorf("Vertex %v lies outside the box extents", v)
+		}
+	}
+}
+
+func TestCreateCapsuleAtFeet(t *testing.T) {
+	const halfHeight, radius = 0.9, 0.3
+	capsule := CreateCapsuleAtFeet(halfHeight, radius)
+	defer capsule.Destroy()
+
+	min, max := capsule.GetLocalBounds()
+
+	const tolerance = 0.01
+	if math.Abs(float64(min.Y)) > tolerance {
+		t.Errorf("Min Y = %f, expected ~0", min.Y)
+	}
+	if expected := float32(2 * (halfHeight + radius)); math.Abs(float64(max.Y-expected)) > tolerance {
+		t.Errorf("Max Y = %f, expected ~%f", max.Y, expected)
+	}
+	if math.Abs(float64(min.X+radius)) > tolerance || math.Abs(float64(max.X-radius)) > tolerance {
+		t.Errorf("X bounds = [%f, %f], expected ~[%f, %f]", min.X, max.X, -radius, radius)
+	}
+}
+
+func TestCreateConvexHullWithMaxVertices(t *testing.T) {
+	// Noisy point cloud on a sphere, as produced by a scanner
+	rng := rand.New(rand.NewSource(1))
+	points := make([]Vec3, 1000)
+	for i := range points {
+		dir := Vec3{
+			X: float32(rng.NormFloat64()),
+			Y: float32(rng.NormFloat64()),
+			Z: float32(rng.NormFloat64()),
+		}.Normalize()
+		points[i] = dir.Mul(1 + 0.02*float32(rng.Float64()))
+	}
+
+	const maxVertices = 32
+	hull := CreateConvexHullWithMaxVertices(points, maxVertices)
+	if hull == nil {
+		t.Fatal("CreateConvexHullWithMaxVertices failed")
+	}
+	defer hull.Destroy()
+
+	vertices, indices := hull.GetDebugTriangles()
+	if len(indices) == 0 {
+		t.Fatal("Expected hull to have triangles")
+	}
+	if len(vertices) > maxVertices {
+		t.Errorf("Hull has %d vertices, expected at most %d", len(vertices), maxVertices)
+	}
+
+	// The full hull keeps many more vertices
+	full := CreateConvexHull(points)
+	if full == nil {
+		t.Fatal("CreateConvexHull failed")
+	}
+	defer full.Destroy()
+	if fullVertices, _ := full.GetDebugTriangles(); len(fullVertices) <= maxVertices {
+		t.Errorf("Full hull has %d vertices, expected more than %d", len(fullVertices), maxVertices)
+	}
+
+	if shape := CreateConvexHullWithMaxVertices(points, 3); shape != nil {
+		shape.Destroy()
+		t.Error("CreateConvexHullWithMaxVertices(points, 3) returned a shape, expected nil")
+	}
+}
+
+func TestCreateConvexHullRejectsDegeneratePoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Vec3
+	}{
+		{"empty", nil},
+		{"three points", []Vec3{{X: 0}, {X: 1}, {Y: 1}}},
+		{"coincident", []Vec3{{X: 1}, {X: 1}, {X: 1}, {X: 1}}},
+		{"collinear", []Vec3{{X: 0}, {X: 1}, {X: 2}, {X: 3}}},
+		{"coplanar", []Vec3{{X: 0}, {X: 1}, {Z: 1}, {X: 1, Z: 1}, {X: 0.5, Z: 0.5}}},
+	}
+
+	for _, tt := range tests {
+		shape, err := CreateConvexHullChecked(tt.points)
+		if err == nil {
+			shape.Destroy()
+			t.Errorf("%s: expected an error", tt.name)
+		}
+		if shape := CreateConvexHull(tt.points); shape != nil {
+			shape.Destroy()
+			t.Errorf("%s: CreateConvexHull returned a shape, expected nil", tt.name)
+		}
+	}
+
+	tetrahedron := []Vec3{{X: 0}, {X: 1}, {Z: 1}, {Y: 1}}
+	shape, err := CreateConvexHullChecked(tetrahedron)
+	if err != nil {
+		t.Fatalf("Tetrahedron: unexpected error: %v", err)
+	}
+	shape.Destroy()
+	if shape := CreateConvexHull(tetrahedron); shape == nil {
+		t.Error("Tetrahedron: CreateConvexHull returned nil, expected a shape")
+	} else {
+		shape.Destroy()
+	}
+}
+
+func TestShapeGetType(t *testing.T) {
+	hull := CreateConvexHull([]Vec3{
+		{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1},
+	})
+	if hull == nil {
+		t.Fatal("CreateConvexHull failed")
+	}
+	mesh := CreateMesh(
+		[]Vec3{{X: -1, Y: 0, Z: -1}, {X: 1, Y: 0, Z: -1}, {X: 0, Y: 0, Z: 1}},
+		[]int32{0, 2, 1},
+	)
+
+	tests := []struct {
+		name     string
+		shape    *Shape
+		expected ShapeType
+		convex   bool
+	}{
+		{"sphere", CreateSphere(1), ShapeTypeSphere, true},
+		{"box", CreateBox(Vec3{X: 1, Y: 1, Z: 1}), ShapeTypeBox, true},
+		{"capsule", CreateCapsule(1, 0.5), ShapeTypeCapsule, true},
+		{"capsule at feet", CreateCapsuleAtFeet(1, 0.5), ShapeTypeDecorated, true},
+		{"convex hull", hull, ShapeTypeConvexHull, true},
+		{"mesh", mesh, ShapeTypeMesh, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.shape.Destroy()
+
+			if shapeType := tt.shape.GetType(); shapeType != tt.expected {
+				t.Errorf("GetType() = %d, expected %d", shapeType, tt.expected)
+			}
+			if convex := tt.shape.IsConvex(); convex != tt.convex {
+				t.Errorf("IsConvex() = %v, expected %v", convex, tt.convex)
+			}
+		})
+	}
+}
+
+func TestMutableCompoundShape(t *testing.T) {
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	sphere := CreateSphere(0.5)
+	defer sphere.Destroy()
+
+	compound := NewMutableCompoundShape()
+	defer compound.Destroy()
+
+	if got := compound.AddShape(box, Vec3{X: -3, Y: 0, Z: 0}, QuatIdentity()); got != 0 {
+		t.Errorf("AddShape(box) = %d, expected 0", got)
+	}
+	if got := compound.AddShape(sphere, Vec3{X: 3, Y: 0, Z: 0}, QuatIdentity()); got != 1 {
+		t.Errorf("AddShape(sphere) = %d, expected 1", got)
+	}
+
+	settings := DefaultRayCastSettings()
+	hits := func(x float32) bool {
+		ray := RRayCast{
+			Origin:    Vec3{X: x, Y: 10, Z: 0},
+			Direction: Vec3{X: 0, Y: -20, Z: 0},
+		}
+		var result RayCastResult
+		return compound.AsShape().CastRay(ray, settings, &result)
+	}
+
+	if !hits(-3) || !hits(3) {
+		t.Fatal("Rays should hit both sub-shapes")
+	}
+
+	// Removing the box shifts the sphere to index 0
+	if err := compound.RemoveShape(0); err != nil {
+		t.Fatalf("RemoveShape(0) error = %v, expected nil", err)
+	}
+	if got := compound.NumShapes(); got != 1 {
+		t.Fatalf("NumShapes() = %d after RemoveShape, expected 1", got)
+	}
+	if hits(-3) {
+		t.Error("Ray hit the removed box")
+	}
+	if !hits(3) {
+		t.Error("Ray missed the remaining sphere")
+	}
+
+	if err := compound.ModifyShape(0, Vec3{X: 0, Y: 0, Z: 0}, QuatIdentity()); err != nil {
+		t.Fatalf("ModifyShape(0) error = %v, expected nil", err)
+	}
+	if hits(3) {
+		t.Error("Ray hit the sphere at its old position")
+	}
+	if !hits(0) {
+		t.Error("Ray missed the sphere at its new position")
+	}
+
+	// Out of range indices are rejected without changing the compound
+	for _, index := range []int{-1, 1} {
+		if err := compound.RemoveShape(index); err == nil {
+			t.Errorf("RemoveShape(%d) error = nil, expected an out of range error", index)
+		}
+		if err := compound.ModifyShape(index, Vec3{X: 3, Y: 0, Z: 0}, QuatIdentity()); err == nil {
+			t.Errorf("ModifyShape(%d) error = nil, expected an out of range error", index)
+		}
+	}
+	if got := compound.NumShapes(); got != 1 {
+		t.Errorf("NumShapes() = %d after invalid calls, expected 1", got)
+	}
+}
+
+func TestGetConvexHullPoints(t *testing.T) {
+	// Corners of a cube away from the origin, plus points inside it
+	var corners []Vec3
+	for _, x := range []float32{1, 3} {
+		for _, y := range []float32{1, 3} {
+			for _, z := range []float32{1, 3} {
+				corners = append(corners, Vec3{X: x, Y: y, Z: z})
+			}
+		}
+	}
+	points := append([]Vec3{}, corners...)
+	points = append(points, Vec3{X: 2, Y: 2, Z: 2}, Vec3{X: 1.5, Y: 2.5, Z: 2}, Vec3{X: 2.8, Y: 1.2, Z: 1.9})
+
+	hull := CreateConvexHull(points)
+	if hull == nil {
+		t.Fatal("CreateConvexHull failed")
+	}
+	defer hull.Destroy()
+
+	got := hull.GetConvexHullPoints()
+	if len(got) != len(corners) {
+		t.Fatalf("GetConvexHullPoints returned %d points, expected %d", len(got), len(corners))
+	}
+	for _, p := range got {
+		found := false
+		for _, c := range corners {
+			if p.Sub(c).Length() < 1e-3 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Hull point %v is not a corner of the cube", p)
+		}
+	}
+
+	sphere := CreateSphere(1)
+	defer sphere.Destroy()
+	if got := sphere.GetConvexHullPoints(); len(got) != 0 {
+		t.Errorf("GetConvexHullPoints on a sphere returned %d points, expected none", len(got))
+	}
+}
+
+func TestCreateMeshWithMaterialsValidatesInput(t *testing.T) {
+	vertices := []Vec3{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 0}}
+
+	if _, err := CreateMeshWithMaterials(vertices, []int32{0, 1, 2}, []int32{0, 1}); err == nil {
+		t.Error("Expected an error for a material index count that doesn't match the triangle count")
+	}
+	if _, err := CreateMeshWithMaterials(vertices, []int32{0, 1, 2}, []int32{-1}); err == nil {
+		t.Error("Expected an error for a negative material index")
+	}
+	if _, err := CreateMeshWithMaterials(vertices, []int32{0, 1, 2}, []int32{MaxMeshMaterials}); err == nil {
+		t.Error("Expected an error for a material index of MaxMeshMaterials")
+	}
+	if _, err := CreateMeshWithMaterials(vertices, []int32{0, 1, 3}, []int32{0}); err == nil {
+		t.Error("Expected an error for a vertex index past the last vertex")
+	}
+	if _, err := CreateMeshWithMaterials(vertices, []int32{0, -1, 2}, []int32{0}); err == nil {
+		t.Error("Expected an error for a negative vertex index")
+	}
+
+	mesh, err := CreateMeshWithMaterials(vertices, []int32{0, 1, 2}, []int32{3})
+	if err != nil {
+		t.Fatalf("CreateMeshWithMaterials failed: %v", err)
+	}
+	defer mesh.Destroy()
+	if got := mesh.GetType(); got != ShapeTypeMesh {
+		t.Errorf("GetType() = %v, expected ShapeTypeMesh", got)
+	}
+}
+
+func TestCreateScaledShapeMass(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	box := CreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	defer box.Destroy()
+	scaled, err := CreateScaledShape(box, Vec3{X: 2, Y: 2, Z: 2})
+	if err != nil {
+		t.Fatalf("CreateScaledShape failed: %v", err)
+	}
+	defer scaled.Destroy()
+
+	// A 1 m³ box at the default density of 1000 kg/m³, scaled to 8 m³
+	if got := box.GetMassProperties().Mass; math.Abs(float64(got-1000)) > 1 {
+		t.Errorf("Box mass = %.1f, expected 1000", got)
+	}
+	if got := scaled.GetMassProperties().Mass; math.Abs(float64(got-8000)) > 8 {
+		t.Errorf("Scaled box mass = %.1f, expected 8000", got)
+	}
+
+	bi := ps.GetBodyInterface()
+	bodyID := bi.CreateBody(scaled, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeDynamic, false)
+	defer bodyID.Destroy()
+	if got := bi.GetMassProperties(bodyID).Mass; math.Abs(float64(got-8000)) > 8 {
+		t.Errorf("Body mass = %.1f, expected 8000", got)
+	}
+
+	if _, err := CreateScaledShape(box, Vec3{X: 1, Y: 0, Z: 1}); err == nil {
+		t.Error("Expected an error for a zero scale")
+	}
+}
+
+func TestCreateShapesRejectInvalidInput(t *testing.T) {
+	nan := float32(math.NaN())
+	for name, shape := range map[string]*Shape{
+		"CreateSphere(0)":            CreateSphere(0),
+		"CreateSphere(-1)":           CreateSphere(-1),
+		"CreateSphere(NaN)":          CreateSphere(nan),
+		"CreateBox(0 half extent)":   CreateBox(Vec3{X: 1, Y: 0, Z: 1}),
+		"CreateCapsule(1, 0)":        CreateCapsule(1, 0),
+		"CreateCapsule(-1, 0.5)":     CreateCapsule(-1, 0.5),
+		"CreateCapsuleAtFeet(1, -1)": CreateCapsuleAtFeet(1, -1),
+		"CreateMesh(no indices)":     CreateMesh([]Vec3{{X: 0, Y: 0, Z: 0}}, nil),
+		"CreateMesh(index 3)":        CreateMesh([]Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}}, []int32{0, 1, 3}),
+	} {
+		if shape != nil {
+			shape.Destroy()
+			t.Errorf("%s returned a shape, expected nil", name)
+		}
+	}
+
+	// Destroy on a nil shape is a no-op
+	var none *Shape
+	none.Destroy()
+}
@@ -0,0 +1,130 @@
+package jolt
+
+import "sync"
+
+// shapeCacheKind identifies the constructor used for a cached shape
+type shapeCacheKind int
+
+const (
+	shapeCacheSphere shapeCacheKind = iota
+	shapeCacheBox
+	shapeCacheCapsule
+)
+
+// shapeCacheKey identifies a cached shape by constructor and parameters
+type shapeCacheKey struct {
+	kind    shapeCacheKind
+	a, b, c float32
+}
+
+// shapeCacheEntry is a cached shape and the number of outstanding GetOrCreate calls for it
+type shapeCacheEntry struct {
+	key   shapeCacheKey
+	shape *Shape
+	refs  int
+}
+
+// ShapeCache shares shapes between callers that ask for identical parameters,
+// e.g. one capsule for every NPC instead of one per NPC. It is safe for concurrent use.
+//
+// Shapes returned by the cache are owned by it: call Release instead of Shape.Destroy
+// when done with one. Bodies keep their own reference, so releasing a shape that is
+// still used by a body is fine.
+//
+// Example:
+//
+//	cache := jolt.NewShapeCache()
+//	defer cache.Destroy()
+//	for _, npc := range npcs {
+//	    capsule := cache.GetOrCreateCapsule(0.9, 0.4)
+//	    npc.body = bi.CreateBody(capsule, npc.spawn, jolt.MotionTypeDynamic, false)
+//	    cache.Release(capsule)
+//	}
+type ShapeCache struct {
+	mu      sync.Mutex
+	entries map[shapeCacheKey]*shapeCacheEntry
+	byShape map[*Shape]*shapeCacheEntry
+}
+
+// NewShapeCache creates an empty shape cache
+func NewShapeCache() *ShapeCache {
+	return &ShapeCache{
+		entries: make(map[shapeCacheKey]*shapeCacheEntry),
+		byShape: make(map[*Shape]*shapeCacheEntry),
+	}
+}
+
+// getOrCreate returns the cached shape for key, calling create on a miss
+func (sc *ShapeCache) getOrCreate(key shapeCacheKey, create func() *Shape) *Shape {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.entries[key]
+	if !ok {
+		entry = &shapeCacheEntry{key: key, shape: create()}
+		sc.entries[key] = entry
+		sc.byShape[entry.shape] = entry
+	}
+	entry.refs++
+	return entry.shape
+}
+
+// GetOrCreateSphere returns a shared sphere shape with the given radius
+func (sc *ShapeCache) GetOrCreateSphere(radius float32) *Shape {
+	return sc.getOrCreate(shapeCacheKey{kind: shapeCacheSphere, a: radius}, func() *Shape {
+		return CreateSphere(radius)
+	})
+}
+
+// GetOrCreateBox returns a shared box shape with the given half extent
+func (sc *ShapeCache) GetOrCreateBox(halfExtent Vec3) *Shape {
+	return sc.getOrCreate(shapeCacheKey{kind: shapeCacheBox, a: halfExtent.X, b: halfExtent.Y, c: halfExtent.Z}, func() *Shape {
+		return CreateBox(halfExtent)
+	})
+}
+
+// GetOrCreateCapsule returns a shared capsule shape with the given half height and radius
+func (sc *ShapeCache) GetOrCreateCapsule(halfHeight, radius float32) *Shape {
+	return sc.getOrCreate(shapeCacheKey{kind: shapeCacheCapsule, a: halfHeight, b: radius}, func() *Shape {
+		return CreateCapsule(halfHeight, radius)
+	})
+}
+
+// Release gives back a shape obtained from one of the GetOrCreate methods.
+// The shape is destroyed once every GetOrCreate call for it has been released.
+// Shapes that don't belong to the cache are ignored.
+func (sc *ShapeCache) Release(shape *Shape) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.byShape[shape]
+	if !ok {
+		return
+	}
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+	delete(sc.entries, entry.key)
+	delete(sc.byShape, shape)
+	shape.Destroy()
+}
+
+// Len returns the number of distinct shapes in the cache
+func (sc *ShapeCache) Len() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return len(sc.entries)
+}
+
+// Destroy frees all cached shapes, whether or not they were released
+func (sc *ShapeCache) Destroy() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for _, entry := range sc.entries {
+		entry.shape.Destroy()
+	}
+	sc.entries = make(map[shapeCacheKey]*shapeCacheEntry)
+	sc.byShape = make(map[*Shape]*shapeCacheEntry)
+}
@@ -0,0 +1,58 @@
+package jolt
+
+import "testing"
+
+func TestShapeCacheSharesIdenticalShapes(t *testing.T) {
+	cache := NewShapeCache()
+	defer cache.Destroy()
+
+	first := cache.GetOrCreateCapsule(0.9, 0.5)
+	second := cache.GetOrCreateCapsule(0.9, 0.5)
+	if first != second || first.Handle() != second.Handle() {
+		t.Error("GetOrCreateCapsule returned different shapes for identical parameters")
+	}
+
+	other := cache.GetOrCreateCapsule(0.9, 0.4)
+	if other == first {
+		t.Error("GetOrCreateCapsule returned the same shape for a different radius")
+	}
+	sphere := cache.GetOrCreateSphere(0.9)
+	if sphere == first {
+		t.Error("GetOrCreateSphere returned the cached capsule")
+	}
+	if got := cache.Len(); got != 3 {
+		t.Errorf("Len() = %d, expected 3", got)
+	}
+
+	// The shape stays cached until every GetOrCreate call is released
+	cache.Release(first)
+	if got := cache.GetOrCreateCapsule(0.9, 0.5); got != first {
+		t.Error("Capsule was evicted while still referenced")
+	}
+	cache.Release(first)
+	cache.Release(first)
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d after releasing the capsule, expected 2", got)
+	}
+}
+
+func TestShapeCacheBodiesOutliveRelease(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	cache := NewShapeCache()
+	defer cache.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := cache.GetOrCreateBox(Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	bodyID := bi.CreateBody(box, Vec3{X: 0, Y: 0, Z: 0}, MotionTypeStatic, false)
+	defer bodyID.Destroy()
+	cache.Release(box)
+
+	// The body holds its own reference to the shape
+	hit, ok := ps.CastRay(Vec3{X: 0, Y: 5, Z: 0}, Vec3{X: 0, Y: -10, Z: 0})
+	if !ok {
+		t.Fatal("CastRay should hit the body after its cached shape was released")
+	}
+	hit.BodyID.Destroy()
+}
@@ -0,0 +1,136 @@
+package jolt
+
+// #include "wrapper/softbody.h"
+import "C"
+import (
+	"fmt"
+	"runtime"
+)
+
+// SoftBody is a deformable body simulated as a set of connected vertices (e.g. cloth)
+type SoftBody struct {
+	bodyID *BodyID
+	ps     *PhysicsSystem
+}
+
+// CreateClothSoftBody creates a cloth from a triangle mesh and adds it to the world.
+// The cloth starts active and falls under gravity, use Pin to hold vertices in place.
+//
+// Parameters:
+//   - vertices: Vertex positions relative to position
+//   - indices: Triangle vertex indices (3 per triangle, each less than len(vertices))
+//   - position: Position of the soft body in world space
+//
+// Returns an error if the mesh is empty or an index is out of range.
+//
+// Example:
+//
+//	// Banner hanging from its top edge
+//	banner, err := ps.CreateClothSoftBody(gridVertices, gridIndices, pole)
+//	if err != nil {
+//	    return err
+//	}
+//	defer banner.Destroy()
+//	banner.Pin(topRow...)
+func (ps *PhysicsSystem) CreateClothSoftBody(vertices []Vec3, indices []int32, position Vec3) (*SoftBody, error) {
+	if len(vertices) == 0 {
+		return nil, fmt.Errorf("cloth has no vertices")
+	}
+	if len(indices) < 3 || len(indices)%3 != 0 {
+		return nil, fmt.Errorf("cloth needs 3 indices per triangle, got %d", len(indices))
+	}
+	for i, idx := range indices {
+		if idx < 0 || int(idx) >= len(vertices) {
+			return nil, fmt.Errorf("cloth index %d is %d, expected 0 to %d", i, idx, len(vertices)-1)
+		}
+	}
+
+	// Flatten Vec3 slice to float array
+	floatVertices := make([]C.float, len(vertices)*3)
+	for i, v := range vertices {
+		floatVertices[i*3] = C.float(v.X)
+		floatVertices[i*3+1] = C.float(v.Y)
+		floatVertices[i*3+2] = C.float(v.Z)
+	}
+
+	// Convert int32 slice to C int array
+	cIndices := make([]C.int, len(indices))
+	for i, idx := range indices {
+		cIndices[i] = C.int(idx)
+	}
+
+	handle := C.JoltCreateClothSoftBody(
+		ps.handle,
+		&floatVertices[0],
+		C.int(len(vertices)),
+		&cIndices[0],
+		C.int(len(indices)),
+		C.float(position.X),
+		C.float(position.Y),
+		C.float(position.Z),
+	)
+	if handle == nil {
+		return nil, fmt.Errorf("failed to create cloth soft body")
+	}
+
+	// JoltDestroySoftBody frees the ID, mark it freed so Destroy on the ID handed out by BodyID is a no-op
+	bodyID := &BodyID{handle: handle}
+	bodyID.freed.Store(true)
+	return &SoftBody{bodyID: bodyID, ps: ps}, nil
+}
+
+// Destroy removes the soft body from the world and frees it.
+// Calling Destroy twice, or on a nil soft body, is a no-op.
+func (sb *SoftBody) Destroy() {
+	if sb == nil || sb.bodyID == nil {
+		return
+	}
+	C.JoltDestroySoftBody(sb.ps.handle, sb.bodyID.handle)
+	runtime.KeepAlive(sb.bodyID)
+	sb.bodyID = nil
+}
+
+// BodyID returns the ID of the soft body, e.g. to move it with the BodyInterface.
+// The ID is borrowed from the soft body: calling Destroy on it is a no-op, and it must not be
+// used after the soft body is destroyed. Returns nil after Destroy.
+func (sb *SoftBody) BodyID() *BodyID {
+	return sb.bodyID
+}
+
+// Pin holds the given vertices in place by giving them infinite mass. Does nothing after Destroy.
+func (sb *SoftBody) Pin(indices ...int) {
+	if sb.bodyID == nil {
+		return
+	}
+	for _, index := range indices {
+		C.JoltSoftBodyPinVertex(sb.ps.handle, sb.bodyID.handle, C.int(index))
+	}
+	runtime.KeepAlive(sb.bodyID)
+}
+
+// GetVertexPositions returns the world space positions of all vertices, in the order they were created.
+// Returns an empty slice after Destroy.
+func (sb *SoftBody) GetVertexPositions() []Vec3 {
+	if sb.bodyID == nil {
+		return []Vec3{}
+	}
+	numVertices := int(C.JoltSoftBodyGetNumVertices(sb.ps.handle, sb.bodyID.handle))
+	runtime.KeepAlive(sb.bodyID)
+	if numVertices == 0 {
+		return []Vec3{}
+	}
+
+	cPositions := make([]C.float, numVertices*3)
+	count := int(C.JoltSoftBodyGetVertexPositions(sb.ps.handle, sb.bodyID.handle, &cPositions[0], C.int(numVertices)))
+	runtime.KeepAlive(sb.bodyID)
+
+	positions := make([]Vec3, count)
+	for i := range positions {
+		positions[i] = Vec3{
+			X: float32(cPositions[i*3]),
+			Y: float32(cPositions[i*3+1]),
+			Z: float32(cPositions[i*3+2]),
+		}
+	}
+	return positions
+}
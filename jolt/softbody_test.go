@@ -0,0 +1,119 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClothSoftBodySags(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	// Horizontal 5x5 grid of vertices, 0.25 apart
+	const size = 5
+	var vertices []Vec3
+	for z := 0; z < size; z++ {
+		for x := 0; x < size; x++ {
+			vertices = append(vertices, Vec3{X: float32(x) * 0.25, Y: 0, Z: float32(z) * 0.25})
+		}
+	}
+	var indices []int32
+	for z := 0; z < size-1; z++ {
+		for x := 0; x < size-1; x++ {
+			i := int32(z*size + x)
+			indices = append(indices, i, i+size, i+1, i+1, i+size, i+size+1)
+		}
+	}
+
+	cloth, err := ps.CreateClothSoftBody(vertices, indices, Vec3{X: 0, Y: 5, Z: 0})
+	if err != nil {
+		t.Fatalf("CreateClothSoftBody failed: %v", err)
+	}
+	defer cloth.Destroy()
+
+	// Hang the cloth from its first row
+	pinned := make([]int, size)
+	for i := range pinned {
+		pinned[i] = i
+	}
+	cloth.Pin(pinned...)
+
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60.0)
+	}
+
+	positions := cloth.GetVertexPositions()
+	if len(positions) != len(vertices) {
+		t.Fatalf("Got %d vertex positions, expected %d", len(positions), len(vertices))
+	}
+
+	for i := 0; i < size; i++ {
+		if math.Abs(float64(positions[i].Y-5)) > 1e-3 {
+			t.Errorf("Pinned vertex %d Y = %.3f, expected 5", i, positions[i].Y)
+		}
+	}
+
+	// The far edge swings down
+	for i := len(positions) - size; i < len(positions); i++ {
+		if positions[i].Y > 4.5 {
+			t.Errorf("Unpinned vertex %d Y = %.3f, expected it to sag below 4.5", i, positions[i].Y)
+		}
+	}
+}
+
+func TestClothSoftBodyBorrowedBodyID(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	vertices := []Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	cloth, err := ps.CreateClothSoftBody(vertices, []int32{0, 1, 2}, Vec3{X: 0, Y: 5, Z: 0})
+	if err != nil {
+		t.Fatalf("CreateClothSoftBody failed: %v", err)
+	}
+
+	// Destroying the borrowed ID must not free it out from under the soft body
+	ps.GetBodyInterface().SetPosition(cloth.BodyID(), Vec3{X: 0, Y: 6, Z: 0})
+	cloth.BodyID().Destroy()
+	if got := len(cloth.GetVertexPositions()); got != len(vertices) {
+		t.Errorf("Got %d vertex positions after destroying the borrowed ID, expected %d", got, len(vertices))
+	}
+
+	cloth.Destroy()
+	cloth.Destroy()
+
+	if id := cloth.BodyID(); id != nil {
+		t.Error("BodyID() after Destroy, expected nil")
+	}
+	cloth.Pin(0)
+	if got := len(cloth.GetVertexPositions()); got != 0 {
+		t.Errorf("Got %d vertex positions after Destroy, expected 0", got)
+	}
+}
+
+func TestClothSoftBodyInvalidMesh(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	vertices := []Vec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	for _, indices := range [][]int32{
+		{0, 1, 3},    // Index past the last vertex
+		{0, -1, 2},   // Negative index
+		{0, 1, 2, 0}, // Incomplete triangle
+		nil,
+	} {
+		if cloth, err := ps.CreateClothSoftBody(vertices, indices, Vec3{}); err == nil {
+			cloth.Destroy()
+			t.Errorf("CreateClothSoftBody(%v) succeeded, expected an error", indices)
+		}
+	}
+
+	// Destroying twice or destroying nil is a no-op
+	cloth, err := ps.CreateClothSoftBody(vertices, []int32{0, 1, 2}, Vec3{})
+	if err != nil {
+		t.Fatalf("CreateClothSoftBody failed: %v", err)
+	}
+	cloth.Destroy()
+	cloth.Destroy()
+	var none *SoftBody
+	none.Destroy()
+}
@@ -0,0 +1,84 @@
+package jolt
+
+// TransformFollower rigidly attaches bodies to other bodies without constraints, e.g. an item
+// held by a character. Every Update moves each child to its parent's transform combined with
+// the child's local offset and rotation. Children must be kinematic: they are moved with
+// MoveKinematic, so they push dynamic bodies out of the way but are never pushed back.
+//
+// Call Update after moving the parents and before every PhysicsSystem.Update, with the same
+// deltaTime. The parent's velocities predict where it will be after the step, so children
+// don't lag behind moving parents.
+//
+// Example:
+//
+//	follower := jolt.NewTransformFollower()
+//	follower.Attach(sword, hand, jolt.Vec3{X: 0, Y: 0.5, Z: 0}, jolt.QuatIdentity())
+//	for {
+//	    follower.Update(bi, 1.0/60.0)
+//	    ps.Update(1.0 / 60.0)
+//	}
+type TransformFollower struct {
+	attachments []attachment
+}
+
+// attachment is a child body following a parent body
+type attachment struct {
+	child, parent *BodyID
+	localOffset   Vec3 // Child position in the parent's local space
+	localRotation Quat // Child rotation relative to the parent
+}
+
+// NewTransformFollower creates a TransformFollower without attachments
+func NewTransformFollower() *TransformFollower {
+	return &TransformFollower{}
+}
+
+// Attach makes child follow parent at localOffset and localRotation in the parent's local space.
+// Attaching a child that is already attached replaces its previous attachment.
+func (f *TransformFollower) Attach(child, parent *BodyID, localOffset Vec3, localRotation Quat) {
+	f.Detach(child)
+	f.attachments = append(f.attachments, attachment{
+		child:         child,
+		parent:        parent,
+		localOffset:   localOffset,
+		localRotation: localRotation,
+	})
+}
+
+// Detach stops child from following its parent. The child keeps its current velocity,
+// set it to zero to stop the body where it is.
+func (f *TransformFollower) Detach(child *BodyID) {
+	for i, a := range f.attachments {
+		if a.child.handle == child.handle {
+			f.attachments = append(f.attachments[:i], f.attachments[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update moves every attached child to where its parent will be after the next step of deltaTime seconds
+func (f *TransformFollower) Update(bi *BodyInterface, deltaTime float32) {
+	if deltaTime <= 0 {
+		return
+	}
+
+	for _, a := range f.attachments {
+		// Predict the parent's transform after the step from its velocities
+		position := bi.GetPosition(a.parent)
+		rotation := bi.GetRotation(a.parent)
+		centerOfMass := bi.GetCenterOfMassPosition(a.parent)
+		linearVelocity := bi.GetLinearVelocity(a.parent)
+		angularVelocity := bi.GetAngularVelocity(a.parent)
+
+		turn := QuatIdentity()
+		if speed := angularVelocity.Length(); speed > 0 {
+			turn = QuatFromAxisAngle(angularVelocity.Mul(1/speed), speed*deltaTime)
+		}
+
+		// The body origin turns around the center of mass, which moves with the linear velocity
+		position = centerOfMass.Add(linearVelocity.Mul(deltaTime)).Add(turn.RotateVec3(position.Sub(centerOfMass)))
+		rotation = turn.Mul(rotation)
+
+		bi.MoveKinematic(a.child, position.Add(rotation.RotateVec3(a.localOffset)), rotation.Mul(a.localRotation), deltaTime)
+	}
+}
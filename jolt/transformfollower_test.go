@@ -0,0 +1,56 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransformFollower(t *testing.T) {
+	ps := NewPhysicsSystem()
+	defer ps.Destroy()
+
+	bi := ps.GetBodyInterface()
+	box := CreateBox(Vec3{X: 0.2, Y: 0.2, Z: 0.2})
+	defer box.Destroy()
+
+	parent := bi.CreateBody(box, Vec3{X: 0, Y: 5, Z: 0}, MotionTypeKinematic, false)
+	defer parent.Destroy()
+	child := bi.CreateBody(box, Vec3{X: 1, Y: 5, Z: 0}, MotionTypeKinematic, false)
+	defer child.Destroy()
+
+	follower := NewTransformFollower()
+	follower.Attach(child, parent, Vec3{X: 1, Y: 0, Z: 0}, QuatIdentity())
+
+	// The parent moves 2 m along X while turning 90 degrees around Y over 1 second
+	const deltaTime = 1.0 / 60.0
+	for i := 1; i <= 60; i++ {
+		elapsed := float32(i) * deltaTime
+		target := Vec3{X: 2 * elapsed, Y: 5, Z: 0}
+		rotation := QuatFromAxisAngle(Vec3{X: 0, Y: 1, Z: 0}, elapsed*math.Pi/2)
+		bi.MoveKinematic(parent, target, rotation, deltaTime)
+
+		follower.Update(bi, deltaTime)
+		ps.Update(deltaTime)
+	}
+
+	// The offset along the parent's X axis now points along -Z
+	if pos := bi.GetPosition(child); pos.Sub(Vec3{X: 2, Y: 5, Z: -1}).Length() > 0.01 {
+		t.Errorf("Child position = %v, expected {2, 5, -1}", pos)
+	}
+	parentRot, childRot := bi.GetRotation(parent), bi.GetRotation(child)
+	if dot := parentRot.X*childRot.X + parentRot.Y*childRot.Y + parentRot.Z*childRot.Z + parentRot.W*childRot.W; math.Abs(float64(dot)) < 0.9999 {
+		t.Errorf("Child rotation = %v, expected the parent rotation %v", childRot, parentRot)
+	}
+
+	// A detached child stays where it is
+	follower.Detach(child)
+	bi.SetLinearVelocity(child, Vec3{})
+	for i := 0; i < 30; i++ {
+		bi.MoveKinematic(parent, Vec3{X: 5, Y: 5, Z: 0}, QuatIdentity(), deltaTime)
+		follower.Update(bi, deltaTime)
+		ps.Update(deltaTime)
+	}
+	if pos := bi.GetPosition(child); pos.Sub(Vec3{X: 2, Y: 5, Z: -1}).Length() > 0.05 {
+		t.Errorf("Detached child position = %v, expected it to stay at {2, 5, -1}", pos)
+	}
+}
@@ -22,11 +22,45 @@ func (v Vec3) Mul(scalar float32) Vec3 {
 	return Vec3{X: v.X * scalar, Y: v.Y * scalar, Z: v.Z * scalar}
 }
 
+// MulVec returns the component-wise product of this vector and another vector
+// (e.g. for applying a non-uniform scale)
+func (v Vec3) MulVec(other Vec3) Vec3 {
+	return Vec3{X: v.X * other.X, Y: v.Y * other.Y, Z: v.Z * other.Z}
+}
+
+// Min returns the component-wise minimum of this vector and another vector
+func (v Vec3) Min(other Vec3) Vec3 {
+	return Vec3{X: min(v.X, other.X), Y: min(v.Y, other.Y), Z: min(v.Z, other.Z)}
+}
+
+// Max returns the component-wise maximum of this vector and another vector
+func (v Vec3) Max(other Vec3) Vec3 {
+	return Vec3{X: max(v.X, other.X), Y: max(v.Y, other.Y), Z: max(v.Z, other.Z)}
+}
+
+// Abs returns a vector with the absolute value of each component
+func (v Vec3) Abs() Vec3 {
+	return Vec3{
+		X: float32(math.Abs(float64(v.X))),
+		Y: float32(math.Abs(float64(v.Y))),
+		Z: float32(math.Abs(float64(v.Z))),
+	}
+}
+
 // Dot returns the dot product of this vector with another vector
 func (v Vec3) Dot(other Vec3) float32 {
 	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
 }
 
+// Cross returns the cross product of this vector and another vector
+func (v Vec3) Cross(other Vec3) Vec3 {
+	return Vec3{
+		X: v.Y*other.Z - v.Z*other.Y,
+		Y: v.Z*other.X - v.X*other.Z,
+		Z: v.X*other.Y - v.Y*other.X,
+	}
+}
+
 // Length returns the magnitude (length) of the vector
 func (v Vec3) Length() float32 {
 	return float32(math.Sqrt(float64(v.X*v.X + v.Y*v.Y + v.Z*v.Z)))
@@ -42,6 +76,35 @@ func (v Vec3) Normalize() Vec3 {
 	return Vec3{X: v.X / length, Y: v.Y / length, Z: v.Z / length}
 }
 
+// Reflect returns this vector reflected off a surface with the given normal (e.g. for bounces).
+// normal must be unit length.
+func (v Vec3) Reflect(normal Vec3) Vec3 {
+	return v.Sub(normal.Mul(2 * v.Dot(normal)))
+}
+
+// ProjectOnPlane returns this vector with the component along normal removed,
+// i.e. projected onto the plane with that normal (e.g. for sliding along walls).
+// normal must be unit length.
+func (v Vec3) ProjectOnPlane(normal Vec3) Vec3 {
+	return v.Sub(normal.Mul(v.Dot(normal)))
+}
+
+// OrthonormalBasis returns two unit vectors that together with this (unit length) vector form a
+// right-handed orthonormal basis: tangent x bitangent = v. The result only depends on v, so it is
+// stable from frame to frame (e.g. to orient a ledge grab on the ground normal).
+func (v Vec3) OrthonormalBasis() (tangent, bitangent Vec3) {
+	// Same construction as Jolt's Vec3::GetNormalizedPerpendicular: zero out the smallest of X and Y
+	if float32(math.Abs(float64(v.X))) > float32(math.Abs(float64(v.Y))) {
+		length := float32(math.Sqrt(float64(v.X*v.X + v.Z*v.Z)))
+		tangent = Vec3{X: v.Z / length, Y: 0, Z: -v.X / length}
+	} else {
+		length := float32(math.Sqrt(float64(v.Y*v.Y + v.Z*v.Z)))
+		tangent = Vec3{X: 0, Y: v.Z / length, Z: -v.Y / length}
+	}
+	bitangent = v.Cross(tangent)
+	return tangent, bitangent
+}
+
 // Quat represents a quaternion for rotations
 type Quat struct {
 	X, Y, Z, W float32
@@ -51,3 +114,63 @@ type Quat struct {
 func QuatIdentity() Quat {
 	return Quat{X: 0, Y: 0, Z: 0, W: 1}
 }
+
+// QuatFromAxisAngle returns a rotation of angle radians around a normalized axis
+func QuatFromAxisAngle(axis Vec3, angle float32) Quat {
+	s := float32(math.Sin(float64(angle) / 2))
+	c := float32(math.Cos(float64(angle) / 2))
+	return Quat{X: axis.X * s, Y: axis.Y * s, Z: axis.Z * s, W: c}
+}
+
+// Mul returns the rotation that first applies other and then q
+func (q Quat) Mul(other Quat) Quat {
+	return Quat{
+		X: q.W*other.X + q.X*other.W + q.Y*other.Z - q.Z*other.Y,
+		Y: q.W*other.Y - q.X*other.Z + q.Y*other.W + q.Z*other.X,
+		Z: q.W*other.Z + q.X*other.Y - q.Y*other.X + q.Z*other.W,
+		W: q.W*other.W - q.X*other.X - q.Y*other.Y - q.Z*other.Z,
+	}
+}
+
+// RotateVec3 rotates a vector by this (normalized) quaternion
+func (q Quat) RotateVec3(v Vec3) Vec3 {
+	// v' = v + 2w(q x v) + 2(q x (q x v))
+	u := Vec3{X: q.X, Y: q.Y, Z: q.Z}
+	t := u.Cross(v).Mul(2)
+	return v.Add(t.Mul(q.W)).Add(u.Cross(t))
+}
+
+// Mat3 is a 3x3 matrix stored in column-major order: element (row, col) is at index col*3+row
+type Mat3 [9]float32
+
+// At returns the element at the given row and column
+func (m Mat3) At(row, col int) float32 {
+	return m[col*3+row]
+}
+
+// MulVec3 multiplies the matrix with a vector
+func (m Mat3) MulVec3(v Vec3) Vec3 {
+	return Vec3{
+		X: m[0]*v.X + m[3]*v.Y + m[6]*v.Z,
+		Y: m[1]*v.X + m[4]*v.Y + m[7]*v.Z,
+		Z: m[2]*v.X + m[5]*v.Y + m[8]*v.Z,
+	}
+}
+
+// Mat4 is a 4x4 transform matrix stored in column-major order (element (row, col) is at index col*4+row),
+// the layout OpenGL and Vulkan expect, so it can be uploaded to the GPU as is
+type Mat4 [16]float32
+
+// At returns the element at the given row and column
+func (m Mat4) At(row, col int) float32 {
+	return m[col*4+row]
+}
+
+// MulPoint transforms a point (rotation and translation)
+func (m Mat4) MulPoint(p Vec3) Vec3 {
+	return Vec3{
+		X: m[0]*p.X + m[4]*p.Y + m[8]*p.Z + m[12],
+		Y: m[1]*p.X + m[5]*p.Y + m[9]*p.Z + m[13],
+		Z: m[2]*p.X + m[6]*p.Y + m[10]*p.Z + m[14],
+	}
+}
@@ -0,0 +1,121 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVec3ComponentWise(t *testing.T) {
+	a := Vec3{X: 1, Y: -2, Z: 3}
+	b := Vec3{X: -4, Y: 5, Z: 3}
+
+	tests := []struct {
+		name     string
+		got      Vec3
+		expected Vec3
+	}{
+		{"Min", a.Min(b), Vec3{X: -4, Y: -2, Z: 3}},
+		{"Max", a.Max(b), Vec3{X: 1, Y: 5, Z: 3}},
+		{"Abs", a.Abs(), Vec3{X: 1, Y: 2, Z: 3}},
+		{"Abs zero", Vec3{}.Abs(), Vec3{}},
+		{"MulVec", a.MulVec(b), Vec3{X: -4, Y: -10, Z: 9}},
+		{"MulVec scale", Vec3{X: 1, Y: 1, Z: 1}.MulVec(Vec3{X: 2, Y: 0.5, Z: -1}), Vec3{X: 2, Y: 0.5, Z: -1}},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %v, expected %v", tt.name, tt.got, tt.expected)
+		}
+	}
+}
+
+func TestVec3ReflectAndProjectOnPlane(t *testing.T) {
+	up := Vec3{X: 0, Y: 1, Z: 0}
+
+	if got := (Vec3{X: 0, Y: -3, Z: 0}).Reflect(up); got != (Vec3{X: 0, Y: 3, Z: 0}) {
+		t.Errorf("Reflect down off up = %v, expected {0 3 0}", got)
+	}
+	if got := (Vec3{X: 1, Y: -1, Z: 0}).Reflect(up); got != (Vec3{X: 1, Y: 1, Z: 0}) {
+		t.Errorf("Reflect diagonal off up = %v, expected {1 1 0}", got)
+	}
+
+	if got := (Vec3{X: 2, Y: -3, Z: 4}).ProjectOnPlane(up); got != (Vec3{X: 2, Y: 0, Z: 4}) {
+		t.Errorf("ProjectOnPlane = %v, expected {2 0 4}", got)
+	}
+
+	// Sliding into a wall facing -X keeps only the tangential motion
+	wall := Vec3{X: -1, Y: 0, Z: 0}
+	if got := (Vec3{X: 1, Y: 0, Z: 1}).ProjectOnPlane(wall); got != (Vec3{X: 0, Y: 0, Z: 1}) {
+		t.Errorf("ProjectOnPlane into wall = %v, expected {0 0 1}", got)
+	}
+}
+
+func TestMat3(t *testing.T) {
+	// Columns are the images of the X, Y and Z axes: rotate 90 degrees around Z and scale Z by 2
+	m := Mat3{
+		0, 1, 0,
+		-1, 0, 0,
+		0, 0, 2,
+	}
+
+	if got := m.At(0, 1); got != -1 {
+		t.Errorf("At(0, 1) = %v, expected -1", got)
+	}
+	if got := m.MulVec3(Vec3{X: 1, Y: 2, Z: 3}); got != (Vec3{X: -2, Y: 1, Z: 6}) {
+		t.Errorf("MulVec3 = %v, expected {-2 1 6}", got)
+	}
+}
+
+func TestQuatRotateVec3(t *testing.T) {
+	q := QuatFromAxisAngle(Vec3{X: 0, Y: 0, Z: 1}, math.Pi/2)
+	got := q.RotateVec3(Vec3{X: 1, Y: 0, Z: 0})
+	if got.Sub(Vec3{X: 0, Y: 1, Z: 0}).Length() > 1e-6 {
+		t.Errorf("Rotating X by 90 degrees around Z = %v, expected {0 1 0}", got)
+	}
+
+	if got := (Vec3{X: 1, Y: 0, Z: 0}).Cross(Vec3{X: 0, Y: 1, Z: 0}); got != (Vec3{X: 0, Y: 0, Z: 1}) {
+		t.Errorf("X cross Y = %v, expected {0 0 1}", got)
+	}
+}
+
+func TestQuatMul(t *testing.T) {
+	aroundZ := QuatFromAxisAngle(Vec3{X: 0, Y: 0, Z: 1}, math.Pi/2)
+	aroundX := QuatFromAxisAngle(Vec3{X: 1, Y: 0, Z: 0}, math.Pi/2)
+
+	// Y around X gives Z, then Z around Z stays Z
+	got := aroundZ.Mul(aroundX).RotateVec3(Vec3{X: 0, Y: 1, Z: 0})
+	if got.Sub(Vec3{X: 0, Y: 0, Z: 1}).Length() > 1e-6 {
+		t.Errorf("Rotating Y around X then Z = %v, expected {0 0 1}", got)
+	}
+
+	// X around Z gives Y, then Y around X gives Z
+	got = aroundX.Mul(aroundZ).RotateVec3(Vec3{X: 1, Y: 0, Z: 0})
+	if got.Sub(Vec3{X: 0, Y: 0, Z: 1}).Length() > 1e-6 {
+		t.Errorf("Rotating X around Z then X = %v, expected {0 0 1}", got)
+	}
+}
+
+func TestVec3OrthonormalBasis(t *testing.T) {
+	normals := []Vec3{
+		{X: 0, Y: 1, Z: 0},
+		{X: 1, Y: 0, Z: 0},
+		{X: 0, Y: 0, Z: -1},
+		Vec3{X: 1, Y: 2, Z: -3}.Normalize(),
+	}
+
+	near := func(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-5 }
+
+	for _, n := range normals {
+		tangent, bitangent := n.OrthonormalBasis()
+
+		if !near(tangent.Length(), 1) || !near(bitangent.Length(), 1) {
+			t.Errorf("Basis of %v has lengths %.5f, %.5f, expected unit vectors", n, tangent.Length(), bitangent.Length())
+		}
+		if !near(tangent.Dot(n), 0) || !near(bitangent.Dot(n), 0) || !near(tangent.Dot(bitangent), 0) {
+			t.Errorf("Basis of %v is not orthogonal: tangent %v, bitangent %v", n, tangent, bitangent)
+		}
+		if c := tangent.Cross(bitangent); !near(c.X, n.X) || !near(c.Y, n.Y) || !near(c.Z, n.Z) {
+			t.Errorf("tangent x bitangent = %v, expected %v", c, n)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Jolt Physics C Wrapper - Body Activation Listener Implementation
+ */
+
+#include "activation.h"
+#include "physics.h"
+#include <Jolt/Jolt.h>
+#include <Jolt/Physics/PhysicsSystem.h>
+#include <Jolt/Physics/Body/BodyActivationListener.h>
+#include <mutex>
+#include <vector>
+
+using namespace JPH;
+
+// Queues activation events, since Jolt reports them from its worker threads while
+// holding locks, where Go callbacks couldn't safely use the body interface
+class QueuedBodyActivationListener : public BodyActivationListener
+{
+public:
+	virtual void OnBodyActivated(const BodyID& inBodyID, uint64 inBodyUserData) override
+	{
+		Push(inBodyID, true);
+	}
+
+	virtual void OnBodyDeactivated(const BodyID& inBodyID, uint64 inBodyUserData) override
+	{
+		Push(inBodyID, false);
+	}
+
+	// Move the oldest events out, with new body ID copies for the caller
+	int Take(JoltBodyActivationEvent* events, int maxEvents)
+	{
+		std::lock_guard<std::mutex> lock(m_mutex);
+
+		int numEvents = static_cast<int>(m_events.size());
+		int numToTake = numEvents < maxEvents ? numEvents : maxEvents;
+		for (int i = 0; i < numToTake; i++)
+		{
+			events[i].bodyID = static_cast<JoltBodyID>(new BodyID(m_events[i].first));
+			events[i].activated = m_events[i].second ? 1 : 0;
+		}
+		m_events.erase(m_events.begin(), m_events.begin() + numToTake);
+		return numToTake;
+	}
+
+private:
+	void Push(const BodyID& inBodyID, bool inActivated)
+	{
+		// Called from several worker threads at once
+		std::lock_guard<std::mutex> lock(m_mutex);
+		m_events.push_back({ inBodyID, inActivated });
+	}
+
+	std::mutex m_mutex;
+	std::vector<std::pair<BodyID, bool>> m_events;
+};
+
+void JoltPhysicsSystemSetBodyActivationListener(JoltPhysicsSystem system, int enabled)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	if (enabled == 0)
+	{
+		SetBodyActivationListener(wrapper, nullptr);
+		return;
+	}
+
+	// Keep the queue of an existing listener
+	if (GetBodyActivationListener(wrapper) == nullptr)
+	{
+		SetBodyActivationListener(wrapper, new QueuedBodyActivationListener());
+	}
+}
+
+int JoltPhysicsSystemTakeBodyActivationEvents(JoltPhysicsSystem system,
+                                              JoltBodyActivationEvent* events,
+                                              int maxEvents)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	QueuedBodyActivationListener* listener = static_cast<QueuedBodyActivationListener*>(GetBodyActivationListener(wrapper));
+	return listener ? listener->Take(events, maxEvents) : 0;
+}
@@ -0,0 +1,39 @@
+/*
+ * Jolt Physics C Wrapper - Body Activation Listener
+ *
+ * Queues bodies waking up and going to sleep, so Go can dispatch them after each step.
+ */
+
+#ifndef JOLT_WRAPPER_ACTIVATION_H
+#define JOLT_WRAPPER_ACTIVATION_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque pointer types
+typedef void* JoltPhysicsSystem;
+typedef void* JoltBodyID;
+
+// A body that woke up or went to sleep
+typedef struct {
+    JoltBodyID bodyID;  // New copy of the body ID (caller must destroy)
+    int activated;      // 1 if the body woke up, 0 if it went to sleep
+} JoltBodyActivationEvent;
+
+// Start (enabled != 0) or stop queueing activation events of a physics system
+// Stopping drops the events that were not taken yet
+void JoltPhysicsSystemSetBodyActivationListener(JoltPhysicsSystem system, int enabled);
+
+// Take the oldest queued activation events, in the order they happened
+// events: pointer to array to store events (must be pre-allocated)
+// Returns: number of events taken (0 when the queue is empty or no listener is set)
+int JoltPhysicsSystemTakeBodyActivationEvents(JoltPhysicsSystem system,
+                                              JoltBodyActivationEvent* events,
+                                              int maxEvents);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // JOLT_WRAPPER_ACTIVATION_H
@@ -4,22 +4,19 @@
 
 #include "body.h"
 #include "physics.h"
+#include "layers.h"
 #include <Jolt/Jolt.h>
 #include <Jolt/Physics/PhysicsSystem.h>
 #include <Jolt/Physics/Body/Body.h>
 #include <Jolt/Physics/Body/BodyCreationSettings.h>
 #include <Jolt/Physics/Body/BodyInterface.h>
+#include <Jolt/Physics/Body/BodyLock.h>
+#include <Jolt/Physics/Collision/TransformedShape.h>
+#include <Jolt/Physics/Collision/GroupFilterTable.h>
 #include <memory>
 
 using namespace JPH;
 
-// Collision layers (defined in physics.cpp)
-namespace Layers
-{
-	static constexpr ObjectLayer NON_MOVING = 0;
-	static constexpr ObjectLayer MOVING = 1;
-};
-
 JoltBodyInterface JoltPhysicsSystemGetBodyInterface(JoltPhysicsSystem system)
 {
 	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
@@ -42,14 +39,262 @@ void JoltGetBodyPosition(const JoltBodyInterface bodyInterface,
 	*z = static_cast<float>(pos.GetZ());
 }
 
+void JoltSetBodyRotation(JoltBodyInterface bodyInterface,
+						 JoltBodyID bodyID,
+						 float x, float y, float z, float w,
+						 int activate)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bi->SetRotation(*bid, Quat(x, y, z, w).Normalized(), activate != 0 ? EActivation::Activate : EActivation::DontActivate);
+}
+
+int JoltMoveBodyKinematic(JoltBodyInterface bodyInterface,
+						  JoltBodyID bodyID,
+						  float x, float y, float z,
+						  float rx, float ry, float rz, float rw,
+						  float deltaTime)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	// Static bodies can't have a velocity, Jolt asserts on them
+	if (bi->GetMotionType(*bid) == EMotionType::Static)
+	{
+		return 0;
+	}
+
+	bi->MoveKinematic(*bid, RVec3(x, y, z), Quat(rx, ry, rz, rw).Normalized(), deltaTime);
+	return 1;
+}
+
+void JoltGetBodyRotation(const JoltBodyInterface bodyInterface,
+						 const JoltBodyID bodyID,
+						 float *x, float *y, float *z, float *w)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	Quat rotation = bi->GetRotation(*bid);
+	*x = rotation.GetX();
+	*y = rotation.GetY();
+	*z = rotation.GetZ();
+	*w = rotation.GetW();
+}
+
+// Writes a transform as 16 floats in column-major order
+static void ToColumnMajor(RMat44Arg transform, float *outMatrix)
+{
+	for (int col = 0; col < 4; ++col)
+	{
+		for (int row = 0; row < 4; ++row)
+		{
+			outMatrix[col * 4 + row] = static_cast<float>(transform(row, col));
+		}
+	}
+}
+
+void JoltGetBodyWorldTransform(const JoltBodyInterface bodyInterface,
+							   const JoltBodyID bodyID,
+							   float *outMatrix)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	ToColumnMajor(bi->GetWorldTransform(*bid), outMatrix);
+}
+
+void JoltGetBodyWorldTransforms(const JoltBodyInterface bodyInterface,
+								const JoltBodyID *bodyIDs, int numBodies,
+								float *outMatrices)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+
+	for (int i = 0; i < numBodies; ++i)
+	{
+		const BodyID *bid = static_cast<const BodyID *>(bodyIDs[i]);
+		ToColumnMajor(bi->GetWorldTransform(*bid), &outMatrices[i * 16]);
+	}
+}
+
+int JoltGetBodiesCombinedWorldBounds(const JoltBodyInterface bodyInterface,
+									 const JoltBodyID *bodyIDs, int numBodies,
+									 float *minX, float *minY, float *minZ,
+									 float *maxX, float *maxY, float *maxZ)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+
+	AABox bounds;
+	for (int i = 0; i < numBodies; ++i)
+	{
+		const BodyID *bid = static_cast<const BodyID *>(bodyIDs[i]);
+		TransformedShape shape = bi->GetTransformedShape(*bid);
+		if (shape.mShape == nullptr) continue;
+
+		bounds.Encapsulate(shape.GetWorldSpaceBounds());
+	}
+
+	if (!bounds.IsValid()) return 0;
+
+	*minX = bounds.mMin.GetX();
+	*minY = bounds.mMin.GetY();
+	*minZ = bounds.mMin.GetZ();
+	*maxX = bounds.mMax.GetX();
+	*maxY = bounds.mMax.GetY();
+	*maxZ = bounds.mMax.GetZ();
+	return 1;
+}
+
+void JoltGetBodyCenterOfMassPosition(const JoltBodyInterface bodyInterface,
+									 const JoltBodyID bodyID,
+									 float *x, float *y, float *z)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	RVec3 pos = bi->GetCenterOfMassPosition(*bid);
+	*x = static_cast<float>(pos.GetX());
+	*y = static_cast<float>(pos.GetY());
+	*z = static_cast<float>(pos.GetZ());
+}
+
 void JoltSetBodyPosition(JoltBodyInterface bodyInterface,
 						 JoltBodyID bodyID,
-						 float x, float y, float z)
+						 float x, float y, float z,
+						 int activate)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bi->SetPosition(*bid, RVec3(x, y, z), activate != 0 ? EActivation::Activate : EActivation::DontActivate);
+}
+
+void JoltSetBodyLinearVelocity(JoltBodyInterface bodyInterface,
+							   JoltBodyID bodyID,
+							   float x, float y, float z)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bi->SetLinearVelocity(*bid, Vec3(x, y, z));
+}
+
+void JoltGetBodyLinearVelocity(const JoltBodyInterface bodyInterface,
+							   const JoltBodyID bodyID,
+							   float *x, float *y, float *z)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	Vec3 velocity = bi->GetLinearVelocity(*bid);
+	*x = velocity.GetX();
+	*y = velocity.GetY();
+	*z = velocity.GetZ();
+}
+
+void JoltGetBodyAngularVelocity(const JoltBodyInterface bodyInterface,
+								const JoltBodyID bodyID,
+								float *x, float *y, float *z)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	Vec3 velocity = bi->GetAngularVelocity(*bid);
+	*x = velocity.GetX();
+	*y = velocity.GetY();
+	*z = velocity.GetZ();
+}
+
+void JoltAddBodyTorque(JoltBodyInterface bodyInterface,
+					   JoltBodyID bodyID,
+					   float x, float y, float z)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bi->AddTorque(*bid, Vec3(x, y, z));
+}
+
+int JoltIsBodyActive(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	return bi->IsActive(*bid) ? 1 : 0;
+}
+
+int JoltApplyBuoyancyImpulse(JoltBodyInterface bodyInterface,
+							 JoltBodyID bodyID,
+							 float surfacePosX, float surfacePosY, float surfacePosZ,
+							 float surfaceNormalX, float surfaceNormalY, float surfaceNormalZ,
+							 float buoyancy, float linearDrag, float angularDrag,
+							 float fluidVelocityX, float fluidVelocityY, float fluidVelocityZ,
+							 float gravityX, float gravityY, float gravityZ,
+							 float deltaTime)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bool submerged = bi->ApplyBuoyancyImpulse(*bid,
+											  RVec3(surfacePosX, surfacePosY, surfacePosZ),
+											  Vec3(surfaceNormalX, surfaceNormalY, surfaceNormalZ),
+											  buoyancy, linearDrag, angularDrag,
+											  Vec3(fluidVelocityX, fluidVelocityY, fluidVelocityZ),
+											  Vec3(gravityX, gravityY, gravityZ),
+											  deltaTime);
+	return submerged ? 1 : 0;
+}
+
+void JoltSetBodyUserData(JoltBodyInterface bodyInterface,
+						 JoltBodyID bodyID,
+						 unsigned long long userData)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bi->SetUserData(*bid, userData);
+}
+
+unsigned long long JoltGetBodyUserData(const JoltBodyInterface bodyInterface,
+									   const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	return bi->GetUserData(*bid);
+}
+
+void JoltSetBodyFriction(JoltBodyInterface bodyInterface, JoltBodyID bodyID, float friction)
 {
 	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
 	const BodyID *bid = static_cast<const BodyID *>(bodyID);
 
-	bi->SetPosition(*bid, RVec3(x, y, z), EActivation::DontActivate);
+	bi->SetFriction(*bid, friction);
+}
+
+float JoltGetBodyFriction(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	return bi->GetFriction(*bid);
+}
+
+void JoltSetBodyRestitution(JoltBodyInterface bodyInterface, JoltBodyID bodyID, float restitution)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	bi->SetRestitution(*bid, restitution);
+}
+
+float JoltGetBodyRestitution(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	return bi->GetRestitution(*bid);
 }
 
 JoltBodyID JoltCreateBody(JoltBodyInterface bodyInterface,
@@ -60,6 +305,10 @@ JoltBodyID JoltCreateBody(JoltBodyInterface bodyInterface,
 {
 	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
 	const Shape *s = static_cast<const Shape *>(shape);
+	if (!s)
+	{
+		return nullptr;
+	}
 
 	// Convert motion type
 	EMotionType joltMotionType;
@@ -108,6 +357,60 @@ JoltBodyID JoltCreateBody(JoltBodyInterface bodyInterface,
 	return static_cast<JoltBodyID>(bodyIDPtr.release());
 }
 
+int JoltCreateBodiesFromShape(JoltBodyInterface bodyInterface,
+							  JoltShape shape,
+							  const float *positions, int count,
+							  int isDynamic,
+							  JoltBodyID *outBodyIDs)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const Shape *s = static_cast<const Shape *>(shape);
+	if (!s || count <= 0)
+	{
+		return 0;
+	}
+
+	EMotionType motionType = isDynamic ? EMotionType::Dynamic : EMotionType::Static;
+	ObjectLayer layer = isDynamic ? Layers::MOVING : Layers::NON_MOVING;
+
+	Array<BodyID> ids;
+	ids.reserve(count);
+	for (int i = 0; i < count; ++i)
+	{
+		BodyCreationSettings body_settings(
+			s,
+			RVec3(positions[i * 3], positions[i * 3 + 1], positions[i * 3 + 2]),
+			Quat::sIdentity(),
+			motionType,
+			layer);
+
+		Body *body = bi->CreateBody(body_settings);
+		if (!body)
+		{
+			break; // Out of bodies, add the ones created so far
+		}
+		ids.push_back(body->GetID());
+	}
+
+	int numCreated = (int)ids.size();
+	if (numCreated == 0)
+	{
+		return 0;
+	}
+
+	// AddBodiesPrepare reorders the array it is given, so batch-add a copy to keep ids in position order
+	Array<BodyID> batch = ids;
+	BodyInterface::AddState state = bi->AddBodiesPrepare(batch.data(), numCreated);
+	bi->AddBodiesFinalize(batch.data(), numCreated, state,
+						  isDynamic ? EActivation::Activate : EActivation::DontActivate);
+
+	for (int i = 0; i < numCreated; ++i)
+	{
+		outBodyIDs[i] = static_cast<JoltBodyID>(new BodyID(ids[i]));
+	}
+	return numCreated;
+}
+
 void JoltActivateBody(JoltBodyInterface bodyInterface, JoltBodyID bodyID)
 {
 	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
@@ -127,13 +430,35 @@ void JoltDeactivateBody(JoltBodyInterface bodyInterface, JoltBodyID bodyID)
 void JoltSetBodyShape(JoltBodyInterface bodyInterface,
 					 JoltBodyID bodyID,
 					 JoltShape shape,
-					 int updateMassProperties)
+					 int updateMassProperties,
+					 int activate)
 {
 	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
 	const BodyID *bid = static_cast<const BodyID *>(bodyID);
 	const Shape *s = static_cast<const Shape *>(shape);
 
-	bi->SetShape(*bid, s, updateMassProperties != 0, EActivation::Activate);
+	bi->SetShape(*bid, s, updateMassProperties != 0, activate != 0 ? EActivation::Activate : EActivation::DontActivate);
+}
+
+JoltShape JoltGetBodyShape(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	RefConst<Shape> shape = bi->GetShape(*bid);
+	if (shape == nullptr)
+	{
+		return nullptr;
+	}
+
+	// Shapes are ref-counted, AddRef so the caller owns a reference
+	shape->AddRef();
+	return static_cast<JoltShape>(const_cast<Shape *>(shape.GetPtr()));
+}
+
+JoltBodyID JoltCreateBodyID()
+{
+	return new BodyID();
 }
 
 void JoltDestroyBodyID(JoltBodyID bodyID)
@@ -141,3 +466,281 @@ void JoltDestroyBodyID(JoltBodyID bodyID)
 	BodyID *bid = static_cast<BodyID *>(bodyID);
 	delete bid;
 }
+
+unsigned int JoltBodyIDGetIndexAndSequenceNumber(const JoltBodyID bodyID)
+{
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+	return bid->GetIndexAndSequenceNumber();
+}
+
+JoltGroupFilterTable JoltCreateGroupFilterTable(unsigned int numSubGroups)
+{
+	// Group filters are ref-counted, AddRef to keep it alive until JoltDestroyGroupFilterTable
+	GroupFilterTable* table = new GroupFilterTable(numSubGroups);
+	table->AddRef();
+
+	return static_cast<JoltGroupFilterTable>(table);
+}
+
+void JoltDestroyGroupFilterTable(JoltGroupFilterTable table)
+{
+	GroupFilterTable* t = static_cast<GroupFilterTable*>(table);
+	t->Release();
+}
+
+void JoltGroupFilterTableDisableCollision(JoltGroupFilterTable table,
+										  unsigned int subGroupA, unsigned int subGroupB)
+{
+	GroupFilterTable* t = static_cast<GroupFilterTable*>(table);
+	t->DisableCollision(subGroupA, subGroupB);
+}
+
+void JoltGroupFilterTableEnableCollision(JoltGroupFilterTable table,
+										 unsigned int subGroupA, unsigned int subGroupB)
+{
+	GroupFilterTable* t = static_cast<GroupFilterTable*>(table);
+	t->EnableCollision(subGroupA, subGroupB);
+}
+
+int JoltGroupFilterTableIsCollisionEnabled(const JoltGroupFilterTable table,
+										   unsigned int subGroupA, unsigned int subGroupB)
+{
+	const GroupFilterTable* t = static_cast<const GroupFilterTable*>(table);
+	return t->IsCollisionEnabled(subGroupA, subGroupB) ? 1 : 0;
+}
+
+void JoltSetBodyCollisionGroup(JoltPhysicsSystem system,
+							  JoltBodyID bodyID,
+							  JoltGroupFilterTable table,
+							  unsigned int groupID,
+							  unsigned int subGroupID)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+	const GroupFilter* filter = static_cast<const GroupFilterTable*>(table);
+
+	// Collision groups are not exposed through BodyInterface, write to the body directly
+	BodyLockWrite lock(ps->GetBodyLockInterface(), *bid);
+	if (lock.Succeeded())
+	{
+		lock.GetBody().SetCollisionGroup(CollisionGroup(filter, groupID, subGroupID));
+	}
+}
+
+void JoltSetBodyInertia(JoltPhysicsSystem system,
+						JoltBodyID bodyID,
+						float mass,
+						float inertiaX, float inertiaY, float inertiaZ)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	// Mass properties are not exposed through BodyInterface, write to the body directly
+	BodyLockWrite lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || !lock.GetBody().IsDynamic())
+	{
+		return;
+	}
+
+	// Zero inertia means infinite resistance, i.e. no rotation around that axis
+	auto inverse = [](float value) { return value > 0.0f ? 1.0f / value : 0.0f; };
+
+	MotionProperties* mp = lock.GetBody().GetMotionProperties();
+	mp->SetInverseMass(inverse(mass));
+	mp->SetInverseInertia(Vec3(inverse(inertiaX), inverse(inertiaY), inverse(inertiaZ)), Quat::sIdentity());
+}
+
+void JoltSetBodyMaxLinearVelocity(JoltPhysicsSystem system, JoltBodyID bodyID, float maxVelocity)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	// Velocity limits live in the motion properties, which BodyInterface doesn't expose
+	BodyLockWrite lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || lock.GetBody().IsStatic())
+	{
+		return;
+	}
+
+	// Jolt asserts on negative limits (also rejects NaN)
+	if (!(maxVelocity >= 0.0f))
+	{
+		return;
+	}
+
+	lock.GetBody().GetMotionProperties()->SetMaxLinearVelocity(maxVelocity);
+}
+
+float JoltGetBodyMaxLinearVelocity(JoltPhysicsSystem system, JoltBodyID bodyID)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	BodyLockRead lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || lock.GetBody().IsStatic())
+	{
+		return 0.0f;
+	}
+
+	return lock.GetBody().GetMotionProperties()->GetMaxLinearVelocity();
+}
+
+void JoltSetBodyMaxAngularVelocity(JoltPhysicsSystem system, JoltBodyID bodyID, float maxVelocity)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	BodyLockWrite lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || lock.GetBody().IsStatic())
+	{
+		return;
+	}
+
+	// Jolt asserts on negative limits (also rejects NaN)
+	if (!(maxVelocity >= 0.0f))
+	{
+		return;
+	}
+
+	lock.GetBody().GetMotionProperties()->SetMaxAngularVelocity(maxVelocity);
+}
+
+float JoltGetBodyMaxAngularVelocity(JoltPhysicsSystem system, JoltBodyID bodyID)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	BodyLockRead lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || lock.GetBody().IsStatic())
+	{
+		return 0.0f;
+	}
+
+	return lock.GetBody().GetMotionProperties()->GetMaxAngularVelocity();
+}
+
+int JoltGetBodySurfaceNormal(JoltPhysicsSystem system,
+							 JoltBodyID bodyID,
+							 unsigned int subShapeID,
+							 float pointX, float pointY, float pointZ,
+							 float* outX, float* outY, float* outZ)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	BodyLockRead lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded())
+	{
+		return 0;
+	}
+
+	SubShapeID id;
+	id.SetValue(subShapeID);
+	Vec3 normal = lock.GetBody().GetWorldSpaceSurfaceNormal(id, RVec3(pointX, pointY, pointZ));
+	*outX = normal.GetX();
+	*outY = normal.GetY();
+	*outZ = normal.GetZ();
+	return 1;
+}
+
+void JoltSetBodyCollisionEnabled(JoltBodyInterface bodyInterface,
+								 JoltBodyID bodyID,
+								 int enabled)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	ObjectLayer layer = Layers::DISABLED;
+	if (enabled != 0)
+	{
+		// Same layer as JoltCreateBody picks for the motion type
+		layer = bi->GetMotionType(*bid) == EMotionType::Static ? Layers::NON_MOVING : Layers::MOVING;
+	}
+
+	bi->SetObjectLayer(*bid, layer);
+}
+
+int JoltIsBodyCollisionEnabled(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	return bi->GetObjectLayer(*bid) != Layers::DISABLED ? 1 : 0;
+}
+
+int JoltSetBodyObjectLayer(JoltBodyInterface bodyInterface, JoltBodyID bodyID, int layer)
+{
+	BodyInterface *bi = static_cast<BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	// The layer filters only know the layers in layers.h, others would index past their tables
+	if (layer < 0 || layer >= Layers::NUM_LAYERS)
+	{
+		return 0;
+	}
+
+	bi->SetObjectLayer(*bid, static_cast<ObjectLayer>(layer));
+	return 1;
+}
+
+int JoltGetBodyObjectLayer(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID)
+{
+	const BodyInterface *bi = static_cast<const BodyInterface *>(bodyInterface);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	return static_cast<int>(bi->GetObjectLayer(*bid));
+}
+
+int JoltGetBodyMassProperties(JoltPhysicsSystem system,
+							  JoltBodyID bodyID,
+							  float* outMass,
+							  float* outCenterOfMass,
+							  float* outInertia)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID *bid = static_cast<const BodyID *>(bodyID);
+
+	// Mass properties are not exposed through BodyInterface, read from the body directly
+	BodyLockRead lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || !lock.GetBody().IsDynamic())
+	{
+		return 0;
+	}
+
+	const Body& body = lock.GetBody();
+	const MotionProperties* mp = body.GetMotionProperties();
+
+	// Jolt stores inverses, an inverse of 0 means the body can't move (reported as 0 like SetInertia takes it)
+	auto inverse = [](float value) { return value > 0.0f ? 1.0f / value : 0.0f; };
+
+	*outMass = inverse(mp->GetInverseMass());
+
+	Vec3 com = body.GetShape()->GetCenterOfMass();
+	outCenterOfMass[0] = com.GetX();
+	outCenterOfMass[1] = com.GetY();
+	outCenterOfMass[2] = com.GetZ();
+
+	// Inertia = R * diagonal * R^T, with R the rotation to the principal axes
+	Vec3 inv_diagonal = mp->GetInverseInertiaDiagonal();
+	Vec3 diagonal(inverse(inv_diagonal.GetX()), inverse(inv_diagonal.GetY()), inverse(inv_diagonal.GetZ()));
+	Mat44 rotation = Mat44::sRotation(mp->GetInertiaRotation());
+	Mat44 inertia = rotation.Multiply3x3(Mat44::sScale(diagonal)).Multiply3x3RightTransposed(rotation);
+
+	for (int col = 0; col < 3; ++col)
+	{
+		for (int row = 0; row < 3; ++row)
+		{
+			outInertia[col * 3 + row] = inertia(row, col);
+		}
+	}
+
+	return 1;
+}
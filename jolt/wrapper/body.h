@@ -17,6 +17,7 @@ extern "C" {
 typedef void* JoltBodyInterface;
 typedef void* JoltBodyID;
 typedef void* JoltShape;
+typedef void* JoltGroupFilterTable;
 
 // Motion type enum (matches Jolt's EMotionType)
 typedef enum {
@@ -33,18 +34,137 @@ void JoltGetBodyPosition(const JoltBodyInterface bodyInterface,
                         const JoltBodyID bodyID,
                         float* x, float* y, float* z);
 
+// Set the rotation of a body
+// activate: non-zero to wake up the body
+void JoltSetBodyRotation(JoltBodyInterface bodyInterface,
+                         JoltBodyID bodyID,
+                         float x, float y, float z, float w,
+                         int activate);
+
+// Move a kinematic body so it reaches the target position and rotation after deltaTime seconds
+// Sets the body's velocities (and activates it), the body moves during the next update
+// Returns 1 on success, 0 if the body is static
+int JoltMoveBodyKinematic(JoltBodyInterface bodyInterface,
+                          JoltBodyID bodyID,
+                          float x, float y, float z,
+                          float rx, float ry, float rz, float rw,
+                          float deltaTime);
+
+// Get the rotation of a body
+void JoltGetBodyRotation(const JoltBodyInterface bodyInterface,
+                         const JoltBodyID bodyID,
+                         float* x, float* y, float* z, float* w);
+
+// Get the world transform of a body (position and rotation)
+// outMatrix: 16 floats, 4x4 matrix in column-major order
+void JoltGetBodyWorldTransform(const JoltBodyInterface bodyInterface,
+                               const JoltBodyID bodyID,
+                               float* outMatrix);
+
+// Get the world transforms of many bodies in one call
+// bodyIDs: array of numBodies body IDs
+// outMatrices: array of numBodies * 16 floats (allocated by caller), one column-major 4x4 matrix per body
+void JoltGetBodyWorldTransforms(const JoltBodyInterface bodyInterface,
+                                const JoltBodyID* bodyIDs, int numBodies,
+                                float* outMatrices);
+
+// Get the world space bounding box enclosing many bodies in one call
+// bodyIDs: array of numBodies body IDs
+// Returns 1 on success, 0 if none of the bodies exist (the outputs are then left untouched)
+int JoltGetBodiesCombinedWorldBounds(const JoltBodyInterface bodyInterface,
+                                     const JoltBodyID* bodyIDs, int numBodies,
+                                     float* minX, float* minY, float* minZ,
+                                     float* maxX, float* maxY, float* maxZ);
+
+// Get the center of mass position of a body (differs from the position for offset-COM shapes)
+void JoltGetBodyCenterOfMassPosition(const JoltBodyInterface bodyInterface,
+                                     const JoltBodyID bodyID,
+                                     float* x, float* y, float* z);
+
 // Set the position of a body
+// activate: if non-zero, wakes up the body (needed for sleeping bodies to respond after a teleport)
 void JoltSetBodyPosition(JoltBodyInterface bodyInterface,
                         JoltBodyID bodyID,
-                        float x, float y, float z);
+                        float x, float y, float z,
+                        int activate);
+
+// Set the linear velocity of a body (activates the body if the velocity is non-zero)
+void JoltSetBodyLinearVelocity(JoltBodyInterface bodyInterface,
+                              JoltBodyID bodyID,
+                              float x, float y, float z);
+
+// Get the linear velocity of a body
+void JoltGetBodyLinearVelocity(const JoltBodyInterface bodyInterface,
+                              const JoltBodyID bodyID,
+                              float* x, float* y, float* z);
+
+// Get the angular velocity of a body (radians/s around each world axis)
+void JoltGetBodyAngularVelocity(const JoltBodyInterface bodyInterface,
+                               const JoltBodyID bodyID,
+                               float* x, float* y, float* z);
+
+// Add a torque to a body, applied during the next simulation step
+void JoltAddBodyTorque(JoltBodyInterface bodyInterface,
+                       JoltBodyID bodyID,
+                       float x, float y, float z);
+
+// Check if a body is active (not sleeping)
+// Returns 1 if active, 0 if sleeping or not in the world
+int JoltIsBodyActive(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID);
+
+// Apply buoyancy and drag impulses for a body partially or fully submerged in a fluid
+// surfacePosition/surfaceNormal: plane of the fluid surface (normal pointing out of the fluid)
+// buoyancy: 1 = neutral, < 1 sinks, > 1 floats
+// Returns 1 if the body is (partially) submerged and impulses were applied, 0 otherwise
+int JoltApplyBuoyancyImpulse(JoltBodyInterface bodyInterface,
+                             JoltBodyID bodyID,
+                             float surfacePosX, float surfacePosY, float surfacePosZ,
+                             float surfaceNormalX, float surfaceNormalY, float surfaceNormalZ,
+                             float buoyancy, float linearDrag, float angularDrag,
+                             float fluidVelocityX, float fluidVelocityY, float fluidVelocityZ,
+                             float gravityX, float gravityY, float gravityZ,
+                             float deltaTime);
+
+// Set the user data of a body (an arbitrary 64-bit value, e.g. an entity ID)
+void JoltSetBodyUserData(JoltBodyInterface bodyInterface,
+                        JoltBodyID bodyID,
+                        unsigned long long userData);
+
+// Get the user data of a body
+unsigned long long JoltGetBodyUserData(const JoltBodyInterface bodyInterface,
+                                       const JoltBodyID bodyID);
+
+// Set the friction of a body (usually between 0 and 1, 0 = no friction)
+void JoltSetBodyFriction(JoltBodyInterface bodyInterface, JoltBodyID bodyID, float friction);
+
+// Get the friction of a body
+float JoltGetBodyFriction(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID);
+
+// Set the restitution (bounciness) of a body (0 = no bounce, 1 = fully elastic)
+void JoltSetBodyRestitution(JoltBodyInterface bodyInterface, JoltBodyID bodyID, float restitution);
+
+// Get the restitution of a body
+float JoltGetBodyRestitution(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID);
 
 // Create a body with specific motion type and sensor flag
+// Returns NULL if shape is NULL or the body couldn't be created (e.g. too many bodies)
 JoltBodyID JoltCreateBody(JoltBodyInterface bodyInterface,
                           JoltShape shape,
                           float x, float y, float z,
                           JoltMotionType motionType,
                           int isSensor);
 
+// Create one body per position sharing the same shape, added to the system in a single batch
+// positions: array of count * 3 floats (x, y, z per body)
+// isDynamic: non-zero creates active dynamic bodies, zero creates static bodies
+// outBodyIDs: array of count body IDs (allocated by caller), filled in position order
+// Returns the number of bodies created, which is less than count if the system ran out of bodies
+int JoltCreateBodiesFromShape(JoltBodyInterface bodyInterface,
+                              JoltShape shape,
+                              const float* positions, int count,
+                              int isDynamic,
+                              JoltBodyID* outBodyIDs);
+
 // Activate a body (makes it participate in simulation)
 void JoltActivateBody(JoltBodyInterface bodyInterface, JoltBodyID bodyID);
 
@@ -55,11 +175,107 @@ void JoltDeactivateBody(JoltBodyInterface bodyInterface, JoltBodyID bodyID);
 void JoltSetBodyShape(JoltBodyInterface bodyInterface,
                      JoltBodyID bodyID,
                      JoltShape shape,
-                     int updateMassProperties);
+                     int updateMassProperties,
+                     int activate);
+
+// Get the shape of a body
+// Returns: a new reference to the shape (caller must destroy), or NULL if the body doesn't exist
+JoltShape JoltGetBodyShape(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID);
+
+// Create an invalid body ID, e.g. as storage for JoltCharacterVirtualGetActiveContact to copy into
+JoltBodyID JoltCreateBodyID();
 
 // Destroy a body ID
 void JoltDestroyBodyID(JoltBodyID bodyID);
 
+// Get the value of a body ID (index and sequence number), unique within a physics system
+unsigned int JoltBodyIDGetIndexAndSequenceNumber(const JoltBodyID bodyID);
+
+// Create a group filter table (all sub-groups collide with each other initially)
+JoltGroupFilterTable JoltCreateGroupFilterTable(unsigned int numSubGroups);
+
+// Destroy a group filter table (decrements ref count, bodies using it keep it alive)
+void JoltDestroyGroupFilterTable(JoltGroupFilterTable table);
+
+// Disable collision between two sub-groups of the same group
+void JoltGroupFilterTableDisableCollision(JoltGroupFilterTable table,
+                                          unsigned int subGroupA, unsigned int subGroupB);
+
+// Enable collision between two sub-groups of the same group
+void JoltGroupFilterTableEnableCollision(JoltGroupFilterTable table,
+                                         unsigned int subGroupA, unsigned int subGroupB);
+
+// Check if two sub-groups of the same group collide
+// Returns 1 if collision is enabled, 0 if disabled
+int JoltGroupFilterTableIsCollisionEnabled(const JoltGroupFilterTable table,
+                                           unsigned int subGroupA, unsigned int subGroupB);
+
+// Set the collision group of a body
+// table: group filter deciding which sub-groups collide (can be NULL to collide with everything)
+void JoltSetBodyCollisionGroup(JoltPhysicsSystem system,
+                              JoltBodyID bodyID,
+                              JoltGroupFilterTable table,
+                              unsigned int groupID,
+                              unsigned int subGroupID);
+
+// Override the mass and inertia of a dynamic body
+// inertiaX/Y/Z: diagonal of the inertia tensor around the body's local axes (0 locks rotation around that axis)
+void JoltSetBodyInertia(JoltPhysicsSystem system,
+                        JoltBodyID bodyID,
+                        float mass,
+                        float inertiaX, float inertiaY, float inertiaZ);
+
+// Set the maximum linear velocity (m/s) of a dynamic or kinematic body, Jolt clamps faster bodies every step
+// Negative or NaN values are ignored
+void JoltSetBodyMaxLinearVelocity(JoltPhysicsSystem system, JoltBodyID bodyID, float maxVelocity);
+
+// Get the maximum linear velocity (m/s) of a body, 0 for static bodies
+float JoltGetBodyMaxLinearVelocity(JoltPhysicsSystem system, JoltBodyID bodyID);
+
+// Set the maximum angular velocity (rad/s) of a dynamic or kinematic body, Jolt clamps faster bodies every step
+// Negative or NaN values are ignored
+void JoltSetBodyMaxAngularVelocity(JoltPhysicsSystem system, JoltBodyID bodyID, float maxVelocity);
+
+// Get the maximum angular velocity (rad/s) of a body, 0 for static bodies
+float JoltGetBodyMaxAngularVelocity(JoltPhysicsSystem system, JoltBodyID bodyID);
+
+// Get the world space surface normal of a body at a point on its surface
+// subShapeID: the sub-shape the point is on (e.g. from a ray cast hit)
+// Returns 1 on success, 0 if the body doesn't exist (outputs are not written)
+int JoltGetBodySurfaceNormal(JoltPhysicsSystem system,
+                             JoltBodyID bodyID,
+                             unsigned int subShapeID,
+                             float pointX, float pointY, float pointZ,
+                             float* outX, float* outY, float* outZ);
+
+// Enable or disable collision for a body without removing it from the world
+// Disabled bodies keep moving but collide with nothing and are not hit by queries
+// Enabling moves the body back to the layer matching its motion type
+void JoltSetBodyCollisionEnabled(JoltBodyInterface bodyInterface,
+                                 JoltBodyID bodyID,
+                                 int enabled);
+
+// Check if collision is enabled for a body
+// Returns 1 if enabled, 0 if disabled
+int JoltIsBodyCollisionEnabled(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID);
+
+// Set the object layer of a body (a JoltObjectLayer value)
+// Returns 1 on success, 0 if the layer is not a valid JoltObjectLayer
+int JoltSetBodyObjectLayer(JoltBodyInterface bodyInterface, JoltBodyID bodyID, int layer);
+
+// Get the object layer of a body (a JoltObjectLayer value)
+int JoltGetBodyObjectLayer(const JoltBodyInterface bodyInterface, const JoltBodyID bodyID);
+
+// Get the mass properties of a dynamic body
+// outCenterOfMass: 3 floats, center of mass relative to the body position in local space
+// outInertia: 9 floats, inertia tensor around the center of mass in local space (column-major)
+// Returns 1 if the body is dynamic, 0 otherwise (outputs are not written)
+int JoltGetBodyMassProperties(JoltPhysicsSystem system,
+                              JoltBodyID bodyID,
+                              float* outMass,
+                              float* outCenterOfMass,
+                              float* outInertia);
+
 #ifdef __cplusplus
 }
 #endif
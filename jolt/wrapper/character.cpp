@@ -4,21 +4,26 @@
 
 #include "character.h"
 #include "physics.h"
+#include "layers.h"
 #include "core.h"
+#include "shape.h"
 #include <Jolt/Jolt.h>
 #include <Jolt/Core/TempAllocator.h>
 #include <Jolt/Physics/Collision/Shape/CapsuleShape.h>
 #include <Jolt/Physics/Character/CharacterVirtual.h>
+#include <Jolt/Physics/Collision/CollideShape.h>
+#include <Jolt/Physics/Collision/CollisionCollectorImpl.h>
+#include <Jolt/Physics/Collision/NarrowPhaseQuery.h>
+#include <Jolt/Physics/Body/Body.h>
+#include <Jolt/Physics/Body/BodyFilter.h>
+#include <Jolt/Physics/Body/BodyLock.h>
+#include <Jolt/Physics/StateRecorderImpl.h>
+#include <algorithm>
+#include <cstring>
 #include <memory>
 
 using namespace JPH;
 
-// Collision layers (defined in physics.cpp)
-namespace Layers
-{
-	static constexpr ObjectLayer MOVING = 1;
-};
-
 // Adapter: converts ObjectVsBroadPhaseLayerFilter to BroadPhaseLayerFilter for character collision
 class BroadPhaseLayerFilterAdapter : public BroadPhaseLayerFilter
 {
@@ -53,15 +58,110 @@ private:
 	ObjectLayer m_object_layer;
 };
 
-JoltCharacterVirtual JoltCreateCharacterVirtual(JoltPhysicsSystem system,
-											 const JoltCharacterVirtualSettings* goSettings,
-											 float x, float y, float z)
+// Accumulates the impulses a character applies to dynamic bodies during an update, per contact.
+// Jolt doesn't report them, so every time a contact is solved the impulse is recomputed with the
+// formula CharacterVirtual uses to push body B (damped relative velocity along the contact normal,
+// divided by the effective mass at the contact and clamped to the character's max strength).
+// Limitation: the penetration recovery term is left out because Jolt doesn't pass the contact
+// distance to OnContactSolve, so the impulse of a contact that starts out penetrating is too low.
+class CharacterImpulseListener : public CharacterContactListener
 {
-	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
-	const Shape* s = static_cast<const Shape*>(goSettings->shape);
+public:
+	explicit CharacterImpulseListener(PhysicsSystem* system) : m_system(system) {}
 
-	CharacterVirtualSettings settings;
-	settings.mShape = s;
+	// Forget the impulses of the previous update
+	void BeginUpdate(float deltaTime)
+	{
+		m_deltaTime = deltaTime;
+		m_impulses.clear();
+	}
+
+	// Get the total impulse applied to a sub-shape of a body during the last update
+	Vec3 GetImpulse(const BodyID& bodyID, const SubShapeID& subShapeID) const
+	{
+		for (const ContactImpulse& impulse : m_impulses)
+		{
+			if (impulse.bodyID == bodyID && impulse.subShapeID == subShapeID) return impulse.impulse;
+		}
+		return Vec3::sZero();
+	}
+
+	// Same impulse as CharacterVirtual::HandleContact applies when the contact is solved
+	virtual void OnContactSolve(const CharacterVirtual* inCharacter, const BodyID& inBodyID2, const SubShapeID& inSubShapeID2,
+								RVec3Arg inContactPosition, Vec3Arg inContactNormal, Vec3Arg inContactVelocity,
+								const PhysicsMaterial* inContactMaterial, Vec3Arg inCharacterVelocity, Vec3& ioNewCharacterVelocity) override
+	{
+		constexpr float cDamping = 0.9f;
+		float deltaVelocity = -(inCharacterVelocity - inContactVelocity).Dot(inContactNormal) * cDamping;
+		if (deltaVelocity <= 0.0f) return;
+
+		// Bodies don't move during a character update, so read them without locking
+		BodyLockRead lock(m_system->GetBodyLockInterfaceNoLock(), inBodyID2);
+		if (!lock.Succeeded()) return;
+
+		const Body& body = lock.GetBody();
+		if (!body.IsDynamic() || body.IsSensor()) return;
+
+		Vec3 jacobian = Vec3(inContactPosition - body.GetCenterOfMassPosition()).Cross(inContactNormal);
+		float inverseEffectiveMass = body.GetInverseInertia().Multiply3x3(jacobian).Dot(jacobian)
+			+ body.GetMotionProperties()->GetInverseMass();
+		if (inverseEffectiveMass <= 0.0f) return;
+
+		float magnitude = std::min(deltaVelocity / inverseEffectiveMass, inCharacter->GetMaxStrength() * m_deltaTime);
+		Vec3 impulse = -magnitude * inContactNormal;
+
+		// Jolt cancels the downward part, gravity pushes the body instead
+		Vec3 up = inCharacter->GetUp();
+		float impulseDotUp = impulse.Dot(up);
+		if (impulseDotUp < 0.0f) impulse -= impulseDotUp * up;
+
+		for (ContactImpulse& contact : m_impulses)
+		{
+			if (contact.bodyID == inBodyID2 && contact.subShapeID == inSubShapeID2)
+			{
+				contact.impulse += impulse;
+				return;
+			}
+		}
+		m_impulses.push_back({ inBodyID2, inSubShapeID2, impulse });
+	}
+
+private:
+	struct ContactImpulse
+	{
+		BodyID bodyID;
+		SubShapeID subShapeID;
+		Vec3 impulse;
+	};
+
+	PhysicsSystem* m_system;
+	float m_deltaTime = 0.0f;
+	Array<ContactImpulse> m_impulses;
+};
+
+// Character contact listener for simulated moves: keeps the character from pushing bodies
+class NoImpulseListener : public CharacterContactListener
+{
+public:
+	virtual void OnContactAdded(const CharacterVirtual* inCharacter, const BodyID& inBodyID2, const SubShapeID& inSubShapeID2,
+								RVec3Arg inContactPosition, Vec3Arg inContactNormal, CharacterContactSettings& ioSettings) override
+	{
+		ioSettings.mCanReceiveImpulses = false;
+	}
+
+	virtual void OnContactPersisted(const CharacterVirtual* inCharacter, const BodyID& inBodyID2, const SubShapeID& inSubShapeID2,
+									RVec3Arg inContactPosition, Vec3Arg inContactNormal, CharacterContactSettings& ioSettings) override
+	{
+		ioSettings.mCanReceiveImpulses = false;
+	}
+};
+
+// Converts Go-side settings to Jolt settings
+// Converts goSettings, using shape instead of goSettings->shape, which callers rebuilding an existing
+// character must not touch: the Go shape may have been destroyed since SetShape replaced it
+static void ToCharacterVirtualSettings(const JoltCharacterVirtualSettings* goSettings, const Shape* shape, CharacterVirtualSettings& settings)
+{
+	settings.mShape = shape;
 	settings.mUp = Vec3(goSettings->upX, goSettings->upY, goSettings->upZ);
 	settings.mMaxSlopeAngle = goSettings->maxSlopeAngle;
 	settings.mMass = goSettings->mass;
@@ -79,14 +179,33 @@ JoltCharacterVirtual JoltCreateCharacterVirtual(JoltPhysicsSystem system,
 	settings.mPenetrationRecoverySpeed = goSettings->penetrationRecoverySpeed;
 	settings.mEnhancedInternalEdgeRemoval = goSettings->enhancedInternalEdgeRemoval != 0;
 
+	// The inner body uses the character shape so rays and dynamic bodies hit the character
+	if (goSettings->createInnerBody != 0)
+	{
+		settings.mInnerBodyShape = shape;
+		settings.mInnerBodyLayer = static_cast<ObjectLayer>(goSettings->innerBodyLayer);
+	}
+}
+
+JoltCharacterVirtual JoltCreateCharacterVirtual(JoltPhysicsSystem system,
+											 const JoltCharacterVirtualSettings* goSettings,
+											 float x, float y, float z)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+
+	CharacterVirtualSettings settings;
+	ToCharacterVirtualSettings(goSettings, static_cast<const Shape*>(goSettings->shape), settings);
+
 	// Create at specified position using smart pointer for exception safety
 	auto character = std::make_unique<CharacterVirtual>(&settings, RVec3(x, y, z), Quat::sIdentity(), GetPhysicsSystem(wrapper));
+	character->SetListener(new CharacterImpulseListener(GetPhysicsSystem(wrapper)));
 	return static_cast<JoltCharacterVirtual>(character.release());
 }
 
 void JoltDestroyCharacterVirtual(JoltCharacterVirtual character)
 {
 	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+	delete cv->GetListener();
 	delete cv;
 }
 
@@ -102,6 +221,9 @@ void JoltCharacterVirtualUpdate(JoltCharacterVirtual character,
 	BroadPhaseLayerFilterAdapter broad_phase_filter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
 	ObjectLayerFilterAdapter object_layer_filter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
 
+	CharacterImpulseListener* listener = static_cast<CharacterImpulseListener*>(cv->GetListener());
+	listener->BeginUpdate(deltaTime);
+
 	// Call basic Update with gravity vector and layer filters
 	cv->Update(
 		deltaTime,
@@ -114,32 +236,160 @@ void JoltCharacterVirtualUpdate(JoltCharacterVirtual character,
 	);
 }
 
-void JoltCharacterVirtualExtendedUpdate(JoltCharacterVirtual character,
-										JoltPhysicsSystem system,
-										float deltaTime,
-										float gravityX, float gravityY, float gravityZ)
+void JoltCharacterVirtualSimulateMove(JoltCharacterVirtual character,
+									  JoltPhysicsSystem system,
+									  const JoltCharacterVirtualSettings* goSettings,
+									  float deltaTime,
+									  float velocityX, float velocityY, float velocityZ,
+									  float gravityX, float gravityY, float gravityZ,
+									  float* outX, float* outY, float* outZ)
 {
-	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
 	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
 
-	// Use default extended update settings
-	CharacterVirtual::ExtendedUpdateSettings settings;
+	// Jolt has no dry run, so move a copy of the character: same settings and live shape, but no inner body
+	CharacterVirtualSettings settings;
+	ToCharacterVirtualSettings(goSettings, cv->GetShape(), settings);
+	settings.mInnerBodyShape = nullptr;
+
+	auto probe = std::make_unique<CharacterVirtual>(&settings, cv->GetPosition(), cv->GetRotation(), GetPhysicsSystem(wrapper));
+
+	// Start from the character's ground state and contacts so the move matches Update
+	StateRecorderImpl state;
+	cv->SaveState(state);
+	state.Rewind();
+	probe->RestoreState(state);
+
+	NoImpulseListener noImpulses;
+	probe->SetListener(&noImpulses);
 
-	// Use MOVING layer for character (same as dynamic bodies)
 	BroadPhaseLayerFilterAdapter broad_phase_filter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
 	ObjectLayerFilterAdapter object_layer_filter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+	IgnoreSingleBodyFilter body_filter(cv->GetInnerBodyID()); // The copy must not collide with the real character
 
-	// Call ExtendedUpdate with gravity vector and layer filters
-	cv->ExtendedUpdate(
+	probe->SetLinearVelocity(Vec3(velocityX, velocityY, velocityZ));
+	probe->Update(
 		deltaTime,
 		Vec3(gravityX, gravityY, gravityZ),
-		settings,
 		broad_phase_filter,
 		object_layer_filter,
-		{}, // Empty BodyFilter (collides with all bodies)
+		body_filter,
 		{}, // Empty ShapeFilter (collides with all shapes)
 		*gTempAllocator.get()
 	);
+
+	RVec3 position = probe->GetPosition();
+	*outX = static_cast<float>(position.GetX());
+	*outY = static_cast<float>(position.GetY());
+	*outZ = static_cast<float>(position.GetZ());
+}
+
+void JoltCharacterVirtualExtendedUpdate(JoltCharacterVirtual character,
+										JoltPhysicsSystem system,
+										float deltaTime,
+										float gravityX, float gravityY, float gravityZ,
+										JoltCharacterUpdateResult* outResult)
+{
+	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	RVec3 oldPosition = cv->GetPosition();
+	Vec3 up = cv->GetUp();
+
+	// Default extended update settings, with the step directions along the character's up instead of Y
+	CharacterVirtual::ExtendedUpdateSettings settings;
+	settings.mStickToFloorStepDown = -0.5f * up;
+	settings.mWalkStairsStepUp = 0.4f * up;
+
+	// Use MOVING layer for character (same as dynamic bodies)
+	BroadPhaseLayerFilterAdapter broad_phase_filter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter object_layer_filter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+	BodyFilter body_filter;
+	ShapeFilter shape_filter;
+	TempAllocator& allocator = *gTempAllocator.get();
+
+	CharacterImpulseListener* listener = static_cast<CharacterImpulseListener*>(cv->GetListener());
+	listener->BeginUpdate(deltaTime);
+
+	// The steps of CharacterVirtual::ExtendedUpdate, done here because Jolt doesn't report the stair walk
+	JoltStairWalkInfo stairWalk = {};
+
+	Vec3 desiredVelocity = cv->GetLinearVelocity();
+	cv->SetLinearVelocity(cv->CancelVelocityTowardsSteepSlopes(desiredVelocity));
+
+	bool groundToAir = cv->IsSupported();
+	cv->Update(deltaTime, Vec3(gravityX, gravityY, gravityZ), broad_phase_filter, object_layer_filter,
+			   body_filter, shape_filter, allocator);
+	if (cv->IsSupported())
+		groundToAir = false;
+
+	// Stick to the floor when walking off a step down, unless the character is moving up
+	if (groundToAir && !settings.mStickToFloorStepDown.IsNearZero())
+	{
+		float velocity = Vec3(cv->GetPosition() - oldPosition).Dot(up) / deltaTime;
+		if (velocity <= 1.0e-6f)
+			cv->StickToFloor(settings.mStickToFloorStepDown, broad_phase_filter, object_layer_filter,
+							 body_filter, shape_filter, allocator);
+	}
+
+	// Try to step up if the character fell short of its desired horizontal step against a steep surface
+	if (!settings.mWalkStairsStepUp.IsNearZero())
+	{
+		Vec3 desiredHorizontalStep = desiredVelocity * deltaTime;
+		desiredHorizontalStep -= desiredHorizontalStep.Dot(up) * up;
+		float desiredHorizontalStepLen = desiredHorizontalStep.Length();
+		if (desiredHorizontalStepLen > 0.0f)
+		{
+			Vec3 achievedHorizontalStep = Vec3(cv->GetPosition() - oldPosition);
+			achievedHorizontalStep -= achievedHorizontalStep.Dot(up) * up;
+
+			// Only count movement in the desired direction, sliding sideways doesn't bring the character closer
+			Vec3 stepForwardNormalized = desiredHorizontalStep / desiredHorizontalStepLen;
+			float achievedHorizontalStepLen = max(0.0f, achievedHorizontalStep.Dot(stepForwardNormalized));
+
+			if (achievedHorizontalStepLen + 1.0e-4f < desiredHorizontalStepLen && cv->CanWalkStairs(desiredVelocity))
+			{
+				Vec3 stepForward = stepForwardNormalized * max(settings.mWalkStairsMinStepForward, desiredHorizontalStepLen - achievedHorizontalStepLen);
+
+				// Scan ahead along the ground normal for a floor, unless it points too far away from the step direction
+				Vec3 stepForwardTest = -cv->GetGroundNormal();
+				stepForwardTest -= stepForwardTest.Dot(up) * up;
+				stepForwardTest = stepForwardTest.NormalizedOr(stepForwardNormalized);
+				if (stepForwardTest.Dot(stepForwardNormalized) < settings.mWalkStairsCosAngleForwardContact)
+					stepForwardTest = stepForwardNormalized;
+				stepForwardTest *= settings.mWalkStairsStepForwardTest;
+
+				RVec3 beforeStep = cv->GetPosition();
+				stairWalk.attempted = 1;
+				if (cv->WalkStairs(deltaTime, settings.mWalkStairsStepUp, stepForward, stepForwardTest, settings.mWalkStairsStepDownExtra,
+								   broad_phase_filter, object_layer_filter, body_filter, shape_filter, allocator))
+				{
+					stairWalk.succeeded = 1;
+					stairWalk.stepHeight = Vec3(cv->GetPosition() - beforeStep).Dot(up);
+				}
+			}
+		}
+	}
+
+	if (!outResult) return;
+
+	Vec3 moved = Vec3(cv->GetPosition() - oldPosition);
+	outResult->movedX = moved.GetX();
+	outResult->movedY = moved.GetY();
+	outResult->movedZ = moved.GetZ();
+	outResult->groundState = static_cast<JoltGroundState>(cv->GetGroundState());
+	outResult->stairWalk = stairWalk;
+
+	// A wall is a surface too steep to walk on that isn't a ceiling (which would be walkable upside down)
+	outResult->hitWall = 0;
+	for (const CharacterVirtual::Contact& c : cv->GetActiveContacts())
+	{
+		if (c.mHadCollision && !c.mIsSensorB
+			&& cv->IsSlopeTooSteep(c.mSurfaceNormal) && cv->IsSlopeTooSteep(-c.mSurfaceNormal))
+		{
+			outResult->hitWall = 1;
+			break;
+		}
+	}
 }
 
 void JoltCharacterVirtualSetLinearVelocity(JoltCharacterVirtual character,
@@ -169,6 +419,12 @@ void JoltCharacterVirtualGetGroundVelocity(const JoltCharacterVirtual character,
 	*z = vel.GetZ();
 }
 
+void JoltCharacterVirtualUpdateGroundVelocity(JoltCharacterVirtual character)
+{
+	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+	cv->UpdateGroundVelocity();
+}
+
 void JoltCharacterVirtualSetPosition(JoltCharacterVirtual character,
 									 float x, float y, float z)
 {
@@ -186,6 +442,40 @@ void JoltCharacterVirtualGetPosition(const JoltCharacterVirtual character,
 	*z = static_cast<float>(pos.GetZ());
 }
 
+// Writes a transform as 16 floats in column-major order (same layout as body.cpp)
+static void ToColumnMajor(RMat44Arg transform, float* outMatrix)
+{
+	for (int col = 0; col < 4; ++col)
+	{
+		for (int row = 0; row < 4; ++row)
+		{
+			outMatrix[col * 4 + row] = static_cast<float>(transform(row, col));
+		}
+	}
+}
+
+void JoltCharacterVirtualGetCenterOfMassPosition(const JoltCharacterVirtual character,
+												 float* x, float* y, float* z)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	RVec3 pos = cv->GetCenterOfMassPosition();
+	*x = static_cast<float>(pos.GetX());
+	*y = static_cast<float>(pos.GetY());
+	*z = static_cast<float>(pos.GetZ());
+}
+
+void JoltCharacterVirtualGetWorldTransform(const JoltCharacterVirtual character, float* outMatrix)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	ToColumnMajor(cv->GetWorldTransform(), outMatrix);
+}
+
+void JoltCharacterVirtualGetCenterOfMassTransform(const JoltCharacterVirtual character, float* outMatrix)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	ToColumnMajor(cv->GetCenterOfMassTransform(), outMatrix);
+}
+
 JoltGroundState JoltCharacterVirtualGetGroundState(const JoltCharacterVirtual character)
 {
 	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
@@ -224,6 +514,93 @@ void JoltCharacterVirtualSetShape(JoltCharacterVirtual character,
 	);
 }
 
+// Body filter for teleport depenetration: skips sensors (the character passes through them)
+// and the character's own inner body
+class TeleportBodyFilter : public BodyFilter
+{
+public:
+	explicit TeleportBodyFilter(const BodyID& innerBodyID) : m_inner_body_id(innerBodyID) {}
+
+	virtual bool ShouldCollide(const BodyID& inBodyID) const override
+	{
+		return inBodyID != m_inner_body_id;
+	}
+
+	virtual bool ShouldCollideLocked(const Body& inBody) const override
+	{
+		return !inBody.IsSensor();
+	}
+
+private:
+	BodyID m_inner_body_id;
+};
+
+int JoltCharacterVirtualSafeTeleport(JoltCharacterVirtual character,
+									 JoltPhysicsSystem system,
+									 float x, float y, float z)
+{
+	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	// Use MOVING layer for character (same as dynamic bodies)
+	BroadPhaseLayerFilterAdapter broad_phase_filter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter object_layer_filter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+	TeleportBodyFilter body_filter(cv->GetInnerBodyID());
+
+	// Penetration below this depth is considered resolved
+	constexpr float cTolerance = 1.0e-3f;
+	constexpr int cMaxIterations = 16;
+
+	RVec3 previous_position = cv->GetPosition();
+	cv->SetPosition(RVec3(x, y, z));
+
+	// Repeatedly push the character out along the deepest contact, this resolves corners in a few iterations
+	bool resolved = false;
+	for (int i = 0; i < cMaxIterations; ++i)
+	{
+		ClosestHitCollisionCollector<CollideShapeCollector> collector;
+		ps->GetNarrowPhaseQuery().CollideShape(
+			cv->GetShape(),
+			Vec3::sReplicate(1.0f),  // Scale
+			cv->GetCenterOfMassTransform(),
+			CollideShapeSettings(),
+			RVec3::sZero(),  // Base offset
+			collector,
+			broad_phase_filter,
+			object_layer_filter,
+			body_filter
+		);
+
+		if (!collector.HadHit() || collector.mHit.mPenetrationDepth <= cTolerance)
+		{
+			resolved = true;
+			break;
+		}
+
+		// Penetration axis points from the character into the other body, move against it
+		const CollideShapeResult& hit = collector.mHit;
+		Vec3 push = -hit.mPenetrationAxis.NormalizedOr(cv->GetUp()) * (hit.mPenetrationDepth + cv->GetCharacterPadding());
+		cv->SetPosition(cv->GetPosition() + push);
+	}
+
+	if (!resolved)
+	{
+		cv->SetPosition(previous_position);
+	}
+
+	// Update the ground state and contacts for the new position
+	cv->RefreshContacts(
+		broad_phase_filter,
+		object_layer_filter,
+		{}, // Empty BodyFilter (collides with all bodies)
+		{}, // Empty ShapeFilter (collides with all shapes)
+		*gTempAllocator.get()
+	);
+
+	return resolved ? 1 : 0;
+}
+
 // Get the shape of a virtual character
 JoltShape JoltCharacterVirtualGetShape(const JoltCharacterVirtual character)
 {
@@ -231,6 +608,18 @@ JoltShape JoltCharacterVirtualGetShape(const JoltCharacterVirtual character)
 	return const_cast<Shape*>(cv->GetShape());
 }
 
+JoltBodyID JoltCharacterVirtualGetInnerBodyID(const JoltCharacterVirtual character)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	BodyID innerBodyID = cv->GetInnerBodyID();
+	if (innerBodyID.IsInvalid())
+	{
+		return nullptr;
+	}
+
+	return static_cast<JoltBodyID>(new BodyID(innerBodyID));
+}
+
 // Get the normal of the ground surface the character is standing on
 void JoltCharacterVirtualGetGroundNormal(const JoltCharacterVirtual character,
 										 float* x, float* y, float* z)
@@ -253,13 +642,69 @@ void JoltCharacterVirtualGetGroundPosition(const JoltCharacterVirtual character,
 	*z = static_cast<float>(pos.GetZ());
 }
 
+// Get the number of active contacts for the character
+int JoltCharacterVirtualGetNumActiveContacts(const JoltCharacterVirtual character)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	return static_cast<int>(cv->GetActiveContacts().size());
+}
+
 // Get the active contacts for the character
+// Copies a Jolt character contact to its C representation, except for bodyB
+static void ToJoltCharacterContact(const CharacterVirtual::Contact& c,
+								   const CharacterImpulseListener* listener,
+								   JoltCharacterContact& out)
+{
+	// Copy position
+	out.positionX = static_cast<float>(c.mPosition.GetX());
+	out.positionY = static_cast<float>(c.mPosition.GetY());
+	out.positionZ = static_cast<float>(c.mPosition.GetZ());
+
+	// Copy linear velocity
+	out.linearVelocityX = c.mLinearVelocity.GetX();
+	out.linearVelocityY = c.mLinearVelocity.GetY();
+	out.linearVelocityZ = c.mLinearVelocity.GetZ();
+
+	// Copy contact normal
+	out.contactNormalX = c.mContactNormal.GetX();
+	out.contactNormalY = c.mContactNormal.GetY();
+	out.contactNormalZ = c.mContactNormal.GetZ();
+
+	// Copy surface normal
+	out.surfaceNormalX = c.mSurfaceNormal.GetX();
+	out.surfaceNormalY = c.mSurfaceNormal.GetY();
+	out.surfaceNormalZ = c.mSurfaceNormal.GetZ();
+
+	// Copy scalar fields
+	out.distance = c.mDistance;
+	out.fraction = c.mFraction;
+
+	// Body ID in the high bits, sub-shape ID in the low bits
+	out.contactID = (static_cast<uint64>(c.mBodyB.GetIndexAndSequenceNumber()) << 32) | c.mSubShapeIDB.GetValue();
+
+	out.userData = c.mUserData;
+	out.materialIndex = GetMaterialIndex(c.mMaterial);
+
+	// Impulse applied to this sub-shape of B during the last update
+	Vec3 impulse = listener->GetImpulse(c.mBodyB, c.mSubShapeIDB);
+	out.impulseX = impulse.GetX();
+	out.impulseY = impulse.GetY();
+	out.impulseZ = impulse.GetZ();
+
+	// Copy bool fields (as int)
+	out.isSensorB = c.mIsSensorB ? 1 : 0;
+	out.hadCollision = c.mHadCollision ? 1 : 0;
+	out.wasDiscarded = c.mWasDiscarded ? 1 : 0;
+	out.canPushCharacter = c.mCanPushCharacter ? 1 : 0;
+}
+
 int JoltCharacterVirtualGetActiveContacts(const JoltCharacterVirtual character,
 										  JoltCharacterContact* contacts,
 										  int maxContacts)
 {
 	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
 	const CharacterVirtual::ContactList& activeContacts = cv->GetActiveContacts();
+	const CharacterImpulseListener* listener = static_cast<const CharacterImpulseListener*>(cv->GetListener());
 
 	int numContacts = static_cast<int>(activeContacts.size());
 	int numToReturn = numContacts < maxContacts ? numContacts : maxContacts;
@@ -267,30 +712,7 @@ int JoltCharacterVirtualGetActiveContacts(const JoltCharacterVirtual character,
 	for (int i = 0; i < numToReturn; i++)
 	{
 		const CharacterVirtual::Contact& c = activeContacts[i];
-
-		// Copy position
-		contacts[i].positionX = static_cast<float>(c.mPosition.GetX());
-		contacts[i].positionY = static_cast<float>(c.mPosition.GetY());
-		contacts[i].positionZ = static_cast<float>(c.mPosition.GetZ());
-
-		// Copy linear velocity
-		contacts[i].linearVelocityX = c.mLinearVelocity.GetX();
-		contacts[i].linearVelocityY = c.mLinearVelocity.GetY();
-		contacts[i].linearVelocityZ = c.mLinearVelocity.GetZ();
-
-		// Copy contact normal
-		contacts[i].contactNormalX = c.mContactNormal.GetX();
-		contacts[i].contactNormalY = c.mContactNormal.GetY();
-		contacts[i].contactNormalZ = c.mContactNormal.GetZ();
-
-		// Copy surface normal
-		contacts[i].surfaceNormalX = c.mSurfaceNormal.GetX();
-		contacts[i].surfaceNormalY = c.mSurfaceNormal.GetY();
-		contacts[i].surfaceNormalZ = c.mSurfaceNormal.GetZ();
-
-		// Copy scalar fields
-		contacts[i].distance = c.mDistance;
-		contacts[i].fraction = c.mFraction;
+		ToJoltCharacterContact(c, listener, contacts[i]);
 
 		// Create a copy of the BodyID for the Go layer
 		if (c.mBodyB.IsInvalid())
@@ -301,15 +723,207 @@ int JoltCharacterVirtualGetActiveContacts(const JoltCharacterVirtual character,
 		{
 			contacts[i].bodyB = new BodyID(c.mBodyB);
 		}
+	}
 
-		contacts[i].userData = c.mUserData;
+	return numToReturn;
+}
 
-		// Copy bool fields (as int)
-		contacts[i].isSensorB = c.mIsSensorB ? 1 : 0;
-		contacts[i].hadCollision = c.mHadCollision ? 1 : 0;
-		contacts[i].wasDiscarded = c.mWasDiscarded ? 1 : 0;
-		contacts[i].canPushCharacter = c.mCanPushCharacter ? 1 : 0;
+int JoltCharacterVirtualGetActiveContact(const JoltCharacterVirtual character,
+										 int index,
+										 JoltCharacterContact* outContact,
+										 JoltBodyID bodyBStorage)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	const CharacterVirtual::ContactList& activeContacts = cv->GetActiveContacts();
+	if (index < 0 || index >= static_cast<int>(activeContacts.size()))
+	{
+		return 0;
 	}
 
-	return numToReturn;
+	const CharacterVirtual::Contact& c = activeContacts[index];
+	ToJoltCharacterContact(c, static_cast<const CharacterImpulseListener*>(cv->GetListener()), *outContact);
+
+	// Copy the BodyID into the caller's storage, never hand out a pointer into the contact list
+	if (c.mBodyB.IsInvalid())
+	{
+		outContact->bodyB = nullptr;
+	}
+	else
+	{
+		*static_cast<BodyID*>(bodyBStorage) = c.mBodyB;
+		outContact->bodyB = bodyBStorage;
+	}
+	return 1;
+}
+
+// Header in front of a saved character state, so corrupt or foreign data is rejected before Jolt reads it
+struct CharacterStateHeader
+{
+	uint32 magic;
+	uint32 version;
+	uint32 size;  // Size of the Jolt state following the header
+	uint32 hash;  // FNV-1a hash of the Jolt state
+};
+
+static constexpr uint32 cCharacterStateMagic = 0x5343564a; // "JVCS"
+static constexpr uint32 cCharacterStateVersion = 1;
+
+static uint32 HashCharacterState(const unsigned char* data, size_t size)
+{
+	uint32 hash = 2166136261u;
+	for (size_t i = 0; i < size; i++)
+	{
+		hash = (hash ^ data[i]) * 16777619u;
+	}
+	return hash;
+}
+
+// Checks the header and hash of a saved state, returns false if it can't be restored
+static bool ValidateCharacterState(const unsigned char* data, int size)
+{
+	if (data == nullptr || size < static_cast<int>(sizeof(CharacterStateHeader)))
+	{
+		return false;
+	}
+
+	CharacterStateHeader header;
+	memcpy(&header, data, sizeof(header));
+	const unsigned char* state = data + sizeof(header);
+	size_t stateSize = static_cast<size_t>(size) - sizeof(header);
+
+	return header.magic == cCharacterStateMagic
+		&& header.version == cCharacterStateVersion
+		&& header.size == stateSize
+		&& header.hash == HashCharacterState(state, stateSize);
+}
+
+int JoltCharacterVirtualSaveState(const JoltCharacterVirtual character, unsigned char* outData, int maxSize)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+
+	try
+	{
+		StateRecorderImpl recorder;
+		cv->SaveState(recorder);
+
+		std::string data = recorder.GetData();
+		const unsigned char* state = reinterpret_cast<const unsigned char*>(data.data());
+
+		CharacterStateHeader header { cCharacterStateMagic, cCharacterStateVersion,
+									  static_cast<uint32>(data.size()), HashCharacterState(state, data.size()) };
+		int size = static_cast<int>(sizeof(header) + data.size());
+		if (size <= maxSize)
+		{
+			memcpy(outData, &header, sizeof(header));
+			memcpy(outData + sizeof(header), state, data.size());
+		}
+		return size;
+	}
+	catch (...)
+	{
+		return 0;
+	}
+}
+
+int JoltCharacterVirtualRestoreState(JoltCharacterVirtual character, const unsigned char* data, int size)
+{
+	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+
+	if (!ValidateCharacterState(data, size))
+	{
+		return 0;
+	}
+
+	try
+	{
+		// Keep the current state to fall back to if the saved one fails to read
+		StateRecorderImpl previous;
+		cv->SaveState(previous);
+
+		StateRecorderImpl recorder;
+		recorder.WriteBytes(data + sizeof(CharacterStateHeader), size - sizeof(CharacterStateHeader));
+		recorder.Rewind();
+		cv->RestoreState(recorder);
+
+		if (recorder.IsFailed())
+		{
+			previous.Rewind();
+			cv->RestoreState(previous);
+			return 0;
+		}
+		return 1;
+	}
+	catch (...)
+	{
+		return 0;
+	}
+}
+
+JoltCharacterVirtual JoltCharacterVirtualRecreate(JoltCharacterVirtual character,
+											   JoltPhysicsSystem system,
+											   const JoltCharacterVirtualSettings* goSettings)
+{
+	CharacterVirtual* old = static_cast<CharacterVirtual*>(character);
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	CharacterVirtualSettings settings;
+	// SetShape changes the shape after construction, so rebuild from the live shape rather than the creation settings
+	ToCharacterVirtualSettings(goSettings, old->GetShape(), settings);
+
+	// Create the replacement at the same transform, then carry over the dynamic state
+	auto replacement = std::make_unique<CharacterVirtual>(&settings, old->GetPosition(), old->GetRotation(),
+														  old->GetUserData(), ps);
+	replacement->SetLinearVelocity(old->GetLinearVelocity());
+	replacement->SetListener(old->GetListener());
+
+	// Jolt creates a new inner body, copy over what callers may have changed on the old one
+	BodyID oldInner = old->GetInnerBodyID();
+	BodyID newInner = replacement->GetInnerBodyID();
+	if (!oldInner.IsInvalid() && !newInner.IsInvalid())
+	{
+		BodyInterface& bi = ps->GetBodyInterface();
+		bi.SetUserData(newInner, bi.GetUserData(oldInner));
+		bi.SetObjectLayer(newInner, bi.GetObjectLayer(oldInner));
+	}
+
+	delete old;
+
+	// Recompute contacts so the ground state is valid before the next update
+	BroadPhaseLayerFilterAdapter broad_phase_filter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter object_layer_filter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+	replacement->RefreshContacts(
+		broad_phase_filter,
+		object_layer_filter,
+		{}, // Empty BodyFilter (collides with all bodies)
+		{}, // Empty ShapeFilter (collides with all shapes)
+		*gTempAllocator.get()
+	);
+
+	return static_cast<JoltCharacterVirtual>(replacement.release());
+}
+
+void JoltCharacterVirtualSetMaxNumHits(JoltCharacterVirtual character, unsigned int maxNumHits)
+{
+	CharacterVirtual* cv = static_cast<CharacterVirtual*>(character);
+	cv->SetMaxNumHits(maxNumHits);
+}
+
+unsigned int JoltCharacterVirtualGetMaxNumHits(const JoltCharacterVirtual character)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	return cv->GetMaxNumHits();
+}
+
+int JoltCharacterVirtualGetMaxHitsExceeded(const JoltCharacterVirtual character)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	return cv->GetMaxHitsExceeded() ? 1 : 0;
+}
+
+float JoltCharacterVirtualGetCharacterPadding(const JoltCharacterVirtual character)
+{
+	const CharacterVirtual* cv = static_cast<const CharacterVirtual*>(character);
+	return cv->GetCharacterPadding();
 }
@@ -46,13 +46,31 @@ typedef struct {
     float distance;                                     // Distance to contact (<= 0 means actual contact, > 0 means predictive)
     float fraction;                                     // Fraction along the path where this contact takes place
     JoltBodyID bodyB;                                   // ID of body we're colliding with
+    unsigned long long contactID;                       // Body and sub-shape ID of B, stable while the contact persists
     unsigned long long userData;                        // User data of B
+    int materialIndex;                                  // Material index of the contacted triangle (see JoltCreateMeshWithMaterials)
+    float impulseX, impulseY, impulseZ;                 // Total impulse the character applied to B during the last update
     int isSensorB;                                      // If B is a sensor (bool as int)
     int hadCollision;                                   // If the character actually collided (bool as int)
     int wasDiscarded;                                   // If contact was discarded (bool as int)
     int canPushCharacter;                               // When true, velocity can push character (bool as int)
 } JoltCharacterContact;
 
+// Stair walk of an extended update
+typedef struct {
+    int attempted;      // If the character was blocked while walking and tried to step up (bool as int)
+    int succeeded;      // If the character stepped up (bool as int)
+    float stepHeight;   // Height the step up raised the character along its up vector, 0 if it didn't step up
+} JoltStairWalkInfo;
+
+// Outcome of an extended update
+typedef struct {
+    float movedX, movedY, movedZ;       // Actual displacement of the character during the update
+    int hitWall;                        // If the character collided with a surface too steep to walk on (bool as int)
+    JoltGroundState groundState;        // Ground state after the update
+    JoltStairWalkInfo stairWalk;        // Stair walk of the update
+} JoltCharacterUpdateResult;
+
 // Character virtual settings structure
 typedef struct {
     JoltShape shape;
@@ -72,6 +90,8 @@ typedef struct {
     float hitReductionCosMaxAngle;
     float penetrationRecoverySpeed;
     int enhancedInternalEdgeRemoval;  // bool as int (0 or 1)
+    int createInnerBody;              // bool as int, creates a kinematic body that follows the character
+    unsigned short innerBodyLayer;    // Object layer of the inner body
 } JoltCharacterVirtualSettings;
 
 // Create a new virtual character with settings at initial position (x, y, z)
@@ -89,12 +109,26 @@ void JoltCharacterVirtualUpdate(JoltCharacterVirtual character,
                                 float deltaTime,
                                 float gravityX, float gravityY, float gravityZ);
 
+// Predict where JoltCharacterVirtualUpdate would move the character with the given velocity,
+// without changing the character or pushing bodies
+// The move is simulated on a temporary character built from settings and the character's current shape and state
+// outX/Y/Z: the position the character would end up at
+void JoltCharacterVirtualSimulateMove(JoltCharacterVirtual character,
+                                      JoltPhysicsSystem system,
+                                      const JoltCharacterVirtualSettings* settings,
+                                      float deltaTime,
+                                      float velocityX, float velocityY, float velocityZ,
+                                      float gravityX, float gravityY, float gravityZ,
+                                      float* outX, float* outY, float* outZ);
+
 // Update virtual character with extended update (combines Update, StickToFloor, WalkStairs)
 // gravityX/Y/Z: gravity vector applied when character stands on another object
+// outResult: receives the displacement, wall hits, ground state and stair walk of the update (may be NULL)
 void JoltCharacterVirtualExtendedUpdate(JoltCharacterVirtual character,
                                         JoltPhysicsSystem system,
                                         float deltaTime,
-                                        float gravityX, float gravityY, float gravityZ);
+                                        float gravityX, float gravityY, float gravityZ,
+                                        JoltCharacterUpdateResult* outResult);
 
 // Set the linear velocity of a virtual character
 void JoltCharacterVirtualSetLinearVelocity(JoltCharacterVirtual character,
@@ -108,14 +142,35 @@ void JoltCharacterVirtualGetLinearVelocity(const JoltCharacterVirtual character,
 void JoltCharacterVirtualGetGroundVelocity(const JoltCharacterVirtual character,
                                            float* x, float* y, float* z);
 
+// Recalculate the ground velocity from the current velocity of the body the character stands on
+void JoltCharacterVirtualUpdateGroundVelocity(JoltCharacterVirtual character);
+
 // Set the position of a virtual character
 void JoltCharacterVirtualSetPosition(JoltCharacterVirtual character,
                                      float x, float y, float z);
 
+// Move a virtual character to a position and push it out of any geometry it overlaps
+// Returns 1 if the character ended up in a free spot, 0 if it couldn't be resolved
+// (the character is then moved back to where it was)
+int JoltCharacterVirtualSafeTeleport(JoltCharacterVirtual character,
+                                     JoltPhysicsSystem system,
+                                     float x, float y, float z);
+
 // Get the position of a virtual character
 void JoltCharacterVirtualGetPosition(const JoltCharacterVirtual character,
                                      float* x, float* y, float* z);
 
+// Get the center of mass position of a virtual character
+// (position plus the rotated shape offset, shape center of mass and character padding)
+void JoltCharacterVirtualGetCenterOfMassPosition(const JoltCharacterVirtual character,
+                                                 float* x, float* y, float* z);
+
+// Get the position and rotation of a virtual character as 16 floats in column-major order
+void JoltCharacterVirtualGetWorldTransform(const JoltCharacterVirtual character, float* outMatrix);
+
+// Get the transform of the character's shape (including shape offset) as 16 floats in column-major order
+void JoltCharacterVirtualGetCenterOfMassTransform(const JoltCharacterVirtual character, float* outMatrix);
+
 // Get the ground state of a virtual character
 JoltGroundState JoltCharacterVirtualGetGroundState(const JoltCharacterVirtual character);
 
@@ -134,6 +189,10 @@ void JoltCharacterVirtualSetShape(JoltCharacterVirtual character,
 // Get the shape of a virtual character
 JoltShape JoltCharacterVirtualGetShape(const JoltCharacterVirtual character);
 
+// Get the ID of the inner rigid body of a virtual character
+// Returns: a new body ID (caller must destroy), or NULL if the character has no inner body
+JoltBodyID JoltCharacterVirtualGetInnerBodyID(const JoltCharacterVirtual character);
+
 // Get the normal of the ground surface the character is standing on
 void JoltCharacterVirtualGetGroundNormal(const JoltCharacterVirtual character,
                                          float* x, float* y, float* z);
@@ -142,6 +201,9 @@ void JoltCharacterVirtualGetGroundNormal(const JoltCharacterVirtual character,
 void JoltCharacterVirtualGetGroundPosition(const JoltCharacterVirtual character,
                                            float* x, float* y, float* z);
 
+// Get the number of active contacts for the character (without copying them)
+int JoltCharacterVirtualGetNumActiveContacts(const JoltCharacterVirtual character);
+
 // Get the active contacts for the character
 // contacts: pointer to array to store contacts (must be pre-allocated)
 // maxContacts: maximum number of contacts to return
@@ -150,6 +212,47 @@ int JoltCharacterVirtualGetActiveContacts(const JoltCharacterVirtual character,
                                           JoltCharacterContact* contacts,
                                           int maxContacts);
 
+// Get a single active contact of the character without allocating
+// index: index of the contact, from 0 to JoltCharacterVirtualGetNumActiveContacts - 1
+// outContact: receives the contact, its bodyB is bodyBStorage (or NULL if the contact has no body)
+// bodyBStorage: body ID the contact's body ID is copied into, e.g. from JoltCreateBodyID (caller owns it)
+// Returns 1 on success, 0 if index is out of range
+int JoltCharacterVirtualGetActiveContact(const JoltCharacterVirtual character,
+                                         int index,
+                                         JoltCharacterContact* outContact,
+                                         JoltBodyID bodyBStorage);
+
+// Save the state of a virtual character (position, rotation, velocity, ground state and contacts)
+// outData: buffer of maxSize bytes (may be NULL if maxSize is 0)
+// Returns: the size of the state in bytes, the state is only written if it fits in maxSize
+int JoltCharacterVirtualSaveState(const JoltCharacterVirtual character, unsigned char* outData, int maxSize);
+
+// Restore the state of a virtual character saved with JoltCharacterVirtualSaveState
+// The size, header and hash of the data are checked before it is restored
+// Returns 1 on success, 0 if the data is not a valid character state (the character is left unchanged)
+int JoltCharacterVirtualRestoreState(JoltCharacterVirtual character, const unsigned char* data, int size);
+
+// Recreate a virtual character with new settings, keeping position, rotation, velocity, user data and current shape
+// Used for settings that Jolt only reads at construction time. The old character is destroyed.
+// The inner body is replaced (its ID changes) but keeps its user data and object layer.
+// Contacts and ground state are refreshed before returning.
+// Returns: the new character
+JoltCharacterVirtual JoltCharacterVirtualRecreate(JoltCharacterVirtual character,
+                                                JoltPhysicsSystem system,
+                                                const JoltCharacterVirtualSettings* settings);
+
+// Set the maximum number of hits collected during collision detection
+void JoltCharacterVirtualSetMaxNumHits(JoltCharacterVirtual character, unsigned int maxNumHits);
+
+// Get the maximum number of hits collected during collision detection
+unsigned int JoltCharacterVirtualGetMaxNumHits(const JoltCharacterVirtual character);
+
+// Returns 1 if the last update found more than the maximum number of hits and had to discard contacts
+int JoltCharacterVirtualGetMaxHitsExceeded(const JoltCharacterVirtual character);
+
+// Get how far the character tries to stay away from geometry
+float JoltCharacterVirtualGetCharacterPadding(const JoltCharacterVirtual character);
+
 #ifdef __cplusplus
 }
 #endif
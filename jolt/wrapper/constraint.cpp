@@ -0,0 +1,164 @@
+/*
+ * Jolt Physics C Wrapper - Constraints Implementation
+ */
+
+#include "constraint.h"
+#include "physics.h"
+#include <Jolt/Jolt.h>
+#include <Jolt/Physics/PhysicsSystem.h>
+#include <Jolt/Physics/Body/BodyInterface.h>
+#include <Jolt/Physics/Constraints/SwingTwistConstraint.h>
+#include <Jolt/Physics/Constraints/SixDOFConstraint.h>
+
+using namespace JPH;
+
+// Creates a constraint from settings, adds it to the system and keeps a reference for the Go layer
+static JoltConstraint AddConstraint(PhysicsSystem* ps, const TwoBodyConstraintSettings& settings,
+									const BodyID& bodyA, const BodyID& bodyB)
+{
+	TwoBodyConstraint* constraint = ps->GetBodyInterface().CreateConstraint(&settings, bodyA, bodyB);
+	if (!constraint)
+	{
+		return nullptr;
+	}
+
+	// Constraints are ref-counted, AddRef to keep it alive until JoltDestroyConstraint
+	constraint->AddRef();
+	ps->AddConstraint(constraint);
+
+	return static_cast<JoltConstraint>(static_cast<Constraint*>(constraint));
+}
+
+JoltConstraint JoltCreateSwingTwistConstraint(JoltPhysicsSystem system,
+											  JoltBodyID bodyA, JoltBodyID bodyB,
+											  float pivotX, float pivotY, float pivotZ,
+											  float twistAxisX, float twistAxisY, float twistAxisZ,
+											  float halfConeAngle,
+											  float minTwist, float maxTwist)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID* a = static_cast<const BodyID*>(bodyA);
+	const BodyID* b = static_cast<const BodyID*>(bodyB);
+
+	RVec3 pivot(pivotX, pivotY, pivotZ);
+	Vec3 twistAxis = Vec3(twistAxisX, twistAxisY, twistAxisZ).NormalizedOr(Vec3::sAxisX());
+	Vec3 planeAxis = twistAxis.GetNormalizedPerpendicular();
+
+	SwingTwistConstraintSettings settings;
+	settings.mSpace = EConstraintSpace::WorldSpace;
+	settings.mPosition1 = settings.mPosition2 = pivot;
+	settings.mTwistAxis1 = settings.mTwistAxis2 = twistAxis;
+	settings.mPlaneAxis1 = settings.mPlaneAxis2 = planeAxis;
+	settings.mNormalHalfConeAngle = halfConeAngle;
+	settings.mPlaneHalfConeAngle = halfConeAngle;
+	settings.mTwistMinAngle = minTwist;
+	settings.mTwistMaxAngle = maxTwist;
+
+	return AddConstraint(ps, settings, *a, *b);
+}
+
+JoltConstraint JoltCreateSixDOFConstraint(JoltPhysicsSystem system,
+										  JoltBodyID bodyA, JoltBodyID bodyB,
+										  float posX, float posY, float posZ,
+										  const JoltSixDOFConfig* config)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID* a = static_cast<const BodyID*>(bodyA);
+	const BodyID* b = static_cast<const BodyID*>(bodyB);
+
+	SixDOFConstraintSettings settings;
+	settings.mSpace = EConstraintSpace::WorldSpace;
+	settings.mPosition1 = settings.mPosition2 = RVec3(posX, posY, posZ);
+
+	for (int i = 0; i < JoltSixDOFAxisNum; ++i)
+	{
+		settings.mLimitMin[i] = config->limitMin[i];
+		settings.mLimitMax[i] = config->limitMax[i];
+
+		// Translation motors are limited by force, rotation motors by torque
+		if (i < JoltSixDOFAxisRotationX)
+			settings.mMotorSettings[i].SetForceLimit(config->motorMaxForce[i]);
+		else
+			settings.mMotorSettings[i].SetTorqueLimit(config->motorMaxForce[i]);
+	}
+
+	JoltConstraint handle = AddConstraint(ps, settings, *a, *b);
+	if (!handle)
+	{
+		return nullptr;
+	}
+
+	// Motor state and targets live on the constraint, not the settings
+	SixDOFConstraint* constraint = static_cast<SixDOFConstraint*>(static_cast<Constraint*>(handle));
+	constraint->SetTargetVelocityCS(Vec3(config->motorTargetVelocity[JoltSixDOFAxisTranslationX],
+										 config->motorTargetVelocity[JoltSixDOFAxisTranslationY],
+										 config->motorTargetVelocity[JoltSixDOFAxisTranslationZ]));
+	constraint->SetTargetAngularVelocityCS(Vec3(config->motorTargetVelocity[JoltSixDOFAxisRotationX],
+												config->motorTargetVelocity[JoltSixDOFAxisRotationY],
+												config->motorTargetVelocity[JoltSixDOFAxisRotationZ]));
+	for (int i = 0; i < JoltSixDOFAxisNum; ++i)
+	{
+		if (config->motorEnabled[i] != 0)
+		{
+			constraint->SetMotorState(static_cast<SixDOFConstraintSettings::EAxis>(i), EMotorState::Velocity);
+		}
+	}
+
+	return handle;
+}
+
+void JoltConstraintSetEnabled(JoltPhysicsSystem system, JoltConstraint constraint, int enabled)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	TwoBodyConstraint* c = static_cast<TwoBodyConstraint*>(static_cast<Constraint*>(constraint));
+
+	c->SetEnabled(enabled != 0);
+
+	// Sleeping bodies won't notice the constraint until they are woken up
+	if (enabled != 0)
+	{
+		ps->GetBodyInterface().ActivateConstraint(c);
+	}
+}
+
+int JoltConstraintIsEnabled(const JoltConstraint constraint)
+{
+	const Constraint* c = static_cast<const Constraint*>(constraint);
+	return c->GetEnabled() ? 1 : 0;
+}
+
+void JoltConstraintGetBodies(const JoltConstraint constraint, JoltBodyID* outBodyA, JoltBodyID* outBodyB)
+{
+	const TwoBodyConstraint* c = static_cast<const TwoBodyConstraint*>(static_cast<const Constraint*>(constraint));
+
+	*outBodyA = static_cast<JoltBodyID>(new BodyID(c->GetBody1()->GetID()));
+	*outBodyB = static_cast<JoltBodyID>(new BodyID(c->GetBody2()->GetID()));
+}
+
+float JoltConstraintGetTotalLambdaPosition(const JoltConstraint constraint)
+{
+	const Constraint* c = static_cast<const Constraint*>(constraint);
+
+	switch (c->GetSubType())
+	{
+	case EConstraintSubType::SwingTwist:
+		return static_cast<const SwingTwistConstraint*>(c)->GetTotalLambdaPosition().Length();
+	case EConstraintSubType::SixDOF:
+		return static_cast<const SixDOFConstraint*>(c)->GetTotalLambdaPosition().Length();
+	default:
+		return 0.0f;
+	}
+}
+
+void JoltDestroyConstraint(JoltPhysicsSystem system, JoltConstraint constraint)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	Constraint* c = static_cast<Constraint*>(constraint);
+
+	ps->RemoveConstraint(c);
+	c->Release();
+}
@@ -0,0 +1,82 @@
+/*
+ * Jolt Physics C Wrapper - Constraints
+ *
+ * Handles creation and destruction of constraints (joints) between bodies.
+ */
+
+#ifndef JOLT_WRAPPER_CONSTRAINT_H
+#define JOLT_WRAPPER_CONSTRAINT_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque pointer types
+typedef void* JoltPhysicsSystem;
+typedef void* JoltBodyID;
+typedef void* JoltConstraint;
+
+// Six-DOF axis enum (matches Jolt's SixDOFConstraintSettings::EAxis)
+typedef enum {
+    JoltSixDOFAxisTranslationX = 0,
+    JoltSixDOFAxisTranslationY = 1,
+    JoltSixDOFAxisTranslationZ = 2,
+    JoltSixDOFAxisRotationX = 3,
+    JoltSixDOFAxisRotationY = 4,
+    JoltSixDOFAxisRotationZ = 5,
+    JoltSixDOFAxisNum = 6
+} JoltSixDOFAxis;
+
+// Six-DOF constraint configuration, arrays are indexed by JoltSixDOFAxis
+typedef struct {
+    float limitMin[JoltSixDOFAxisNum];            // Lower limit (min >= max locks the axis)
+    float limitMax[JoltSixDOFAxisNum];            // Upper limit
+    int motorEnabled[JoltSixDOFAxisNum];          // Enable a velocity motor on the axis (bool as int)
+    float motorTargetVelocity[JoltSixDOFAxisNum]; // Motor target velocity (m/s or rad/s)
+    float motorMaxForce[JoltSixDOFAxisNum];       // Max force (N) or torque (N m) the motor can apply
+} JoltSixDOFConfig;
+
+// Create a swing-twist (cone-twist) constraint between two bodies and add it to the physics system
+// pivot: shared attachment point in world space
+// twistAxis: twist axis in world space (the cone is centered around this axis)
+// halfConeAngle: half angle of the swing cone in radians
+// minTwist/maxTwist: twist limits around the twist axis in radians
+// Returns NULL if either body doesn't exist
+JoltConstraint JoltCreateSwingTwistConstraint(JoltPhysicsSystem system,
+                                              JoltBodyID bodyA, JoltBodyID bodyB,
+                                              float pivotX, float pivotY, float pivotZ,
+                                              float twistAxisX, float twistAxisY, float twistAxisZ,
+                                              float halfConeAngle,
+                                              float minTwist, float maxTwist);
+
+// Create a six-DOF constraint between two bodies and add it to the physics system
+// position: shared attachment point in world space, constraint axes are aligned with the world axes
+// Returns NULL if either body doesn't exist
+JoltConstraint JoltCreateSixDOFConstraint(JoltPhysicsSystem system,
+                                          JoltBodyID bodyA, JoltBodyID bodyB,
+                                          float posX, float posY, float posZ,
+                                          const JoltSixDOFConfig* config);
+
+// Enable or disable a constraint, enabling also wakes up the connected bodies
+void JoltConstraintSetEnabled(JoltPhysicsSystem system, JoltConstraint constraint, int enabled);
+
+// Check if a constraint is enabled
+// Returns 1 if enabled, 0 if disabled
+int JoltConstraintIsEnabled(const JoltConstraint constraint);
+
+// Get the two bodies connected by a constraint
+// outBodyA/outBodyB: receive new body IDs (free with JoltDestroyBodyID)
+void JoltConstraintGetBodies(const JoltConstraint constraint, JoltBodyID* outBodyA, JoltBodyID* outBodyB);
+
+// Get the magnitude of the positional impulse (N s) the constraint applied during the last step
+// Returns 0 for constraint types that don't report their impulse
+float JoltConstraintGetTotalLambdaPosition(const JoltConstraint constraint);
+
+// Remove a constraint from the physics system and release it
+void JoltDestroyConstraint(JoltPhysicsSystem system, JoltConstraint constraint);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // JOLT_WRAPPER_CONSTRAINT_H
@@ -0,0 +1,263 @@
+/*
+ * Jolt Physics C Wrapper - Contact Listener Implementation
+ */
+
+#include "contact.h"
+#include "physics.h"
+#include <Jolt/Jolt.h>
+#include <Jolt/Physics/PhysicsSystem.h>
+#include <Jolt/Physics/Collision/ContactListener.h>
+#include <Jolt/Physics/Body/Body.h>
+#include <Jolt/Physics/Collision/EstimateCollisionResponse.h>
+#include <Jolt/Physics/Collision/Shape/SubShapeIDPair.h>
+#include <mutex>
+#include <vector>
+
+using namespace JPH;
+
+// The contact being reported to Go on this thread, for JoltContactEstimateImpactImpulse
+struct ReportedContact
+{
+	BodyID bodyA;
+	BodyID bodyB;
+	float impactImpulse = 0.0f;
+	bool active = false;
+};
+
+static thread_local ReportedContact tReportedContact;
+
+// Estimates the total impulse the solver will apply along the normal to resolve a contact
+static float EstimateImpactImpulse(const Body& inBody1, const Body& inBody2, const ContactManifold& inManifold, const ContactSettings& inSettings)
+{
+	CollisionEstimationResult result;
+	EstimateCollisionResponse(inBody1, inBody2, inManifold, result, inSettings.mCombinedFriction, inSettings.mCombinedRestitution);
+
+	// Sum the normal impulses of all contact points
+	float impulse = 0.0f;
+	for (const CollisionEstimationResult::Impulse& point : result.mImpulses)
+	{
+		impulse += point.mContactImpulse;
+	}
+	return impulse;
+}
+
+// Converts a Jolt contact to a JoltContactInfo
+// bodyA/bodyB must outlive the info since it points to them
+static void ToJoltContactInfo(BodyID& bodyA, BodyID& bodyB, const ContactManifold& manifold, JoltContactInfo& info)
+{
+	info.bodyA = static_cast<JoltBodyID>(&bodyA);
+	info.bodyB = static_cast<JoltBodyID>(&bodyB);
+	info.subShapeIDA = manifold.mSubShapeID1.GetValue();
+	info.subShapeIDB = manifold.mSubShapeID2.GetValue();
+	info.contactID = SubShapeIDPair(bodyA, manifold.mSubShapeID1, bodyB, manifold.mSubShapeID2).GetHash();
+
+	info.normalX = manifold.mWorldSpaceNormal.GetX();
+	info.normalY = manifold.mWorldSpaceNormal.GetY();
+	info.normalZ = manifold.mWorldSpaceNormal.GetZ();
+	info.penetrationDepth = manifold.mPenetrationDepth;
+
+	info.numPoints = static_cast<int>(manifold.mRelativeContactPointsOn1.size());
+	for (int i = 0; i < info.numPoints; i++)
+	{
+		RVec3 pointOnA = manifold.GetWorldSpaceContactPointOn1(i);
+		info.pointsOnA[i * 3] = static_cast<float>(pointOnA.GetX());
+		info.pointsOnA[i * 3 + 1] = static_cast<float>(pointOnA.GetY());
+		info.pointsOnA[i * 3 + 2] = static_cast<float>(pointOnA.GetZ());
+
+		RVec3 pointOnB = manifold.GetWorldSpaceContactPointOn2(i);
+		info.pointsOnB[i * 3] = static_cast<float>(pointOnB.GetX());
+		info.pointsOnB[i * 3 + 1] = static_cast<float>(pointOnB.GetY());
+		info.pointsOnB[i * 3 + 2] = static_cast<float>(pointOnB.GetZ());
+	}
+}
+
+// Copies the modifiable contact settings to a JoltContactInfo
+static void ToJoltContactSettings(const ContactSettings& settings, JoltContactInfo& info)
+{
+	info.isSensor = settings.mIsSensor ? 1 : 0;
+	info.combinedFriction = settings.mCombinedFriction;
+	info.combinedRestitution = settings.mCombinedRestitution;
+}
+
+// Copies the contact settings back after Go had the chance to modify them
+static void FromJoltContactSettings(const JoltContactInfo& info, ContactSettings& settings)
+{
+	settings.mIsSensor = info.isSensor != 0;
+	settings.mCombinedFriction = info.combinedFriction;
+	settings.mCombinedRestitution = info.combinedRestitution;
+}
+
+// Contact listener that forwards events to a Go listener
+// Jolt calls it from its worker threads, possibly concurrently
+class GoContactListener : public ContactListener
+{
+public:
+	GoContactListener(uintptr_t listener, int callbacks) : m_listener(listener), m_callbacks(callbacks) {}
+
+	virtual ValidateResult OnContactValidate(const Body& inBody1, const Body& inBody2, RVec3Arg inBaseOffset, const CollideShapeResult& inCollisionResult) override
+	{
+		if ((m_callbacks & JoltContactCallbackValidate) == 0) return ValidateResult::AcceptAllContactsForThisBodyPair;
+
+		BodyID bodyA = inBody1.GetID();
+		BodyID bodyB = inBody2.GetID();
+		int result = goJoltContactValidate(m_listener, static_cast<JoltBodyID>(&bodyA), static_cast<JoltBodyID>(&bodyB));
+		return static_cast<ValidateResult>(result);
+	}
+
+	virtual void OnContactAdded(const Body& inBody1, const Body& inBody2, const ContactManifold& inManifold, ContactSettings& ioSettings) override
+	{
+		if ((m_callbacks & JoltContactCallbackRecord) != 0) Record(inBody1, inBody2, inManifold, ioSettings);
+		if ((m_callbacks & JoltContactCallbackAdded) == 0) return;
+
+		BodyID bodyA = inBody1.GetID();
+		BodyID bodyB = inBody2.GetID();
+		JoltContactInfo info;
+		ToJoltContactInfo(bodyA, bodyB, inManifold, info);
+		ToJoltContactSettings(ioSettings, info);
+		info.impactImpulse = EstimateImpactImpulse(inBody1, inBody2, inManifold, ioSettings);
+		tReportedContact = { bodyA, bodyB, info.impactImpulse, true };
+		goJoltContactAdded(m_listener, &info);
+		tReportedContact.active = false;
+		FromJoltContactSettings(info, ioSettings);
+	}
+
+	virtual void OnContactPersisted(const Body& inBody1, const Body& inBody2, const ContactManifold& inManifold, ContactSettings& ioSettings) override
+	{
+		if ((m_callbacks & JoltContactCallbackRecord) != 0) Record(inBody1, inBody2, inManifold, ioSettings);
+		if ((m_callbacks & JoltContactCallbackPersisted) == 0) return;
+
+		BodyID bodyA = inBody1.GetID();
+		BodyID bodyB = inBody2.GetID();
+		JoltContactInfo info;
+		ToJoltContactInfo(bodyA, bodyB, inManifold, info);
+		ToJoltContactSettings(ioSettings, info);
+		info.impactImpulse = EstimateImpactImpulse(inBody1, inBody2, inManifold, ioSettings);
+		tReportedContact = { bodyA, bodyB, info.impactImpulse, true };
+		goJoltContactPersisted(m_listener, &info);
+		tReportedContact.active = false;
+		FromJoltContactSettings(info, ioSettings);
+	}
+
+	virtual void OnContactRemoved(const SubShapeIDPair& inSubShapePair) override
+	{
+		if ((m_callbacks & JoltContactCallbackRemoved) == 0) return;
+
+		BodyID bodyA = inSubShapePair.GetBody1ID();
+		BodyID bodyB = inSubShapePair.GetBody2ID();
+		goJoltContactRemoved(m_listener,
+							 static_cast<JoltBodyID>(&bodyA), static_cast<JoltBodyID>(&bodyB),
+							 inSubShapePair.GetSubShapeID1().GetValue(), inSubShapePair.GetSubShapeID2().GetValue());
+	}
+
+	// Forget the recorded contacts
+	void ClearRecorded()
+	{
+		std::lock_guard<std::mutex> lock(m_recordedMutex);
+		m_recorded.clear();
+	}
+
+	// Get the number of recorded contacts
+	int GetNumRecorded()
+	{
+		std::lock_guard<std::mutex> lock(m_recordedMutex);
+		return static_cast<int>(m_recorded.size());
+	}
+
+	// Copy the recorded contacts out, with new body ID copies for the caller
+	int GetRecorded(JoltContactInfo* contacts, int maxContacts)
+	{
+		std::lock_guard<std::mutex> lock(m_recordedMutex);
+
+		int numContacts = static_cast<int>(m_recorded.size());
+		int numToReturn = numContacts < maxContacts ? numContacts : maxContacts;
+		for (int i = 0; i < numToReturn; i++)
+		{
+			const RecordedContact& recorded = m_recorded[i];
+			contacts[i] = recorded.info;
+			contacts[i].bodyA = static_cast<JoltBodyID>(new BodyID(recorded.bodyA));
+			contacts[i].bodyB = static_cast<JoltBodyID>(new BodyID(recorded.bodyB));
+		}
+		return numToReturn;
+	}
+
+private:
+	// A contact kept for GetRecorded, the info points to nothing until it is copied out
+	struct RecordedContact
+	{
+		BodyID bodyA;
+		BodyID bodyB;
+		JoltContactInfo info;
+	};
+
+	void Record(const Body& inBody1, const Body& inBody2, const ContactManifold& inManifold, const ContactSettings& inSettings)
+	{
+		RecordedContact recorded;
+		recorded.bodyA = inBody1.GetID();
+		recorded.bodyB = inBody2.GetID();
+		ToJoltContactInfo(recorded.bodyA, recorded.bodyB, inManifold, recorded.info);
+		ToJoltContactSettings(inSettings, recorded.info);
+		recorded.info.impactImpulse = EstimateImpactImpulse(inBody1, inBody2, inManifold, inSettings);
+
+		// Called from several worker threads at once
+		std::lock_guard<std::mutex> lock(m_recordedMutex);
+		m_recorded.push_back(recorded);
+	}
+
+	uintptr_t m_listener;
+	int m_callbacks;
+
+	std::mutex m_recordedMutex;
+	std::vector<RecordedContact> m_recorded;
+};
+
+// Get the Go contact listener of a physics system, NULL if none is set
+static GoContactListener* GetRecordingListener(JoltPhysicsSystem system)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	return static_cast<GoContactListener*>(GetContactListener(wrapper));
+}
+
+void JoltPhysicsSystemSetContactListener(JoltPhysicsSystem system, uintptr_t listener, int callbacks)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	if (callbacks == 0)
+	{
+		SetContactListener(wrapper, nullptr);
+		return;
+	}
+
+	SetContactListener(wrapper, new GoContactListener(listener, callbacks));
+}
+
+void JoltPhysicsSystemClearRecordedContacts(JoltPhysicsSystem system)
+{
+	GoContactListener* listener = GetRecordingListener(system);
+	if (listener) listener->ClearRecorded();
+}
+
+int JoltPhysicsSystemGetNumRecordedContacts(JoltPhysicsSystem system)
+{
+	GoContactListener* listener = GetRecordingListener(system);
+	return listener ? listener->GetNumRecorded() : 0;
+}
+
+int JoltPhysicsSystemGetRecordedContacts(JoltPhysicsSystem system, JoltContactInfo* contacts, int maxContacts)
+{
+	GoContactListener* listener = GetRecordingListener(system);
+	return listener ? listener->GetRecorded(contacts, maxContacts) : 0;
+}
+
+float JoltContactEstimateImpactImpulse(JoltBodyID bodyA, JoltBodyID bodyB)
+{
+	const ReportedContact& reported = tReportedContact;
+	if (!reported.active)
+	{
+		return 0.0f;
+	}
+
+	const BodyID& a = *static_cast<const BodyID*>(bodyA);
+	const BodyID& b = *static_cast<const BodyID*>(bodyB);
+	bool matches = (a == reported.bodyA && b == reported.bodyB) || (a == reported.bodyB && b == reported.bodyA);
+	return matches ? reported.impactImpulse : 0.0f;
+}
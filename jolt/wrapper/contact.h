@@ -0,0 +1,97 @@
+/*
+ * Jolt Physics C Wrapper - Contact Listener
+ *
+ * Forwards contact events between bodies to Go.
+ */
+
+#ifndef JOLT_WRAPPER_CONTACT_H
+#define JOLT_WRAPPER_CONTACT_H
+
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque pointer types
+typedef void* JoltPhysicsSystem;
+typedef void* JoltBodyID;
+
+// Maximum number of points in a contact manifold (matches Jolt's ContactPoints)
+#define JOLT_MAX_CONTACT_POINTS 64
+
+// Contact between two bodies, passed to the Go callbacks
+// Body IDs point to temporary copies that are only valid during the callback
+typedef struct {
+    JoltBodyID bodyA;
+    JoltBodyID bodyB;
+    unsigned int subShapeIDA;
+    unsigned int subShapeIDB;
+    unsigned long long contactID;     // Hash of the body and sub-shape IDs, stable while the contact persists
+    float normalX, normalY, normalZ;  // World space, direction to move body B out of collision
+    float penetrationDepth;
+    int numPoints;
+    float pointsOnA[JOLT_MAX_CONTACT_POINTS * 3];  // World space contact points on body A (xyz per point)
+    float pointsOnB[JOLT_MAX_CONTACT_POINTS * 3];  // World space contact points on body B (xyz per point)
+
+    // Contact settings, can be modified by the added/persisted callbacks
+    int isSensor;               // Non-zero to report the contact without a collision response
+    float combinedFriction;     // Friction of the body pair
+    float combinedRestitution;  // Restitution (bounciness) of the body pair
+
+    float impactImpulse;  // Estimated total normal impulse to resolve the contact, using the settings before the callback
+} JoltContactInfo;
+
+// Result of the validate callback (matches Jolt's ValidateResult)
+typedef enum {
+    JoltValidateResultAcceptAllContacts = 0,  // Accept this and any further contacts for this body pair
+    JoltValidateResultAcceptContact = 1,      // Accept this contact only
+    JoltValidateResultRejectContact = 2,      // Reject this contact only
+    JoltValidateResultRejectAllContacts = 3   // Reject this and any further contacts for this body pair
+} JoltValidateResult;
+
+// Which callbacks the Go listener implements (bit flags)
+typedef enum {
+    JoltContactCallbackAdded = 1,
+    JoltContactCallbackPersisted = 2,
+    JoltContactCallbackRemoved = 4,
+    JoltContactCallbackValidate = 8,
+    JoltContactCallbackRecord = 16    // Not a Go callback: record added and persisted contacts for JoltPhysicsSystemGetRecordedContacts
+} JoltContactCallback;
+
+// Set the contact listener of a physics system
+// listener: cgo.Handle identifying the Go listener, passed back to the callbacks
+// callbacks: combination of JoltContactCallback flags, only these callbacks are forwarded to Go
+// Pass callbacks = 0 to remove the listener
+void JoltPhysicsSystemSetContactListener(JoltPhysicsSystem system, uintptr_t listener, int callbacks);
+
+// Forget the contacts recorded so far (requires JoltContactCallbackRecord)
+void JoltPhysicsSystemClearRecordedContacts(JoltPhysicsSystem system);
+
+// Get the number of contacts recorded since the last clear (requires JoltContactCallbackRecord)
+int JoltPhysicsSystemGetNumRecordedContacts(JoltPhysicsSystem system);
+
+// Get the contacts recorded since the last clear (requires JoltContactCallbackRecord)
+// contacts: pointer to array to store contacts (must be pre-allocated)
+// The body IDs of the returned contacts are new copies (caller must destroy)
+// Returns: actual number of contacts returned
+int JoltPhysicsSystemGetRecordedContacts(JoltPhysicsSystem system, JoltContactInfo* contacts, int maxContacts);
+
+// Get the estimated impact impulse of the contact between bodyA and bodyB (in either order)
+// that is being reported to an added/persisted callback on the calling thread
+// Returns 0 outside of a callback or if the bodies don't match the reported contact
+float JoltContactEstimateImpactImpulse(JoltBodyID bodyA, JoltBodyID bodyB);
+
+#ifdef __cplusplus
+}
+
+// Implemented in Go (contact.go): dispatch contact events to the Go listener
+extern "C" int goJoltContactValidate(uintptr_t listener, JoltBodyID bodyA, JoltBodyID bodyB);
+extern "C" void goJoltContactAdded(uintptr_t listener, JoltContactInfo* info);
+extern "C" void goJoltContactPersisted(uintptr_t listener, JoltContactInfo* info);
+extern "C" void goJoltContactRemoved(uintptr_t listener, JoltBodyID bodyA, JoltBodyID bodyB,
+                                     unsigned int subShapeIDA, unsigned int subShapeIDB);
+
+#endif
+
+#endif // JOLT_WRAPPER_CONTACT_H
@@ -9,13 +9,13 @@
 #include <Jolt/Core/TempAllocator.h>
 #include <Jolt/Core/JobSystemThreadPool.h>
 #include <Jolt/Physics/PhysicsSettings.h>
-#include <iostream>
+#include <cstdio>
 #include <memory>
 #include <cstdarg>
 
 using namespace JPH;
 
-// Trace callback for Jolt error messages (helps with debugging)
+// Trace callback for Jolt error messages, forwarded to the Go logger
 static void TraceImpl(const char *inFMT, ...)
 {
 	va_list list;
@@ -23,15 +23,19 @@ static void TraceImpl(const char *inFMT, ...)
 	char buffer[1024];
 	vsnprintf(buffer, sizeof(buffer), inFMT, list);
 	va_end(list);
-	std::cout << buffer << std::endl;
+	goJoltTrace(buffer);
 }
 
 #ifdef JPH_ENABLE_ASSERTS
+// Assert callback, forwarded to the Go logger
+// Returns false so execution continues instead of breaking into the debugger / aborting
 static bool AssertFailedImpl(const char *inExpression, const char *inMessage, const char *inFile, uint inLine)
 {
-	std::cout << inFile << ":" << inLine << ": (" << inExpression << ") "
-			  << (inMessage != nullptr ? inMessage : "") << std::endl;
-	return true;
+	char buffer[1024];
+	snprintf(buffer, sizeof(buffer), "%s:%u: assertion failed (%s) %s",
+			 inFile, inLine, inExpression, inMessage != nullptr ? inMessage : "");
+	goJoltTrace(buffer);
+	return false;
 };
 #endif
 
@@ -43,6 +47,12 @@ static std::unique_ptr<Factory> gFactory;
 
 int JoltInit()
 {
+	// Already initialized, the global resources are shared by all physics systems
+	if (gFactory)
+	{
+		return 1;
+	}
+
 	Trace = TraceImpl;
 	JPH_IF_ENABLE_ASSERTS(AssertFailed = AssertFailedImpl;)
 
@@ -64,3 +74,11 @@ void JoltShutdown()
 	gFactory.reset();
 	Factory::sInstance = nullptr;
 }
+
+#define JOLT_STRINGIFY_IMPL(x) #x
+#define JOLT_STRINGIFY(x) JOLT_STRINGIFY_IMPL(x)
+
+const char* JoltGetVersion()
+{
+	return JOLT_STRINGIFY(JPH_VERSION_MAJOR) "." JOLT_STRINGIFY(JPH_VERSION_MINOR) "." JOLT_STRINGIFY(JPH_VERSION_PATCH);
+}
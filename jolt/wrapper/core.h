@@ -11,13 +11,16 @@
 extern "C" {
 #endif
 
-// Initialize Jolt Physics (call once at startup)
+// Initialize Jolt Physics (process-global, safe to call when already initialized)
 // Returns 1 on success, 0 on failure
 int JoltInit();
 
-// Shutdown Jolt Physics (call once at exit)
+// Shutdown Jolt Physics (safe to call when not initialized)
 void JoltShutdown();
 
+// Get the version of the linked Jolt library ("major.minor.patch")
+const char* JoltGetVersion();
+
 #ifdef __cplusplus
 }
 
@@ -32,6 +35,9 @@ namespace JPH {
 extern std::unique_ptr<JPH::TempAllocatorImpl> gTempAllocator;
 extern std::unique_ptr<JPH::JobSystemThreadPool> gJobSystem;
 
+// Implemented in Go (log.go): forwards trace and assert messages to jolt.Logger
+extern "C" void goJoltTrace(char* msg);
+
 #endif
 
 #endif // JOLT_WRAPPER_CORE_H
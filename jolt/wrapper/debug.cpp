@@ -0,0 +1,90 @@
+/*
+ * Jolt Physics C Wrapper - Debug Drawing Implementation
+ *
+ * Requires JPH_DEBUG_RENDERER (enabled for both Jolt and the wrapper).
+ */
+
+#include "debug.h"
+#include "physics.h"
+#include <Jolt/Jolt.h>
+#include <Jolt/Physics/PhysicsSystem.h>
+#include <Jolt/Physics/Body/BodyManager.h>
+#include <Jolt/Renderer/DebugRendererSimple.h>
+#include <vector>
+#include <algorithm>
+
+using namespace JPH;
+
+// Debug renderer that records every primitive as line segments
+class LineCollectorRenderer final : public DebugRendererSimple
+{
+public:
+	virtual void DrawLine(RVec3Arg inFrom, RVec3Arg inTo, ColorArg inColor) override
+	{
+		JoltDebugLine line;
+		line.fromX = static_cast<float>(inFrom.GetX());
+		line.fromY = static_cast<float>(inFrom.GetY());
+		line.fromZ = static_cast<float>(inFrom.GetZ());
+		line.toX = static_cast<float>(inTo.GetX());
+		line.toY = static_cast<float>(inTo.GetY());
+		line.toZ = static_cast<float>(inTo.GetZ());
+		line.color = inColor.GetUInt32();
+		m_lines.push_back(line);
+	}
+
+	virtual void DrawTriangle(RVec3Arg inV1, RVec3Arg inV2, RVec3Arg inV3, ColorArg inColor, ECastShadow inCastShadow) override
+	{
+		// Emit triangle edges so callers only need to handle lines
+		DrawLine(inV1, inV2, inColor);
+		DrawLine(inV2, inV3, inColor);
+		DrawLine(inV3, inV1, inColor);
+	}
+
+	virtual void DrawText3D(RVec3Arg inPosition, const string_view& inString, ColorArg inColor, float inHeight) override
+	{
+		// Text is not supported
+	}
+
+	std::vector<JoltDebugLine>& GetLines() { return m_lines; }
+
+private:
+	std::vector<JoltDebugLine> m_lines;
+};
+
+JoltDebugLines JoltPhysicsSystemDrawBodies(JoltPhysicsSystem system)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	BodyManager::DrawSettings settings;
+	settings.mDrawShape = true;
+	settings.mDrawShapeWireframe = true;
+
+	LineCollectorRenderer renderer;
+	ps->DrawBodies(settings, &renderer);
+
+	// Move the collected lines to the heap, caller frees with JoltDestroyDebugLines
+	auto lines = new std::vector<JoltDebugLine>(std::move(renderer.GetLines()));
+	return static_cast<JoltDebugLines>(lines);
+}
+
+int JoltDebugLinesGetCount(const JoltDebugLines lines)
+{
+	const std::vector<JoltDebugLine>* l = static_cast<const std::vector<JoltDebugLine>*>(lines);
+	return static_cast<int>(l->size());
+}
+
+int JoltDebugLinesGet(const JoltDebugLines lines, JoltDebugLine* outLines, int maxLines)
+{
+	const std::vector<JoltDebugLine>* l = static_cast<const std::vector<JoltDebugLine>*>(lines);
+
+	int numToReturn = std::min(static_cast<int>(l->size()), maxLines);
+	std::copy(l->begin(), l->begin() + numToReturn, outLines);
+	return numToReturn;
+}
+
+void JoltDestroyDebugLines(JoltDebugLines lines)
+{
+	std::vector<JoltDebugLine>* l = static_cast<std::vector<JoltDebugLine>*>(lines);
+	delete l;
+}
@@ -0,0 +1,44 @@
+/*
+ * Jolt Physics C Wrapper - Debug Drawing
+ *
+ * Collects body wireframes from Jolt's debug renderer for visualizing colliders.
+ */
+
+#ifndef JOLT_WRAPPER_DEBUG_H
+#define JOLT_WRAPPER_DEBUG_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque pointer types
+typedef void* JoltPhysicsSystem;
+typedef void* JoltDebugLines;
+
+// A single debug line segment in world space
+typedef struct {
+    float fromX, fromY, fromZ;
+    float toX, toY, toZ;
+    unsigned int color;     // Packed RGBA (0xAABBGGRR)
+} JoltDebugLine;
+
+// Draw all bodies in the physics system as wireframes
+// Returns a line list that must be freed with JoltDestroyDebugLines
+JoltDebugLines JoltPhysicsSystemDrawBodies(JoltPhysicsSystem system);
+
+// Get the number of line segments in a line list
+int JoltDebugLinesGetCount(const JoltDebugLines lines);
+
+// Copy line segments from a line list
+// outLines: array to store results (allocated by caller)
+// Returns: actual number of lines copied
+int JoltDebugLinesGet(const JoltDebugLines lines, JoltDebugLine* outLines, int maxLines);
+
+// Destroy a line list
+void JoltDestroyDebugLines(JoltDebugLines lines);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // JOLT_WRAPPER_DEBUG_H
@@ -0,0 +1,39 @@
+/*
+ * Jolt Physics C Wrapper - Collision Layers
+ *
+ * C++ only: the object and broad phase layers shared by the wrapper implementation.
+ * The values match JoltObjectLayer in physics.h.
+ */
+
+#ifndef JOLT_WRAPPER_LAYERS_H
+#define JOLT_WRAPPER_LAYERS_H
+
+#include "physics.h"
+#include <Jolt/Jolt.h>
+#include <Jolt/Physics/Collision/ObjectLayer.h>
+#include <Jolt/Physics/Collision/BroadPhase/BroadPhaseLayer.h>
+
+// Collision layers: NON_MOVING (static), MOVING (dynamic), DISABLED (collides with nothing)
+// and QUERY_ONLY (collides with nothing, but is hit by queries)
+namespace Layers
+{
+	static constexpr JPH::ObjectLayer NON_MOVING = JoltObjectLayerNonMoving;
+	static constexpr JPH::ObjectLayer MOVING = JoltObjectLayerMoving;
+	static constexpr JPH::ObjectLayer DISABLED = JoltObjectLayerDisabled;
+	static constexpr JPH::ObjectLayer QUERY_ONLY = JoltObjectLayerQueryOnly;
+
+	static constexpr JPH::ObjectLayer NUM_LAYERS = 4;
+};
+
+// One broad phase layer per object layer
+namespace BroadPhaseLayers
+{
+	static constexpr JPH::BroadPhaseLayer NON_MOVING(0);
+	static constexpr JPH::BroadPhaseLayer MOVING(1);
+	static constexpr JPH::BroadPhaseLayer DISABLED(2);
+	static constexpr JPH::BroadPhaseLayer QUERY_ONLY(3);
+
+	static constexpr JPH::uint NUM_LAYERS(4);
+};
+
+#endif // JOLT_WRAPPER_LAYERS_H
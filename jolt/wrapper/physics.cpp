@@ -7,33 +7,20 @@
  */
 
 #include "physics.h"
+#include "layers.h"
 #include "core.h"
 #include <Jolt/Jolt.h>
 #include <Jolt/Core/TempAllocator.h>
 #include <Jolt/Core/JobSystemThreadPool.h>
+#include <Jolt/Core/JobSystemSingleThreaded.h>
 #include <Jolt/Physics/PhysicsSettings.h>
 #include <Jolt/Physics/PhysicsSystem.h>
+#include <Jolt/Physics/Collision/ContactListener.h>
+#include <Jolt/Physics/Body/BodyActivationListener.h>
 #include <memory>
 
 using namespace JPH;
 
-// Collision layers: NON_MOVING (static) and MOVING (dynamic)
-namespace Layers
-{
-	static constexpr ObjectLayer NON_MOVING = 0;
-	static constexpr ObjectLayer MOVING = 1;
-
-	static constexpr ObjectLayer NUM_LAYERS = 2;
-};
-
-namespace BroadPhaseLayers
-{
-	static constexpr BroadPhaseLayer NON_MOVING(0);
-	static constexpr BroadPhaseLayer MOVING(1);
-
-	static constexpr uint NUM_LAYERS(2);
-};
-
 // Maps object layers to broad phase layers
 class BPLayerInterfaceImpl final : public BroadPhaseLayerInterface
 {
@@ -42,6 +29,8 @@ public:
 	{
 		mObjectToBroadPhase[Layers::NON_MOVING] = BroadPhaseLayers::NON_MOVING;
 		mObjectToBroadPhase[Layers::MOVING] = BroadPhaseLayers::MOVING;
+		mObjectToBroadPhase[Layers::DISABLED] = BroadPhaseLayers::DISABLED;
+		mObjectToBroadPhase[Layers::QUERY_ONLY] = BroadPhaseLayers::QUERY_ONLY;
 	}
 
 	virtual uint GetNumBroadPhaseLayers() const override
@@ -62,6 +51,8 @@ public:
 		{
 		case (BroadPhaseLayer::Type)BroadPhaseLayers::NON_MOVING:	return "NON_MOVING";
 		case (BroadPhaseLayer::Type)BroadPhaseLayers::MOVING:		return "MOVING";
+		case (BroadPhaseLayer::Type)BroadPhaseLayers::DISABLED:		return "DISABLED";
+		case (BroadPhaseLayer::Type)BroadPhaseLayers::QUERY_ONLY:	return "QUERY_ONLY";
 		default:													return "INVALID";
 		}
 	}
@@ -82,7 +73,10 @@ public:
 		case Layers::NON_MOVING:
 			return inLayer2 == BroadPhaseLayers::MOVING;
 		case Layers::MOVING:
-			return true;
+			return inLayer2 == BroadPhaseLayers::NON_MOVING || inLayer2 == BroadPhaseLayers::MOVING;
+		case Layers::DISABLED:
+		case Layers::QUERY_ONLY:
+			return false;
 		default:
 			JPH_ASSERT(false);
 			return false;
@@ -101,7 +95,10 @@ public:
 		case Layers::NON_MOVING:
 			return inObject2 == Layers::MOVING;
 		case Layers::MOVING:
-			return true;
+			return inObject2 == Layers::NON_MOVING || inObject2 == Layers::MOVING;
+		case Layers::DISABLED:
+		case Layers::QUERY_ONLY:
+			return false;
 		default:
 			JPH_ASSERT(false);
 			return false;
@@ -116,6 +113,9 @@ struct PhysicsSystemWrapper
 	std::unique_ptr<BPLayerInterfaceImpl> broad_phase_layer_interface;
 	std::unique_ptr<ObjectVsBroadPhaseLayerFilterImpl> object_vs_broadphase_layer_filter;
 	std::unique_ptr<ObjectLayerPairFilterImpl> object_vs_object_layer_filter;
+	std::unique_ptr<ContactListener> contact_listener;
+	std::unique_ptr<BodyActivationListener> body_activation_listener;
+	std::unique_ptr<JobSystemSingleThreaded> single_threaded_job_system; // Used instead of gJobSystem in deterministic mode
 
 	~PhysicsSystemWrapper() = default;
 };
@@ -154,9 +154,107 @@ void JoltDestroyPhysicsSystem(JoltPhysicsSystem system)
 }
 
 void JoltPhysicsSystemUpdate(JoltPhysicsSystem system, float deltaTime)
+{
+	JoltPhysicsSystemUpdateWithCollisionSteps(system, deltaTime, 1);
+}
+
+void JoltPhysicsSystemUpdateWithCollisionSteps(JoltPhysicsSystem system, float deltaTime, int collisionSteps)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	JobSystem* jobSystem = wrapper->single_threaded_job_system
+		? static_cast<JobSystem*>(wrapper->single_threaded_job_system.get())
+		: static_cast<JobSystem*>(gJobSystem.get());
+	wrapper->system->Update(deltaTime, collisionSteps, gTempAllocator.get(), jobSystem);
+}
+
+void JoltPhysicsSystemSetDeterministicMode(JoltPhysicsSystem system, int enabled)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+
+	if (!enabled)
+	{
+		wrapper->single_threaded_job_system.reset();
+		return;
+	}
+
+	if (!wrapper->single_threaded_job_system)
+	{
+		wrapper->single_threaded_job_system = std::make_unique<JobSystemSingleThreaded>(cMaxPhysicsJobs);
+	}
+
+	// Sort bodies and constraints so the solve order doesn't depend on the order the broad phase finds them in
+	PhysicsSettings joltSettings = wrapper->system->GetPhysicsSettings();
+	joltSettings.mDeterministicSimulation = true;
+	wrapper->system->SetPhysicsSettings(joltSettings);
+}
+
+int JoltPhysicsSystemIsDeterministicMode(const JoltPhysicsSystem system)
+{
+	const PhysicsSystemWrapper *wrapper = static_cast<const PhysicsSystemWrapper *>(system);
+	return wrapper->single_threaded_job_system ? 1 : 0;
+}
+
+void JoltPhysicsSystemSetGravity(JoltPhysicsSystem system, float x, float y, float z)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+	wrapper->system->SetGravity(Vec3(x, y, z));
+}
+
+void JoltPhysicsSystemGetGravity(const JoltPhysicsSystem system, float* x, float* y, float* z)
+{
+	const PhysicsSystemWrapper *wrapper = static_cast<const PhysicsSystemWrapper *>(system);
+	Vec3 gravity = wrapper->system->GetGravity();
+	*x = gravity.GetX();
+	*y = gravity.GetY();
+	*z = gravity.GetZ();
+}
+
+void JoltPhysicsSystemGetSettings(const JoltPhysicsSystem system, JoltPhysicsSettings* outSettings)
+{
+	const PhysicsSystemWrapper *wrapper = static_cast<const PhysicsSystemWrapper *>(system);
+	const PhysicsSettings& settings = wrapper->system->GetPhysicsSettings();
+
+	outSettings->allowSleeping = settings.mAllowSleeping ? 1 : 0;
+	outSettings->pointVelocitySleepThreshold = settings.mPointVelocitySleepThreshold;
+	outSettings->timeBeforeSleep = settings.mTimeBeforeSleep;
+}
+
+void JoltPhysicsSystemSetSettings(JoltPhysicsSystem system, const JoltPhysicsSettings* settings)
 {
 	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
-	wrapper->system->Update(deltaTime, 1, gTempAllocator.get(), gJobSystem.get());
+
+	// Start from the current settings so fields not exposed to Go are kept
+	PhysicsSettings joltSettings = wrapper->system->GetPhysicsSettings();
+	joltSettings.mAllowSleeping = settings->allowSleeping != 0;
+	joltSettings.mPointVelocitySleepThreshold = settings->pointVelocitySleepThreshold;
+	joltSettings.mTimeBeforeSleep = settings->timeBeforeSleep;
+
+	wrapper->system->SetPhysicsSettings(joltSettings);
+}
+
+void JoltPhysicsSystemGetSolverSettings(const JoltPhysicsSystem system, JoltSolverSettings* outSettings)
+{
+	const PhysicsSystemWrapper *wrapper = static_cast<const PhysicsSystemWrapper *>(system);
+	const PhysicsSettings& settings = wrapper->system->GetPhysicsSettings();
+
+	outSettings->baumgarte = settings.mBaumgarte;
+	outSettings->penetrationSlop = settings.mPenetrationSlop;
+	outSettings->numVelocitySteps = static_cast<int>(settings.mNumVelocitySteps);
+	outSettings->numPositionSteps = static_cast<int>(settings.mNumPositionSteps);
+}
+
+void JoltPhysicsSystemSetSolverSettings(JoltPhysicsSystem system, const JoltSolverSettings* settings)
+{
+	PhysicsSystemWrapper *wrapper = static_cast<PhysicsSystemWrapper *>(system);
+
+	// Start from the current settings so fields not exposed to Go are kept
+	PhysicsSettings joltSettings = wrapper->system->GetPhysicsSettings();
+	joltSettings.mBaumgarte = settings->baumgarte;
+	joltSettings.mPenetrationSlop = settings->penetrationSlop;
+	joltSettings.mNumVelocitySteps = static_cast<uint>(settings->numVelocitySteps);
+	joltSettings.mNumPositionSteps = static_cast<uint>(settings->numPositionSteps);
+
+	wrapper->system->SetPhysicsSettings(joltSettings);
 }
 
 // C++ only: Accessor functions for wrapper internals
@@ -174,3 +272,27 @@ const ObjectLayerPairFilter* GetObjectLayerPairFilter(PhysicsSystemWrapper* wrap
 {
 	return wrapper->object_vs_object_layer_filter.get();
 }
+
+ContactListener* GetContactListener(PhysicsSystemWrapper* wrapper)
+{
+	return wrapper->contact_listener.get();
+}
+
+void SetContactListener(PhysicsSystemWrapper* wrapper, ContactListener* listener)
+{
+	// Detach the old listener from the system before freeing it
+	wrapper->system->SetContactListener(listener);
+	wrapper->contact_listener.reset(listener);
+}
+
+BodyActivationListener* GetBodyActivationListener(PhysicsSystemWrapper* wrapper)
+{
+	return wrapper->body_activation_listener.get();
+}
+
+void SetBodyActivationListener(PhysicsSystemWrapper* wrapper, BodyActivationListener* listener)
+{
+	// Detach the old listener from the system before freeing it
+	wrapper->system->SetBodyActivationListener(listener);
+	wrapper->body_activation_listener.reset(listener);
+}
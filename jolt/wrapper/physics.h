@@ -14,6 +14,29 @@ extern "C" {
 // Opaque pointer types
 typedef void* JoltPhysicsSystem;
 
+// Object layers (match the layer setup in physics.cpp)
+typedef enum {
+    JoltObjectLayerNonMoving = 0,  // Static bodies, only collide with moving bodies
+    JoltObjectLayerMoving = 1,     // Dynamic/kinematic bodies, collide with static and moving bodies
+    JoltObjectLayerDisabled = 2,   // Bodies with collision disabled, collide with nothing
+    JoltObjectLayerQueryOnly = 3   // Markers, collide with nothing but are hit by queries
+} JoltObjectLayer;
+
+// World-level simulation settings (subset of JPH::PhysicsSettings)
+typedef struct {
+    int allowSleeping;                 // Non-zero if bodies can go to sleep
+    float pointVelocitySleepThreshold; // Velocity of points on the bounding sphere below which a body can sleep (m/s)
+    float timeBeforeSleep;             // Time a body must stay below the threshold before it sleeps (s)
+} JoltPhysicsSettings;
+
+// Contact solver settings (subset of JPH::PhysicsSettings)
+typedef struct {
+    float baumgarte;        // Fraction of the position error corrected per step (0-1)
+    float penetrationSlop;  // Penetration that is allowed without being corrected (m)
+    int numVelocitySteps;   // Velocity solver iterations per step
+    int numPositionSteps;   // Position solver iterations per step
+} JoltSolverSettings;
+
 // Create a new physics world
 JoltPhysicsSystem JoltCreatePhysicsSystem();
 
@@ -23,6 +46,33 @@ void JoltDestroyPhysicsSystem(JoltPhysicsSystem system);
 // Step the physics simulation by deltaTime seconds
 void JoltPhysicsSystemUpdate(JoltPhysicsSystem system, float deltaTime);
 
+// Step the physics simulation by deltaTime seconds, running collisionSteps collision steps within the step
+void JoltPhysicsSystemUpdateWithCollisionSteps(JoltPhysicsSystem system, float deltaTime, int collisionSteps);
+
+// Enable or disable deterministic mode: updates run on the calling thread with a deterministic solve order
+void JoltPhysicsSystemSetDeterministicMode(JoltPhysicsSystem system, int enabled);
+
+// Returns 1 if deterministic mode is enabled, 0 otherwise
+int JoltPhysicsSystemIsDeterministicMode(const JoltPhysicsSystem system);
+
+// Set the gravity of a physics world
+void JoltPhysicsSystemSetGravity(JoltPhysicsSystem system, float x, float y, float z);
+
+// Get the gravity of a physics world
+void JoltPhysicsSystemGetGravity(const JoltPhysicsSystem system, float* x, float* y, float* z);
+
+// Get the simulation settings of a physics world
+void JoltPhysicsSystemGetSettings(const JoltPhysicsSystem system, JoltPhysicsSettings* outSettings);
+
+// Set the simulation settings of a physics world (Jolt settings not in JoltPhysicsSettings are kept)
+void JoltPhysicsSystemSetSettings(JoltPhysicsSystem system, const JoltPhysicsSettings* settings);
+
+// Get the contact solver settings
+void JoltPhysicsSystemGetSolverSettings(const JoltPhysicsSystem system, JoltSolverSettings* outSettings);
+
+// Set the contact solver settings
+void JoltPhysicsSystemSetSolverSettings(JoltPhysicsSystem system, const JoltSolverSettings* settings);
+
 #ifdef __cplusplus
 }
 
@@ -31,6 +81,8 @@ namespace JPH {
     class PhysicsSystem;
     class ObjectVsBroadPhaseLayerFilter;
     class ObjectLayerPairFilter;
+    class ContactListener;
+    class BodyActivationListener;
 }
 
 struct PhysicsSystemWrapper;  // Opaque forward declaration
@@ -40,6 +92,18 @@ JPH::PhysicsSystem* GetPhysicsSystem(PhysicsSystemWrapper* wrapper);
 const JPH::ObjectVsBroadPhaseLayerFilter* GetObjectVsBroadPhaseLayerFilter(PhysicsSystemWrapper* wrapper);
 const JPH::ObjectLayerPairFilter* GetObjectLayerPairFilter(PhysicsSystemWrapper* wrapper);
 
+// Set the contact listener of a physics system, taking ownership (NULL removes the current listener)
+void SetContactListener(PhysicsSystemWrapper* wrapper, JPH::ContactListener* listener);
+
+// Get the contact listener of a physics system (NULL if none)
+JPH::ContactListener* GetContactListener(PhysicsSystemWrapper* wrapper);
+
+// Set the body activation listener of a physics system, taking ownership (NULL removes the current listener)
+void SetBodyActivationListener(PhysicsSystemWrapper* wrapper, JPH::BodyActivationListener* listener);
+
+// Get the body activation listener of a physics system (NULL if none)
+JPH::BodyActivationListener* GetBodyActivationListener(PhysicsSystemWrapper* wrapper);
+
 #endif
 
 #endif // JOLT_WRAPPER_PHYSICS_H
@@ -4,27 +4,28 @@
 
 #include "query.h"
 #include "physics.h"
+#include "layers.h"
 #include <Jolt/Jolt.h>
 #include <Jolt/Physics/PhysicsSystem.h>
 #include <Jolt/Physics/Collision/CollideShape.h>
+#include <Jolt/Physics/Collision/ShapeCast.h>
 #include <Jolt/Physics/Collision/CollisionCollectorImpl.h>
 #include <Jolt/Physics/Collision/NarrowPhaseQuery.h>
 #include <Jolt/Physics/Collision/RayCast.h>
 #include <Jolt/Physics/Collision/CastResult.h>
 #include <Jolt/Physics/Body/BodyID.h>
 #include <Jolt/Physics/Body/BodyLockInterface.h>
+#include <Jolt/Physics/Body/BodyInterface.h>
+#include <Jolt/Physics/Body/BodyFilter.h>
+#include <Jolt/Physics/Collision/TransformedShape.h>
+#include <Jolt/Physics/Collision/Shape/SphereShape.h>
 #include <vector>
 #include <algorithm>
 
 using namespace JPH;
 
-// Collision layers (defined in physics.cpp)
-namespace Layers
-{
-	static constexpr ObjectLayer MOVING = 1;
-};
-
 // Adapter: converts ObjectVsBroadPhaseLayerFilter to BroadPhaseLayerFilter for queries
+// Queries also see query-only bodies, which the simulation ignores
 class BroadPhaseLayerFilterAdapter : public BroadPhaseLayerFilter
 {
 public:
@@ -33,7 +34,7 @@ public:
 
 	virtual bool ShouldCollide(BroadPhaseLayer inLayer) const override
 	{
-		return m_filter->ShouldCollide(m_object_layer, inLayer);
+		return inLayer == BroadPhaseLayers::QUERY_ONLY || m_filter->ShouldCollide(m_object_layer, inLayer);
 	}
 
 private:
@@ -42,6 +43,7 @@ private:
 };
 
 // Adapter: converts ObjectLayerPairFilter to ObjectLayerFilter for queries
+// Queries also see query-only bodies, which the simulation ignores
 class ObjectLayerFilterAdapter : public ObjectLayerFilter
 {
 public:
@@ -50,7 +52,7 @@ public:
 
 	virtual bool ShouldCollide(ObjectLayer inLayer) const override
 	{
-		return m_filter->ShouldCollide(m_object_layer, inLayer);
+		return inLayer == Layers::QUERY_ONLY || m_filter->ShouldCollide(m_object_layer, inLayer);
 	}
 
 private:
@@ -75,6 +77,67 @@ private:
 	bool m_hasHit;
 };
 
+// Converts a collide shape result to a JoltCollisionHit (allocates the body ID)
+static void ToJoltCollisionHit(const CollideShapeResult& inResult, JoltCollisionHit& hit)
+{
+	// Store body ID
+	BodyID* bodyIDCopy = new BodyID(inResult.mBodyID2);
+	hit.bodyID = static_cast<JoltBodyID>(bodyIDCopy);
+
+	// Store contact point (using contact point on second shape)
+	Vec3 contactPoint = inResult.mContactPointOn2;
+	hit.contactPointX = contactPoint.GetX();
+	hit.contactPointY = contactPoint.GetY();
+	hit.contactPointZ = contactPoint.GetZ();
+
+	// Store penetration depth
+	hit.penetrationDepth = inResult.mPenetrationDepth;
+
+	// Store contact normal (penetration axis points towards moving body 2 out, so flip it)
+	Vec3 normal = -inResult.mPenetrationAxis.NormalizedOr(Vec3::sZero());
+	hit.normalX = normal.GetX();
+	hit.normalY = normal.GetY();
+	hit.normalZ = normal.GetZ();
+
+	// Store sub-shape ID
+	hit.subShapeID2 = inResult.mSubShapeID2.GetValue();
+}
+
+// Fills in the sensor and back face flags of collision hits
+// Done after the query since the narrow phase does not hold body locks while calling collectors
+static void SetBodyFlags(PhysicsSystem* ps, JoltCollisionHit* hits, int numHits)
+{
+	const BodyLockInterface& bodyLock = ps->GetBodyLockInterface();
+	for (int i = 0; i < numHits; i++)
+	{
+		JoltCollisionHit& hit = hits[i];
+		hit.isSensor = 0;
+		hit.isBackFaceHit = 0;
+
+		const BodyID* bodyID = static_cast<const BodyID*>(hit.bodyID);
+		BodyLockRead lock(bodyLock, *bodyID);
+		if (!lock.Succeeded())
+			continue;
+
+		const Body& body = lock.GetBody();
+		hit.isSensor = body.IsSensor() ? 1 : 0;
+
+		// Only mesh triangles have a back face
+		SubShapeID subShapeID;
+		subShapeID.SetValue(hit.subShapeID2);
+		SubShapeID remainder;
+		const Shape* leaf = body.GetShape()->GetLeafShape(subShapeID, remainder);
+		if (leaf == nullptr || leaf->GetSubType() != EShapeSubType::Mesh)
+			continue;
+
+		// The query shape is pushed out against the triangle normal when it is behind the triangle
+		RVec3 contactPoint(hit.contactPointX, hit.contactPointY, hit.contactPointZ);
+		Vec3 triangleNormal = body.GetWorldSpaceSurfaceNormal(subShapeID, contactPoint);
+		Vec3 normal(hit.normalX, hit.normalY, hit.normalZ);
+		hit.isBackFaceHit = normal.Dot(triangleNormal) < 0.0f ? 1 : 0;
+	}
+}
+
 // Collector that stores all collision hits
 class AllHitsCollector : public CollideShapeCollector
 {
@@ -86,21 +149,7 @@ public:
 	{
 		if (m_numHits < m_maxHits)
 		{
-			JoltCollisionHit& hit = m_outHits[m_numHits];
-
-			// Store body ID
-			BodyID* bodyIDCopy = new BodyID(inResult.mBodyID2);
-			hit.bodyID = static_cast<JoltBodyID>(bodyIDCopy);
-
-			// Store contact point (using contact point on second shape)
-			Vec3 contactPoint = inResult.mContactPointOn2;
-			hit.contactPointX = contactPoint.GetX();
-			hit.contactPointY = contactPoint.GetY();
-			hit.contactPointZ = contactPoint.GetZ();
-
-			// Store penetration depth
-			hit.penetrationDepth = inResult.mPenetrationDepth;
-
+			ToJoltCollisionHit(inResult, m_outHits[m_numHits]);
 			m_numHits++;
 		}
 	}
@@ -113,8 +162,38 @@ private:
 	int m_numHits;
 };
 
+// Converts collide settings from C to Jolt
+static CollideShapeSettings ToCollideShapeSettings(const JoltCollideShapeSettings* settings)
+{
+	CollideShapeSettings result;
+	result.mActiveEdgeMode = settings->activeEdgeMode != 0 ? EActiveEdgeMode::CollideWithAll : EActiveEdgeMode::CollideOnlyWithActive;
+	result.mBackFaceMode = settings->backFaceMode != 0 ? EBackFaceMode::CollideWithBackFaces : EBackFaceMode::IgnoreBackFaces;
+	result.mPenetrationTolerance = settings->penetrationTolerance;
+	result.mMaxSeparationDistance = settings->maxSeparationDistance;
+	return result;
+}
+
+// Default settings of the collide queries without explicit settings
+static JoltCollideShapeSettings DefaultCollideShapeSettings(float penetrationTolerance)
+{
+	JoltCollideShapeSettings settings;
+	settings.activeEdgeMode = 0;
+	settings.backFaceMode = 0;
+	settings.penetrationTolerance = penetrationTolerance;
+	settings.maxSeparationDistance = 0.0f;
+	return settings;
+}
+
 int JoltCollideShape(JoltPhysicsSystem system, JoltShape shape,
                      float posX, float posY, float posZ, float penetrationTolerance)
+{
+	JoltCollideShapeSettings settings = DefaultCollideShapeSettings(penetrationTolerance);
+	return JoltCollideShapeWithSettings(system, shape, posX, posY, posZ, &settings);
+}
+
+int JoltCollideShapeWithSettings(JoltPhysicsSystem system, JoltShape shape,
+                                 float posX, float posY, float posZ,
+                                 const JoltCollideShapeSettings* settings)
 {
 	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
 	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
@@ -130,16 +209,12 @@ int JoltCollideShape(JoltPhysicsSystem system, JoltShape shape,
 	// Create collector to check for any hit
 	AnyHitCollector collector;
 
-	// Setup collision settings
-	CollideShapeSettings settings;
-	settings.mPenetrationTolerance = penetrationTolerance;
-
 	// Perform collision query
 	query.CollideShape(
 		s,
 		Vec3::sReplicate(1.0f),  // Scale
 		RMat44::sTranslation(RVec3(posX, posY, posZ)),  // Transform (position, no rotation)
-		settings,
+		ToCollideShapeSettings(settings),
 		RVec3::sZero(),  // Base offset
 		collector,
 		bpFilter,
@@ -149,9 +224,66 @@ int JoltCollideShape(JoltPhysicsSystem system, JoltShape shape,
 	return collector.HasHit() ? 1 : 0;
 }
 
+// Collector that counts the distinct bodies hit, a body can report several hits (e.g. a mesh)
+class CountBodiesCollector : public CollideShapeCollector
+{
+public:
+	virtual void AddHit(const CollideShapeResult& inResult) override
+	{
+		if (std::find(m_bodies.begin(), m_bodies.end(), inResult.mBodyID2) == m_bodies.end())
+			m_bodies.push_back(inResult.mBodyID2);
+	}
+
+	int GetNumBodies() const { return static_cast<int>(m_bodies.size()); }
+
+private:
+	std::vector<BodyID> m_bodies;
+};
+
+int JoltCountOverlaps(JoltPhysicsSystem system, JoltShape shape,
+                      float posX, float posY, float posZ,
+                      const JoltCollideShapeSettings* settings)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const Shape* s = static_cast<const Shape*>(shape);
+
+	// Get narrow phase query interface
+	const NarrowPhaseQuery& query = ps->GetNarrowPhaseQuery();
+
+	// Create filter adapters (query shape acts as MOVING layer)
+	BroadPhaseLayerFilterAdapter bpFilter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter objFilter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+
+	CountBodiesCollector collector;
+
+	// Perform collision query
+	query.CollideShape(
+		s,
+		Vec3::sReplicate(1.0f),  // Scale
+		RMat44::sTranslation(RVec3(posX, posY, posZ)),  // Transform (position, no rotation)
+		ToCollideShapeSettings(settings),
+		RVec3::sZero(),  // Base offset
+		collector,
+		bpFilter,
+		objFilter
+	);
+
+	return collector.GetNumBodies();
+}
+
 int JoltCollideShapeGetHits(JoltPhysicsSystem system, JoltShape shape,
                             float posX, float posY, float posZ,
                             JoltCollisionHit* outHits, int maxHits, float penetrationTolerance)
+{
+	JoltCollideShapeSettings settings = DefaultCollideShapeSettings(penetrationTolerance);
+	return JoltCollideShapeGetHitsWithSettings(system, shape, posX, posY, posZ, &settings, outHits, maxHits);
+}
+
+int JoltCollideShapeGetHitsWithSettings(JoltPhysicsSystem system, JoltShape shape,
+                                        float posX, float posY, float posZ,
+                                        const JoltCollideShapeSettings* settings,
+                                        JoltCollisionHit* outHits, int maxHits)
 {
 	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
 	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
@@ -167,25 +299,252 @@ int JoltCollideShapeGetHits(JoltPhysicsSystem system, JoltShape shape,
 	// Create collector to gather all hits
 	AllHitsCollector collector(outHits, maxHits);
 
-	// Setup collision settings
-	CollideShapeSettings settings;
-	settings.mPenetrationTolerance = penetrationTolerance;
-
 	// Perform collision query
 	query.CollideShape(
 		s,
 		Vec3::sReplicate(1.0f),  // Scale
 		RMat44::sTranslation(RVec3(posX, posY, posZ)),  // Transform (position, no rotation)
-		settings,
+		ToCollideShapeSettings(settings),
 		RVec3::sZero(),  // Base offset
 		collector,
 		bpFilter,
 		objFilter
 	);
 
+	SetBodyFlags(ps, outHits, collector.GetNumHits());
+
 	return collector.GetNumHits();
 }
 
+int JoltCollideShapeDeepest(JoltPhysicsSystem system, JoltShape shape,
+                            float posX, float posY, float posZ,
+                            JoltCollisionHit* outHit)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const Shape* s = static_cast<const Shape*>(shape);
+
+	// Get narrow phase query interface
+	const NarrowPhaseQuery& query = ps->GetNarrowPhaseQuery();
+
+	// Create filter adapters (query shape acts as MOVING layer)
+	BroadPhaseLayerFilterAdapter bpFilter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter objFilter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+
+	// Early out fraction of a collide shape result is -depth, so the "closest" hit is the deepest one
+	ClosestHitCollisionCollector<CollideShapeCollector> collector;
+
+	// Perform collision query
+	query.CollideShape(
+		s,
+		Vec3::sReplicate(1.0f),  // Scale
+		RMat44::sTranslation(RVec3(posX, posY, posZ)),  // Transform (position, no rotation)
+		CollideShapeSettings(),
+		RVec3::sZero(),  // Base offset
+		collector,
+		bpFilter,
+		objFilter
+	);
+
+	if (!collector.HadHit())
+	{
+		return 0;
+	}
+
+	ToJoltCollisionHit(collector.mHit, *outHit);
+	SetBodyFlags(ps, outHit, 1);
+	return 1;
+}
+
+int JoltApplyRadialImpulse(JoltPhysicsSystem system,
+                           float centerX, float centerY, float centerZ,
+                           float radius, float strength)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	// SphereShape asserts on a non-positive radius (also rejects NaN)
+	if (!(radius > 0.0f))
+	{
+		return 0;
+	}
+
+	// Get narrow phase query interface
+	const NarrowPhaseQuery& query = ps->GetNarrowPhaseQuery();
+
+	// Create filter adapters (explosion acts as MOVING layer)
+	BroadPhaseLayerFilterAdapter bpFilter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter objFilter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+
+	// Find all bodies overlapping the explosion sphere
+	RefConst<Shape> sphere = new SphereShape(radius);
+	AllHitCollisionCollector<CollideShapeCollector> collector;
+	RVec3 center(centerX, centerY, centerZ);
+
+	query.CollideShape(
+		sphere,
+		Vec3::sReplicate(1.0f),  // Scale
+		RMat44::sTranslation(center),  // Transform (position, no rotation)
+		CollideShapeSettings(),
+		RVec3::sZero(),  // Base offset
+		collector,
+		bpFilter,
+		objFilter
+	);
+
+	// A body can be hit multiple times (e.g. compound shapes), push it only once
+	std::vector<BodyID> bodyIDs;
+	bodyIDs.reserve(collector.mHits.size());
+	for (const CollideShapeResult& hit : collector.mHits)
+	{
+		bodyIDs.push_back(hit.mBodyID2);
+	}
+	std::sort(bodyIDs.begin(), bodyIDs.end());
+	bodyIDs.erase(std::unique(bodyIDs.begin(), bodyIDs.end()), bodyIDs.end());
+
+	BodyInterface& bi = ps->GetBodyInterface();
+	int numBodies = 0;
+	for (const BodyID& bodyID : bodyIDs)
+	{
+		if (bi.GetMotionType(bodyID) != EMotionType::Dynamic)
+		{
+			continue;
+		}
+
+		// Linear falloff from full strength at the center to zero at the radius
+		Vec3 offset = Vec3(bi.GetCenterOfMassPosition(bodyID) - center);
+		float falloff = std::max(0.0f, 1.0f - offset.Length() / radius);
+
+		// Bodies that overlap the sphere but have their center of mass outside it get nothing
+		float magnitude = strength * falloff;
+		if (magnitude == 0.0f)
+		{
+			continue;
+		}
+
+		// Bodies exactly at the center are pushed up
+		Vec3 direction = offset.NormalizedOr(Vec3::sAxisY());
+		bi.AddImpulse(bodyID, direction * magnitude);
+		numBodies++;
+	}
+
+	return numBodies;
+}
+
+// Sweeps a shape from a world transform and finds the closest hit
+static bool CastShapeClosest(PhysicsSystemWrapper* wrapper, const Shape* shape,
+                             RMat44Arg transform, Vec3Arg direction, ShapeCastResult& outResult)
+{
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	// Get narrow phase query interface
+	const NarrowPhaseQuery& query = ps->GetNarrowPhaseQuery();
+
+	// Create filter adapters (cast shape acts as MOVING layer)
+	BroadPhaseLayerFilterAdapter bpFilter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter objFilter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+
+	RShapeCast shapeCast = RShapeCast::sFromWorldTransform(
+		shape,
+		Vec3::sReplicate(1.0f),  // Scale
+		transform,  // Start transform
+		direction  // Direction and length of the sweep
+	);
+
+	ClosestHitCollisionCollector<CastShapeCollector> collector;
+	query.CastShape(
+		shapeCast,
+		ShapeCastSettings(),
+		RVec3::sZero(),  // Base offset
+		collector,
+		bpFilter,
+		objFilter
+	);
+
+	if (!collector.HadHit())
+	{
+		return false;
+	}
+
+	outResult = collector.mHit;
+	return true;
+}
+
+int JoltCastShape(JoltPhysicsSystem system, JoltShape shape,
+                  float posX, float posY, float posZ,
+                  float directionX, float directionY, float directionZ,
+                  JoltShapeCastHit* outHit)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	const Shape* s = static_cast<const Shape*>(shape);
+
+	ShapeCastResult result;
+	if (!CastShapeClosest(wrapper, s, RMat44::sTranslation(RVec3(posX, posY, posZ)),
+	                      Vec3(directionX, directionY, directionZ), result))
+	{
+		return 0;
+	}
+
+	// Store body ID
+	BodyID* bodyIDCopy = new BodyID(result.mBodyID2);
+	outHit->bodyID = static_cast<JoltBodyID>(bodyIDCopy);
+
+	Vec3 contactPoint = result.mContactPointOn2;
+	outHit->contactPointX = contactPoint.GetX();
+	outHit->contactPointY = contactPoint.GetY();
+	outHit->contactPointZ = contactPoint.GetZ();
+
+	// Penetration axis points from the cast shape into the hit body, flip it
+	Vec3 normal = -result.mPenetrationAxis.NormalizedOr(Vec3::sZero());
+	outHit->normalX = normal.GetX();
+	outHit->normalY = normal.GetY();
+	outHit->normalZ = normal.GetZ();
+
+	outHit->fraction = result.mFraction;
+	outHit->penetrationDepth = result.mPenetrationDepth;
+	return 1;
+}
+
+int JoltProbeGround(JoltPhysicsSystem system,
+                    float posX, float posY, float posZ,
+                    float upX, float upY, float upZ,
+                    float radius, float maxDistance,
+                    float* outGroundPosX, float* outGroundPosY, float* outGroundPosZ,
+                    float* outGroundNormalX, float* outGroundNormalY, float* outGroundNormalZ,
+                    float* outDistance)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	// SphereShape asserts on a radius that isn't positive, also catches NaN
+	if (!(radius > 0.0f) || !(maxDistance >= 0.0f))
+	{
+		return 0;
+	}
+
+	// Sweep the probe sphere straight down
+	Vec3 up(upX, upY, upZ);
+	RefConst<Shape> sphere = new SphereShape(radius);
+	ShapeCastResult hit;
+	if (!CastShapeClosest(wrapper, sphere, RMat44::sTranslation(RVec3(posX, posY, posZ)),
+	                      -up * maxDistance, hit))
+	{
+		return 0;
+	}
+
+	*outGroundPosX = hit.mContactPointOn2.GetX();
+	*outGroundPosY = hit.mContactPointOn2.GetY();
+	*outGroundPosZ = hit.mContactPointOn2.GetZ();
+
+	// Penetration axis points from the sphere into the ground, the ground normal is its inverse
+	Vec3 normal = -hit.mPenetrationAxis.NormalizedOr(-up);
+	*outGroundNormalX = normal.GetX();
+	*outGroundNormalY = normal.GetY();
+	*outGroundNormalZ = normal.GetZ();
+
+	*outDistance = hit.mFraction * maxDistance;
+	return 1;
+}
+
 // Raycast: Closest hit collector
 class ClosestRayHitCollector : public CastRayCollector
 {
@@ -270,6 +629,7 @@ public:
 
 			// Store fraction
 			hit.fraction = result.mFraction;
+			hit.subShapeID2 = result.mSubShapeID2.GetValue();
 		}
 		m_numHits = numToReturn;
 	}
@@ -288,17 +648,25 @@ int JoltCastRay(JoltPhysicsSystem system,
                 float directionX, float directionY, float directionZ,
                 JoltRaycastHit* outHit)
 {
-	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	// Jolt defaults: ignore back faces, treat convex shapes as solid
+	return JoltCastRayWithSettings(system, originX, originY, originZ,
+	                               directionX, directionY, directionZ,
+	                               0, 1, outHit);
+}
+
+// Casts a ray and stores the closest hit accepted by bodyFilter in outHit (if not NULL)
+// Returns 1 if hit detected, 0 if no hit
+static int CastRayClosest(PhysicsSystemWrapper* wrapper,
+                          const RRayCast& ray,
+                          const RayCastSettings& settings,
+                          const BodyFilter& bodyFilter,
+                          JoltRaycastHit* outHit)
+{
 	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
 
 	// Get narrow phase query interface
 	const NarrowPhaseQuery& query = ps->GetNarrowPhaseQuery();
 
-	// Create the ray
-	RRayCast ray;
-	ray.mOrigin = RVec3(originX, originY, originZ);
-	ray.mDirection = Vec3(directionX, directionY, directionZ);
-
 	// Create filter adapters (ray acts as MOVING layer)
 	BroadPhaseLayerFilterAdapter bpFilter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
 	ObjectLayerFilterAdapter objFilter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
@@ -306,16 +674,14 @@ int JoltCastRay(JoltPhysicsSystem system,
 	// Create collector for closest hit
 	ClosestRayHitCollector collector;
 
-	// Create raycast settings
-	RayCastSettings settings;
-
 	// Perform raycast
 	query.CastRay(
 		ray,
 		settings,
 		collector,
 		bpFilter,
-		objFilter
+		objFilter,
+		bodyFilter
 	);
 
 	// Store result if hit and outHit is provided
@@ -350,11 +716,126 @@ int JoltCastRay(JoltPhysicsSystem system,
 
 		// Store fraction
 		outHit->fraction = result.mFraction;
+		outHit->subShapeID2 = result.mSubShapeID2.GetValue();
 	}
 
 	return collector.HasHit() ? 1 : 0;
 }
 
+int JoltCastRayWithSettings(JoltPhysicsSystem system,
+                            float originX, float originY, float originZ,
+                            float directionX, float directionY, float directionZ,
+                            int backfaceMode, int treatConvexAsSolid,
+                            JoltRaycastHit* outHit)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	// Create raycast settings
+	RayCastSettings settings;
+	settings.SetBackFaceMode(backfaceMode != 0 ? EBackFaceMode::CollideWithBackFaces : EBackFaceMode::IgnoreBackFaces);
+	settings.mTreatConvexAsSolid = treatConvexAsSolid != 0;
+
+	return CastRayClosest(wrapper, ray, settings, BodyFilter(), outHit);
+}
+
+// Body filter that asks a Go predicate whether a body can be hit
+class GoBodyFilter : public BodyFilter
+{
+public:
+	explicit GoBodyFilter(uintptr_t filter) : m_filter(filter) {}
+
+	virtual bool ShouldCollideLocked(const Body& inBody) const override
+	{
+		// Body ID is only valid during the callback, Go must not keep it
+		BodyID bodyID = inBody.GetID();
+		return goJoltBodyFilterAccept(m_filter, static_cast<JoltBodyID>(&bodyID), inBody.GetUserData()) != 0;
+	}
+
+private:
+	uintptr_t m_filter;
+};
+
+int JoltCastRayFiltered(JoltPhysicsSystem system,
+                        float originX, float originY, float originZ,
+                        float directionX, float directionY, float directionZ,
+                        uintptr_t filter,
+                        JoltRaycastHit* outHit)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	GoBodyFilter bodyFilter(filter);
+
+	return CastRayClosest(wrapper, ray, RayCastSettings(), bodyFilter, outHit);
+}
+
+// Body filter that skips sensors, so they never block a ray
+class IgnoreSensorsBodyFilter : public BodyFilter
+{
+public:
+	virtual bool ShouldCollideLocked(const Body& inBody) const override
+	{
+		return !inBody.IsSensor();
+	}
+};
+
+int JoltCastRaySolid(JoltPhysicsSystem system,
+                     float originX, float originY, float originZ,
+                     float directionX, float directionY, float directionZ,
+                     JoltRaycastHit* outHit)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	IgnoreSensorsBodyFilter bodyFilter;
+
+	return CastRayClosest(wrapper, ray, RayCastSettings(), bodyFilter, outHit);
+}
+
+int JoltCastRaysBatch(JoltPhysicsSystem system,
+                      const float* origins, const float* directions, int numRays,
+                      JoltRaycastHit* outHits)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+
+	// Same settings as JoltCastRay
+	RayCastSettings settings;
+	settings.SetBackFaceMode(EBackFaceMode::IgnoreBackFaces);
+	settings.mTreatConvexAsSolid = true;
+
+	int numHits = 0;
+	for (int i = 0; i < numRays; ++i)
+	{
+		RRayCast ray;
+		ray.mOrigin = RVec3(origins[i * 3], origins[i * 3 + 1], origins[i * 3 + 2]);
+		ray.mDirection = Vec3(directions[i * 3], directions[i * 3 + 1], directions[i * 3 + 2]);
+
+		if (CastRayClosest(wrapper, ray, settings, BodyFilter(), &outHits[i]))
+		{
+			numHits++;
+		}
+		else
+		{
+			outHits[i].bodyID = nullptr;
+		}
+	}
+
+	return numHits;
+}
+
 int JoltCastRayGetHits(JoltPhysicsSystem system,
                        float originX, float originY, float originZ,
                        float directionX, float directionY, float directionZ,
@@ -395,3 +876,295 @@ int JoltCastRayGetHits(JoltPhysicsSystem system,
 
 	return collector.GetNumHits();
 }
+
+// Object layer filter that only accepts the layers in a bit mask, on top of the regular query layers
+class LayerMaskObjectLayerFilter : public ObjectLayerFilter
+{
+public:
+	LayerMaskObjectLayerFilter(const ObjectLayerFilter& filter, unsigned int mask)
+		: m_filter(filter), m_mask(mask) {}
+
+	virtual bool ShouldCollide(ObjectLayer inLayer) const override
+	{
+		return inLayer < 32 && (m_mask & (1u << inLayer)) != 0 && m_filter.ShouldCollide(inLayer);
+	}
+
+private:
+	const ObjectLayerFilter& m_filter;
+	unsigned int m_mask;
+};
+
+int JoltCastRayGetHitsFiltered(JoltPhysicsSystem system,
+                               float originX, float originY, float originZ,
+                               float directionX, float directionY, float directionZ,
+                               const JoltBodyID* excludeBodyIDs, int numExclude,
+                               unsigned int layerMask,
+                               JoltRaycastHit* outHits, int maxHits)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	// Get narrow phase query interface
+	const NarrowPhaseQuery& query = ps->GetNarrowPhaseQuery();
+
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	// Create filter adapters (ray acts as MOVING layer), restricted to the layers in the mask
+	BroadPhaseLayerFilterAdapter bpFilter(GetObjectVsBroadPhaseLayerFilter(wrapper), Layers::MOVING);
+	ObjectLayerFilterAdapter queryFilter(GetObjectLayerPairFilter(wrapper), Layers::MOVING);
+	LayerMaskObjectLayerFilter objFilter(queryFilter, layerMask);
+
+	// Skip the excluded bodies
+	IgnoreMultipleBodiesFilter bodyFilter;
+	bodyFilter.Reserve(numExclude);
+	for (int i = 0; i < numExclude; ++i)
+	{
+		bodyFilter.IgnoreBody(*static_cast<const BodyID*>(excludeBodyIDs[i]));
+	}
+
+	// Create collector for all hits
+	AllRayHitsCollector collector(outHits, maxHits);
+
+	// Perform raycast
+	query.CastRay(
+		ray,
+		RayCastSettings(),
+		collector,
+		bpFilter,
+		objFilter,
+		bodyFilter
+	);
+
+	// Finalize results (sorts and converts)
+	collector.Finalize(ray, ps);
+
+	return collector.GetNumHits();
+}
+
+int JoltCollideShapeTransformed(JoltShape shape,
+                                float posX, float posY, float posZ,
+                                const JoltTransformedShape* targets, int numTargets,
+                                const JoltCollideShapeSettings* settings,
+                                JoltCollisionHit* outHits, int maxHits)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	if (!s)
+		return 0;
+
+	CollideShapeSettings collideSettings = ToCollideShapeSettings(settings);
+	AllHitsCollector collector(outHits, maxHits);
+
+	for (int i = 0; i < numTargets; ++i)
+	{
+		const TransformedShape* ts = static_cast<const TransformedShape*>(targets[i]);
+		int first = collector.GetNumHits();
+
+		// Hits get the target's body ID
+		ts->CollideShape(
+			s,
+			Vec3::sReplicate(1.0f),  // Scale
+			RMat44::sTranslation(RVec3(posX, posY, posZ)),  // Transform (position, no rotation)
+			collideSettings,
+			RVec3::sZero(),  // Base offset
+			collector
+		);
+
+		// Same flags as SetBodyFlags, the target is never a sensor
+		for (int h = first; h < collector.GetNumHits(); ++h)
+		{
+			JoltCollisionHit& hit = outHits[h];
+			hit.isSensor = 0;
+			hit.isBackFaceHit = 0;
+
+			SubShapeID subShapeID;
+			subShapeID.SetValue(hit.subShapeID2);
+			SubShapeID remainder;
+			const Shape* leaf = ts->mShape->GetLeafShape(subShapeID, remainder);
+			if (leaf == nullptr || leaf->GetSubType() != EShapeSubType::Mesh)
+				continue;
+
+			RVec3 contactPoint(hit.contactPointX, hit.contactPointY, hit.contactPointZ);
+			Vec3 triangleNormal = ts->GetWorldSpaceSurfaceNormal(subShapeID, contactPoint);
+			Vec3 normal(hit.normalX, hit.normalY, hit.normalZ);
+			hit.isBackFaceHit = normal.Dot(triangleNormal) < 0.0f ? 1 : 0;
+		}
+	}
+
+	return collector.GetNumHits();
+}
+
+int JoltCastRayTransformed(const JoltTransformedShape* targets, int numTargets,
+                           float originX, float originY, float originZ,
+                           float directionX, float directionY, float directionZ,
+                           JoltRaycastHit* outHits, int maxHits)
+{
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	// Gather the hits of every target, remembering which target each hit belongs to
+	struct TargetHit
+	{
+		RayCastResult result;
+		const TransformedShape* target;
+	};
+	std::vector<TargetHit> hits;
+	for (int i = 0; i < numTargets; ++i)
+	{
+		const TransformedShape* ts = static_cast<const TransformedShape*>(targets[i]);
+
+		AllHitCollisionCollector<CastRayCollector> collector;
+		ts->CastRay(ray, RayCastSettings(), collector);
+		for (const RayCastResult& result : collector.mHits)
+			hits.push_back({ result, ts });
+	}
+
+	// Sort hits by distance (fraction)
+	std::sort(hits.begin(), hits.end(),
+		[](const TargetHit& a, const TargetHit& b) {
+			return a.result.mFraction < b.result.mFraction;
+		});
+
+	int numToReturn = std::min(static_cast<int>(hits.size()), maxHits);
+	for (int i = 0; i < numToReturn; i++)
+	{
+		const RayCastResult& result = hits[i].result;
+		JoltRaycastHit& hit = outHits[i];
+
+		hit.bodyID = static_cast<JoltBodyID>(new BodyID(result.mBodyID));
+
+		RVec3 hitPoint = ray.GetPointOnRay(result.mFraction);
+		hit.hitPointX = static_cast<float>(hitPoint.GetX());
+		hit.hitPointY = static_cast<float>(hitPoint.GetY());
+		hit.hitPointZ = static_cast<float>(hitPoint.GetZ());
+
+		Vec3 normal = hits[i].target->GetWorldSpaceSurfaceNormal(result.mSubShapeID2, hitPoint);
+		hit.normalX = normal.GetX();
+		hit.normalY = normal.GetY();
+		hit.normalZ = normal.GetZ();
+
+		hit.fraction = result.mFraction;
+		hit.subShapeID2 = result.mSubShapeID2.GetValue();
+	}
+
+	return numToReturn;
+}
+
+int JoltCastRayAgainstBody(JoltBodyInterface bodyInterface, JoltBodyID bodyID,
+                           float originX, float originY, float originZ,
+                           float directionX, float directionY, float directionZ,
+                           JoltRaycastHit* outHit)
+{
+	const BodyInterface* bi = static_cast<const BodyInterface*>(bodyInterface);
+	const BodyID* bid = static_cast<const BodyID*>(bodyID);
+
+	// Snapshot of the body's shape and transform (empty if the body doesn't exist)
+	TransformedShape ts = bi->GetTransformedShape(*bid);
+	if (ts.mShape == nullptr)
+		return 0;
+
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	// Cast against this body only
+	RayCastResult result;
+	if (!ts.CastRay(ray, result))
+		return 0;
+
+	if (outHit != nullptr)
+	{
+		// Store body ID
+		BodyID* bodyIDCopy = new BodyID(*bid);
+		outHit->bodyID = static_cast<JoltBodyID>(bodyIDCopy);
+
+		// Calculate hit point
+		RVec3 hitPoint = ray.GetPointOnRay(result.mFraction);
+		outHit->hitPointX = static_cast<float>(hitPoint.GetX());
+		outHit->hitPointY = static_cast<float>(hitPoint.GetY());
+		outHit->hitPointZ = static_cast<float>(hitPoint.GetZ());
+
+		// Get surface normal from the transformed shape
+		Vec3 normal = ts.GetWorldSpaceSurfaceNormal(result.mSubShapeID2, hitPoint);
+		outHit->normalX = normal.GetX();
+		outHit->normalY = normal.GetY();
+		outHit->normalZ = normal.GetZ();
+
+		// Store fraction
+		outHit->fraction = result.mFraction;
+		outHit->subShapeID2 = result.mSubShapeID2.GetValue();
+	}
+
+	return 1;
+}
+
+int JoltCastRayThrough(JoltPhysicsSystem system,
+                       float originX, float originY, float originZ,
+                       float directionX, float directionY, float directionZ,
+                       JoltRaycastHit* outEntry, JoltRaycastHit* outExit)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	// Create the ray
+	RRayCast ray;
+	ray.mOrigin = RVec3(originX, originY, originZ);
+	ray.mDirection = Vec3(directionX, directionY, directionZ);
+
+	// Find where the ray enters the first solid body
+	IgnoreSensorsBodyFilter bodyFilter;
+	if (!CastRayClosest(wrapper, ray, RayCastSettings(), bodyFilter, outEntry))
+		return 0;
+
+	BodyID* entryBodyID = static_cast<BodyID*>(outEntry->bodyID);
+	TransformedShape ts = ps->GetBodyInterface().GetTransformedShape(*entryBodyID);
+
+	// Continue the ray from just past the entry point and look for the first back face of that body
+	float length = ray.mDirection.Length();
+	float startFraction = outEntry->fraction + (length > 0.0f ? 1.0e-3f / length : 0.0f);
+	if (ts.mShape != nullptr && startFraction < 1.0f)
+	{
+		RRayCast exitRay;
+		exitRay.mOrigin = ray.GetPointOnRay(startFraction);
+		exitRay.mDirection = ray.mDirection * (1.0f - startFraction);
+
+		RayCastSettings settings;
+		settings.SetBackFaceMode(EBackFaceMode::CollideWithBackFaces);
+		settings.mTreatConvexAsSolid = false;
+
+		ClosestRayHitCollector collector;
+		ts.CastRay(exitRay, settings, collector);
+
+		if (collector.HasHit())
+		{
+			const RayCastResult& result = collector.GetClosestHit();
+			float fraction = startFraction + result.mFraction * (1.0f - startFraction);
+
+			outExit->bodyID = static_cast<JoltBodyID>(new BodyID(*entryBodyID));
+
+			RVec3 hitPoint = ray.GetPointOnRay(fraction);
+			outExit->hitPointX = static_cast<float>(hitPoint.GetX());
+			outExit->hitPointY = static_cast<float>(hitPoint.GetY());
+			outExit->hitPointZ = static_cast<float>(hitPoint.GetZ());
+
+			Vec3 normal = ts.GetWorldSpaceSurfaceNormal(result.mSubShapeID2, hitPoint);
+			outExit->normalX = normal.GetX();
+			outExit->normalY = normal.GetY();
+			outExit->normalZ = normal.GetZ();
+
+			outExit->fraction = fraction;
+			outExit->subShapeID2 = result.mSubShapeID2.GetValue();
+			return 1;
+		}
+	}
+
+	// The ray ends inside the body (or the body has no thickness)
+	delete entryBodyID;
+	outEntry->bodyID = nullptr;
+	return 0;
+}
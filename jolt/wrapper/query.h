@@ -7,6 +7,8 @@
 #ifndef JOLT_WRAPPER_QUERY_H
 #define JOLT_WRAPPER_QUERY_H
 
+#include <stdint.h>
+
 #ifdef __cplusplus
 extern "C" {
 #endif
@@ -15,6 +17,8 @@ extern "C" {
 typedef void* JoltPhysicsSystem;
 typedef void* JoltShape;
 typedef void* JoltBodyID;
+typedef void* JoltBodyInterface;
+typedef void* JoltTransformedShape;
 
 // Result structure for collision hits
 typedef struct {
@@ -23,6 +27,12 @@ typedef struct {
     float contactPointY;
     float contactPointZ;
     float penetrationDepth;
+    float normalX;          // Contact normal, direction to push the query shape out
+    float normalY;
+    float normalZ;
+    unsigned int subShapeID2; // Sub-shape ID of the hit body's shape
+    int isSensor;             // Non-zero if the hit body is a sensor
+    int isBackFaceHit;        // Non-zero if the hit is on the back of a mesh triangle
 } JoltCollisionHit;
 
 // Result structure for raycast hits
@@ -35,14 +45,48 @@ typedef struct {
     float normalY;
     float normalZ;
     float fraction;         // Fraction along the ray where hit occurred [0, 1]
+    unsigned int subShapeID2; // Sub-shape ID of the hit body's shape
 } JoltRaycastHit;
 
+// Result structure for shape cast hits
+typedef struct {
+    JoltBodyID bodyID;      // The body that was hit
+    float contactPointX;    // Contact point on the hit body in world space
+    float contactPointY;
+    float contactPointZ;
+    float normalX;          // Contact normal, direction to push the cast shape out
+    float normalY;
+    float normalZ;
+    float fraction;         // Fraction along the sweep where the hit occurred [0, 1]
+    float penetrationDepth; // Penetration depth at the hit (only non-zero when starting in collision)
+} JoltShapeCastHit;
+
+// Settings for shape collision queries (subset of Jolt's CollideShapeSettings)
+typedef struct {
+    int activeEdgeMode;          // 0 = collide only with active edges, 1 = collide with all edges
+    int backFaceMode;            // 0 = ignore back faces, 1 = collide with back faces
+    float penetrationTolerance;  // Accuracy of the penetration depth calculation
+    float maxSeparationDistance; // Also report shapes up to this distance apart (negative penetration depth)
+} JoltCollideShapeSettings;
+
 // Check if a shape at a position collides with anything in the physics system
 // Returns 1 if collision detected, 0 if no collision
 // penetrationTolerance: distance threshold for collision detection (use 0 for default)
 int JoltCollideShape(JoltPhysicsSystem system, JoltShape shape,
                      float posX, float posY, float posZ, float penetrationTolerance);
 
+// Check if a shape at a position collides with anything, with explicit collide settings
+// Returns 1 if collision detected, 0 if no collision
+int JoltCollideShapeWithSettings(JoltPhysicsSystem system, JoltShape shape,
+                                 float posX, float posY, float posZ,
+                                 const JoltCollideShapeSettings* settings);
+
+// Count the bodies a shape at a position overlaps, without building hit results
+// Returns the number of distinct bodies hit
+int JoltCountOverlaps(JoltPhysicsSystem system, JoltShape shape,
+                      float posX, float posY, float posZ,
+                      const JoltCollideShapeSettings* settings);
+
 // Get all collision hits for a shape at a position
 // outHits: array to store results (allocated by caller)
 // maxHits: maximum number of hits to return
@@ -52,6 +96,56 @@ int JoltCollideShapeGetHits(JoltPhysicsSystem system, JoltShape shape,
                             float posX, float posY, float posZ,
                             JoltCollisionHit* outHits, int maxHits, float penetrationTolerance);
 
+// Get all collision hits for a shape at a position, with explicit collide settings
+// outHits: array to store results (allocated by caller)
+// maxHits: maximum number of hits to return
+// Returns: actual number of hits found (may be less than maxHits)
+int JoltCollideShapeGetHitsWithSettings(JoltPhysicsSystem system, JoltShape shape,
+                                        float posX, float posY, float posZ,
+                                        const JoltCollideShapeSettings* settings,
+                                        JoltCollisionHit* outHits, int maxHits);
+
+// Get the deepest collision hit for a shape at a position
+// Cheaper than JoltCollideShapeGetHits since it can stop early on shallower hits
+// outHit: pointer to store the deepest hit (bodyID must be destroyed by caller)
+// Returns 1 if collision detected, 0 if no collision
+int JoltCollideShapeDeepest(JoltPhysicsSystem system, JoltShape shape,
+                            float posX, float posY, float posZ,
+                            JoltCollisionHit* outHit);
+
+// Apply an outward impulse to all dynamic bodies overlapping a sphere (e.g. an explosion)
+// The impulse points from the center to each body's center of mass, scaled by strength
+// and falling off linearly to zero at the radius
+// Returns: number of bodies that received a non-zero impulse, 0 if radius is not positive
+int JoltApplyRadialImpulse(JoltPhysicsSystem system,
+                           float centerX, float centerY, float centerZ,
+                           float radius, float strength);
+
+// Sweep a shape from a position along a direction and get the closest hit
+// The length of the direction determines the sweep distance
+// outHit: pointer to store the closest hit (bodyID must be destroyed by caller)
+// Returns 1 if hit detected, 0 if no hit
+int JoltCastShape(JoltPhysicsSystem system, JoltShape shape,
+                  float posX, float posY, float posZ,
+                  float directionX, float directionY, float directionZ,
+                  JoltShapeCastHit* outHit);
+
+// Sweep a sphere straight down to find the ground below a position
+// up: unit length world up direction, the sphere sweeps along -up
+// radius: radius of the probe sphere, centered at the position
+// maxDistance: how far down to sweep
+// outGroundPos: contact point on the ground
+// outGroundNormal: ground surface normal
+// outDistance: distance the sphere travelled before touching the ground
+// Returns 1 if ground was found within maxDistance, 0 otherwise or if radius isn't positive or maxDistance is negative
+int JoltProbeGround(JoltPhysicsSystem system,
+                    float posX, float posY, float posZ,
+                    float upX, float upY, float upZ,
+                    float radius, float maxDistance,
+                    float* outGroundPosX, float* outGroundPosY, float* outGroundPosZ,
+                    float* outGroundNormalX, float* outGroundNormalY, float* outGroundNormalZ,
+                    float* outDistance);
+
 // Cast a ray and check if it hits anything
 // Returns 1 if hit detected, 0 if no hit
 // outHit: pointer to store the closest hit result (can be NULL if you only need hit/no-hit)
@@ -60,6 +154,43 @@ int JoltCastRay(JoltPhysicsSystem system,
                 float directionX, float directionY, float directionZ,
                 JoltRaycastHit* outHit);
 
+// Cast a ray with explicit raycast settings and check if it hits anything
+// backfaceMode: 0 = ignore back faces, 1 = collide with back faces
+// treatConvexAsSolid: if non-zero, rays starting inside a convex shape hit at fraction 0
+// Returns 1 if hit detected, 0 if no hit
+// outHit: pointer to store the closest hit result (can be NULL if you only need hit/no-hit)
+int JoltCastRayWithSettings(JoltPhysicsSystem system,
+                            float originX, float originY, float originZ,
+                            float directionX, float directionY, float directionZ,
+                            int backfaceMode, int treatConvexAsSolid,
+                            JoltRaycastHit* outHit);
+
+// Cast a ray and return the closest hit on a body accepted by a Go predicate
+// filter: cgo.Handle of the Go predicate, called via goJoltBodyFilterAccept for every candidate body
+// Returns 1 if hit detected, 0 if no hit
+// outHit: pointer to store the closest hit result (can be NULL if you only need hit/no-hit)
+int JoltCastRayFiltered(JoltPhysicsSystem system,
+                        float originX, float originY, float originZ,
+                        float directionX, float directionY, float directionZ,
+                        uintptr_t filter,
+                        JoltRaycastHit* outHit);
+
+// Cast a ray that passes through sensor bodies and return the closest solid hit
+// Returns 1 if hit detected, 0 if no hit
+// outHit: pointer to store the closest hit result (can be NULL if you only need hit/no-hit)
+int JoltCastRaySolid(JoltPhysicsSystem system,
+                     float originX, float originY, float originZ,
+                     float directionX, float directionY, float directionZ,
+                     JoltRaycastHit* outHit);
+
+// Cast many rays in one call and get the closest hit of each ray
+// origins, directions: arrays of numRays * 3 floats
+// outHits: array of numRays results (allocated by caller), bodyID is NULL for rays that missed
+// Returns: number of rays that hit something
+int JoltCastRaysBatch(JoltPhysicsSystem system,
+                      const float* origins, const float* directions, int numRays,
+                      JoltRaycastHit* outHits);
+
 // Cast a ray and get all hits along the ray (sorted by distance)
 // outHits: array to store results (allocated by caller)
 // maxHits: maximum number of hits to return
@@ -69,8 +200,64 @@ int JoltCastRayGetHits(JoltPhysicsSystem system,
                        float directionX, float directionY, float directionZ,
                        JoltRaycastHit* outHits, int maxHits);
 
+// Cast a ray and get all hits along the ray (sorted by distance), skipping excluded bodies and layers
+// excludeBodyIDs: array of numExclude bodies the ray passes through (can be NULL if numExclude is 0)
+// layerMask: bit (1 << layer) set for every object layer the ray can hit
+// outHits: array to store results (allocated by caller)
+// maxHits: maximum number of hits to return
+// Returns: actual number of hits found (may be less than maxHits)
+int JoltCastRayGetHitsFiltered(JoltPhysicsSystem system,
+                               float originX, float originY, float originZ,
+                               float directionX, float directionY, float directionZ,
+                               const JoltBodyID* excludeBodyIDs, int numExclude,
+                               unsigned int layerMask,
+                               JoltRaycastHit* outHits, int maxHits);
+
+// Get all collision hits of a shape at a position against a list of transformed shapes (not in any physics system)
+// targets: array of numTargets transformed shapes
+// outHits: array to store results (allocated by caller), bodyID is a copy of the body ID of the target that was hit
+// maxHits: maximum number of hits to return
+// Returns: actual number of hits found (may be less than maxHits)
+int JoltCollideShapeTransformed(JoltShape shape,
+                                float posX, float posY, float posZ,
+                                const JoltTransformedShape* targets, int numTargets,
+                                const JoltCollideShapeSettings* settings,
+                                JoltCollisionHit* outHits, int maxHits);
+
+// Cast a ray against a list of transformed shapes (not in any physics system) and get all hits (sorted by distance)
+// targets: array of numTargets transformed shapes
+// outHits: array to store results (allocated by caller), bodyID is a copy of the body ID of the target that was hit
+// maxHits: maximum number of hits to return
+// Returns: actual number of hits found (may be less than maxHits)
+int JoltCastRayTransformed(const JoltTransformedShape* targets, int numTargets,
+                           float originX, float originY, float originZ,
+                           float directionX, float directionY, float directionZ,
+                           JoltRaycastHit* outHits, int maxHits);
+
+// Cast a ray against a single body, ignoring all other bodies
+// Returns 1 if hit detected, 0 if no hit
+// outHit: pointer to store the hit result (can be NULL if you only need hit/no-hit)
+int JoltCastRayAgainstBody(JoltBodyInterface bodyInterface, JoltBodyID bodyID,
+                           float originX, float originY, float originZ,
+                           float directionX, float directionY, float directionZ,
+                           JoltRaycastHit* outHit);
+
+// Cast a ray through the first solid body it hits, passing through sensors
+// Returns 1 if the ray both enters and exits the body, 0 otherwise
+// outEntry: receives the hit where the ray enters the body
+// outExit: receives the hit where the ray leaves the body, its normal points along the ray
+int JoltCastRayThrough(JoltPhysicsSystem system,
+                       float originX, float originY, float originZ,
+                       float directionX, float directionY, float directionZ,
+                       JoltRaycastHit* outEntry, JoltRaycastHit* outExit);
+
 #ifdef __cplusplus
 }
+
+// Implemented in Go (query.go): calls the predicate of a filtered query
+// Returns non-zero if the body can be hit
+extern "C" int goJoltBodyFilterAccept(uintptr_t filter, JoltBodyID bodyID, unsigned long long userData);
+
 #endif
 
 #endif // JOLT_WRAPPER_QUERY_H
@@ -9,35 +9,52 @@
 #include <Jolt/Physics/Collision/Shape/CapsuleShape.h>
 #include <Jolt/Physics/Collision/Shape/ConvexHullShape.h>
 #include <Jolt/Physics/Collision/Shape/MeshShape.h>
+#include <Jolt/Physics/Collision/Shape/RotatedTranslatedShape.h>
+#include <Jolt/Physics/Collision/Shape/ScaledShape.h>
+#include <Jolt/Physics/Collision/Shape/DecoratedShape.h>
+#include <Jolt/Physics/Collision/Shape/MutableCompoundShape.h>
 #include <Jolt/Physics/Collision/RayCast.h>
 #include <Jolt/Physics/Collision/CastResult.h>
 #include <Jolt/Physics/Collision/Shape/SubShapeID.h>
 #include <Jolt/Physics/Collision/TransformedShape.h>
+#include <Jolt/Geometry/ConvexHullBuilder.h>
+#include <Jolt/Physics/Collision/PhysicsMaterialSimple.h>
+#include <Jolt/Core/StringTools.h>
+#include <unordered_map>
 
 using namespace JPH;
 
-JoltShape JoltCreateSphere(float radius)
+// Converts a shape creation result to a handle, reporting errors through Jolt's Trace
+// Returns NULL if the shape could not be created
+static JoltShape ToJoltShape(const ShapeSettings::ShapeResult& result)
 {
-	SphereShapeSettings sphere_settings(radius);
-	ShapeSettings::ShapeResult sphere_result = sphere_settings.Create();
+	if (result.HasError())
+	{
+		Trace("Failed to create shape: %s", result.GetError().c_str());
+		return nullptr;
+	}
 
 	// Shapes are ref-counted, AddRef to keep it alive
-	ShapeRefC shape = sphere_result.Get();
+	ShapeRefC shape = result.Get();
 	shape->AddRef();
 
 	return static_cast<JoltShape>(const_cast<Shape*>(shape.GetPtr()));
 }
 
+JoltShape JoltCreateSphere(float radius)
+{
+	SphereShapeSettings sphere_settings(radius);
+	ShapeSettings::ShapeResult sphere_result = sphere_settings.Create();
+
+	return ToJoltShape(sphere_result);
+}
+
 JoltShape JoltCreateBox(float halfExtentX, float halfExtentY, float halfExtentZ)
 {
 	BoxShapeSettings box_settings(Vec3(halfExtentX, halfExtentY, halfExtentZ));
 	ShapeSettings::ShapeResult box_result = box_settings.Create();
 
-	// Shapes are ref-counted, AddRef to keep it alive
-	ShapeRefC shape = box_result.Get();
-	shape->AddRef();
-
-	return static_cast<JoltShape>(const_cast<Shape*>(shape.GetPtr()));
+	return ToJoltShape(box_result);
 }
 
 JoltShape JoltCreateCapsule(float halfHeight, float radius)
@@ -45,11 +62,27 @@ JoltShape JoltCreateCapsule(float halfHeight, float radius)
 	CapsuleShapeSettings capsule_settings(halfHeight, radius);
 	ShapeSettings::ShapeResult capsule_result = capsule_settings.Create();
 
-	// Shapes are ref-counted, AddRef to keep it alive
-	ShapeRefC shape = capsule_result.Get();
-	shape->AddRef();
+	return ToJoltShape(capsule_result);
+}
 
-	return static_cast<JoltShape>(const_cast<Shape*>(shape.GetPtr()));
+JoltShape JoltCreateCapsuleAtFeet(float halfHeight, float radius)
+{
+	// Shift the capsule up so the bottom of the lower cap sits at the origin
+	RefConst<ShapeSettings> capsule_settings = new CapsuleShapeSettings(halfHeight, radius);
+	RotatedTranslatedShapeSettings feet_settings(Vec3(0, halfHeight + radius, 0), Quat::sIdentity(), capsule_settings);
+	ShapeSettings::ShapeResult feet_result = feet_settings.Create();
+
+	return ToJoltShape(feet_result);
+}
+
+JoltShape JoltCreateScaledShape(JoltShape shape, float scaleX, float scaleY, float scaleZ)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+
+	ScaledShapeSettings scaled_settings(s, Vec3(scaleX, scaleY, scaleZ));
+	ShapeSettings::ShapeResult scaled_result = scaled_settings.Create();
+
+	return ToJoltShape(scaled_result);
 }
 
 JoltShape JoltCreateConvexHull(const float* points, int numPoints)
@@ -64,11 +97,53 @@ JoltShape JoltCreateConvexHull(const float* points, int numPoints)
 	ConvexHullShapeSettings hull_settings(vertices);
 	ShapeSettings::ShapeResult hull_result = hull_settings.Create();
 
-	// Shapes are ref-counted, AddRef to keep it alive
-	ShapeRefC shape = hull_result.Get();
-	shape->AddRef();
+	return ToJoltShape(hull_result);
+}
 
-	return static_cast<JoltShape>(const_cast<Shape*>(shape.GetPtr()));
+JoltShape JoltCreateConvexHullWithMaxVertices(const float* points, int numPoints, int maxVertices)
+{
+	// Convert float array to Vec3 array
+	Array<Vec3> vertices;
+	vertices.reserve(numPoints);
+	for (int i = 0; i < numPoints; ++i) {
+		vertices.push_back(Vec3(points[i * 3], points[i * 3 + 1], points[i * 3 + 2]));
+	}
+
+	ConvexHullShapeSettings hull_settings;
+
+	// Build a reduced hull first, the shape settings always allow the full 256 vertices
+	ConvexHullBuilder builder(vertices);
+	const char* error = nullptr;
+	ConvexHullBuilder::EResult build_result = builder.Initialize(maxVertices, hull_settings.mHullTolerance, error);
+	if (build_result != ConvexHullBuilder::EResult::Success
+		&& build_result != ConvexHullBuilder::EResult::MaxVerticesReached)
+	{
+		Trace("Failed to create shape: %s", error != nullptr ? error : "convex hull build failed");
+		return nullptr;
+	}
+
+	// Collect the points that ended up on the hull
+	Array<bool> used(vertices.size(), false);
+	for (const ConvexHullBuilder::Face* face : builder.GetFaces())
+	{
+		if (face->mRemoved) continue;
+
+		const ConvexHullBuilder::Edge* edge = face->mFirstEdge;
+		do {
+			used[edge->mStartIdx] = true;
+			edge = edge->mNextEdge;
+		} while (edge != face->mFirstEdge);
+	}
+
+	for (size_t i = 0; i < vertices.size(); ++i) {
+		if (used[i]) {
+			hull_settings.mPoints.push_back(vertices[i]);
+		}
+	}
+
+	ShapeSettings::ShapeResult hull_result = hull_settings.Create();
+
+	return ToJoltShape(hull_result);
 }
 
 JoltShape JoltCreateMesh(const float* vertices, int numVertices,
@@ -93,16 +168,92 @@ JoltShape JoltCreateMesh(const float* vertices, int numVertices,
 	MeshShapeSettings mesh_settings(triangles);
 	ShapeSettings::ShapeResult mesh_result = mesh_settings.Create();
 
-	// Shapes are ref-counted, AddRef to keep it alive
-	ShapeRefC shape = mesh_result.Get();
-	shape->AddRef();
+	return ToJoltShape(mesh_result);
+}
 
-	return static_cast<JoltShape>(const_cast<Shape*>(shape.GetPtr()));
+// Materials shared by all meshes created with JoltCreateMeshWithMaterials, the index in this
+// list is the material index, so a contact's material can be mapped back to it.
+// Created once with the maximum a mesh supports and never changed, so lookups need no lock.
+struct IndexedMaterials
+{
+	PhysicsMaterialList materials;
+	std::unordered_map<const PhysicsMaterial*, int> indices;
+};
+
+static const IndexedMaterials& GetIndexedMaterials()
+{
+	static const IndexedMaterials sIndexedMaterials = [] {
+		IndexedMaterials indexed;
+		indexed.materials.reserve(JOLT_MAX_MESH_MATERIALS);
+		for (int i = 0; i < JOLT_MAX_MESH_MATERIALS; ++i)
+		{
+			String name = "Material " + ConvertToString(i);
+			Ref<PhysicsMaterial> material = new PhysicsMaterialSimple(name, Color::sGrey);
+			indexed.indices[material.GetPtr()] = i;
+			indexed.materials.push_back(material);
+		}
+		return indexed;
+	}();
+	return sIndexedMaterials;
+}
+
+int GetMaterialIndex(const PhysicsMaterial* material)
+{
+	const IndexedMaterials& indexed = GetIndexedMaterials();
+	auto it = indexed.indices.find(material);
+	return it != indexed.indices.end() ? it->second : 0;
+}
+
+JoltShape JoltCreateMeshWithMaterials(const float* vertices, int numVertices,
+									  const int* indices, int numIndices,
+									  const int* materialIndices)
+{
+	if (numVertices <= 0 || numIndices <= 0 || numIndices % 3 != 0)
+	{
+		return nullptr;
+	}
+
+	TriangleList triangles;
+	triangles.reserve(numIndices / 3);
+
+	uint numMaterials = 1;
+	for (int i = 0; i < numIndices; i += 3) {
+		int i0 = indices[i];
+		int i1 = indices[i + 1];
+		int i2 = indices[i + 2];
+		int material = materialIndices[i / 3];
+
+		// Out of range indices would read past the vertices, Jolt only supports a few materials per mesh
+		if (i0 < 0 || i0 >= numVertices || i1 < 0 || i1 >= numVertices || i2 < 0 || i2 >= numVertices
+			|| material < 0 || material >= JOLT_MAX_MESH_MATERIALS)
+		{
+			return nullptr;
+		}
+
+		Triangle tri(Float3(vertices[i0 * 3], vertices[i0 * 3 + 1], vertices[i0 * 3 + 2]),
+					 Float3(vertices[i1 * 3], vertices[i1 * 3 + 1], vertices[i1 * 3 + 2]),
+					 Float3(vertices[i2 * 3], vertices[i2 * 3 + 1], vertices[i2 * 3 + 2]),
+					 static_cast<uint>(material));
+		triangles.push_back(tri);
+
+		if (static_cast<uint>(material) + 1 > numMaterials) {
+			numMaterials = static_cast<uint>(material) + 1;
+		}
+	}
+
+	const PhysicsMaterialList& indexed = GetIndexedMaterials().materials;
+	PhysicsMaterialList materials(indexed.begin(), indexed.begin() + numMaterials);
+
+	MeshShapeSettings mesh_settings(triangles, std::move(materials));
+	ShapeSettings::ShapeResult mesh_result = mesh_settings.Create();
+
+	return ToJoltShape(mesh_result);
 }
 
 void JoltDestroyShape(JoltShape shape)
 {
 	Shape* s = static_cast<Shape*>(shape);
+	if (!s) return;
 	s->Release();
 }
 
@@ -110,7 +261,8 @@ int JoltShapeCastRay(JoltShape shape,
                      float originX, float originY, float originZ,
                      float directionX, float directionY, float directionZ,
                      int backfaceMode, int treatConvexAsSolid,
-                     float* outFraction)
+                     float* outFraction,
+                     float* outNormalX, float* outNormalY, float* outNormalZ)
 {
 	Shape* s = static_cast<Shape*>(shape);
 	if (!s) return 0;
@@ -125,6 +277,12 @@ int JoltShapeCastRay(JoltShape shape,
 	RayCastResult result;
 	if (s->CastRay(ray, sub_shape_creator, result)) {
 		*outFraction = result.mFraction;
+
+		// The ray and the surface normal are both relative to the shape's center of mass
+		Vec3 normal = s->GetSurfaceNormal(result.mSubShapeID2, ray.GetPointOnRay(result.mFraction));
+		*outNormalX = normal.GetX();
+		*outNormalY = normal.GetY();
+		*outNormalZ = normal.GetZ();
 		return 1; // Hit
 	}
 
@@ -159,7 +317,8 @@ void JoltDestroyTransformedShape(JoltTransformedShape transformedShape)
 int JoltTransformedShapeCastRay(JoltTransformedShape transformedShape,
                                  float originX, float originY, float originZ,
                                  float directionX, float directionY, float directionZ,
-                                 float* outFraction)
+                                 float* outFraction,
+                                 float* outNormalX, float* outNormalY, float* outNormalZ)
 {
 	TransformedShape* ts = static_cast<TransformedShape*>(transformedShape);
 	if (!ts) return 0;
@@ -171,8 +330,231 @@ int JoltTransformedShapeCastRay(JoltTransformedShape transformedShape,
 	RayCastResult result;
 	if (ts->CastRay(ray, result)) {
 		*outFraction = result.mFraction;
+
+		Vec3 normal = ts->GetWorldSpaceSurfaceNormal(result.mSubShapeID2, ray.GetPointOnRay(result.mFraction));
+		*outNormalX = normal.GetX();
+		*outNormalY = normal.GetY();
+		*outNormalZ = normal.GetZ();
 		return 1; // Hit
 	}
 
 	return 0; // No hit
 }
+
+// Walks the shape's triangles, copying up to maxTriangles into outVertices (may be NULL to only count)
+static int CollectShapeTriangles(const Shape* s, float* outVertices, int maxTriangles)
+{
+	Shape::GetTrianglesContext context;
+	s->GetTrianglesStart(context, AABox::sBiggest(), s->GetCenterOfMass(), Quat::sIdentity(), Vec3::sOne());
+
+	// GetTrianglesNext requires room for at least cGetTrianglesMinTrianglesRequested triangles
+	Float3 buffer[Shape::cGetTrianglesMinTrianglesRequested * 3];
+	int total = 0;
+	for (;;)
+	{
+		int count = s->GetTrianglesNext(context, Shape::cGetTrianglesMinTrianglesRequested, buffer);
+		if (count == 0)
+			break;
+
+		for (int i = 0; i < count; ++i)
+		{
+			if (outVertices != nullptr && total >= maxTriangles)
+				return total;
+
+			if (outVertices != nullptr)
+			{
+				for (int v = 0; v < 3; ++v)
+				{
+					const Float3& vertex = buffer[i * 3 + v];
+					outVertices[total * 9 + v * 3] = vertex.x;
+					outVertices[total * 9 + v * 3 + 1] = vertex.y;
+					outVertices[total * 9 + v * 3 + 2] = vertex.z;
+				}
+			}
+			total++;
+		}
+	}
+
+	return total;
+}
+
+int JoltShapeGetTriangleCount(JoltShape shape)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	if (!s) return 0;
+
+	return CollectShapeTriangles(s, nullptr, 0);
+}
+
+int JoltShapeGetTriangles(JoltShape shape, float* outVertices, int maxTriangles)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	if (!s || maxTriangles <= 0) return 0;
+
+	return CollectShapeTriangles(s, outVertices, maxTriangles);
+}
+
+void JoltShapeGetLocalBounds(JoltShape shape,
+							 float* outMinX, float* outMinY, float* outMinZ,
+							 float* outMaxX, float* outMaxY, float* outMaxZ)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	AABox bounds = s->GetLocalBounds();
+
+	*outMinX = bounds.mMin.GetX();
+	*outMinY = bounds.mMin.GetY();
+	*outMinZ = bounds.mMin.GetZ();
+	*outMaxX = bounds.mMax.GetX();
+	*outMaxY = bounds.mMax.GetY();
+	*outMaxZ = bounds.mMax.GetZ();
+}
+
+void JoltShapeGetMassProperties(JoltShape shape,
+								float* outMass,
+								float* outCenterOfMass,
+								float* outInertia)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	MassProperties properties = s->GetMassProperties();
+
+	*outMass = properties.mMass;
+
+	Vec3 com = s->GetCenterOfMass();
+	outCenterOfMass[0] = com.GetX();
+	outCenterOfMass[1] = com.GetY();
+	outCenterOfMass[2] = com.GetZ();
+
+	for (int col = 0; col < 3; ++col)
+	{
+		for (int row = 0; row < 3; ++row)
+		{
+			outInertia[col * 3 + row] = properties.mInertia(row, col);
+		}
+	}
+}
+
+JoltShapeType JoltShapeGetType(JoltShape shape)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+
+	switch (s->GetSubType())
+	{
+	case EShapeSubType::Sphere:
+		return JoltShapeTypeSphere;
+	case EShapeSubType::Box:
+		return JoltShapeTypeBox;
+	case EShapeSubType::Capsule:
+		return JoltShapeTypeCapsule;
+	case EShapeSubType::ConvexHull:
+		return JoltShapeTypeConvexHull;
+	case EShapeSubType::Mesh:
+		return JoltShapeTypeMesh;
+	default:
+		break;
+	}
+
+	switch (s->GetType())
+	{
+	case EShapeType::Compound:
+		return JoltShapeTypeCompound;
+	case EShapeType::Decorated:
+		return JoltShapeTypeDecorated;
+	default:
+		return JoltShapeTypeOther;
+	}
+}
+
+int JoltShapeIsConvex(JoltShape shape)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+
+	// Decorated shapes (e.g. a capsule at feet) are convex if the shape they wrap is
+	while (s->GetType() == EShapeType::Decorated)
+	{
+		s = static_cast<const DecoratedShape*>(s)->GetInnerShape();
+	}
+
+	return s->GetType() == EShapeType::Convex ? 1 : 0;
+}
+
+int JoltConvexHullShapeGetNumPoints(JoltShape shape)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	if (s->GetSubType() != EShapeSubType::ConvexHull)
+		return 0;
+
+	return static_cast<int>(static_cast<const ConvexHullShape*>(s)->GetNumPoints());
+}
+
+int JoltConvexHullShapeGetPoints(JoltShape shape, float* outPoints, int maxPoints)
+{
+	const Shape* s = static_cast<const Shape*>(shape);
+	if (s->GetSubType() != EShapeSubType::ConvexHull)
+		return 0;
+
+	const ConvexHullShape* hull = static_cast<const ConvexHullShape*>(s);
+	int count = min(static_cast<int>(hull->GetNumPoints()), maxPoints);
+
+	// Hull points are stored relative to the center of mass
+	Vec3 com = hull->GetCenterOfMass();
+	for (int i = 0; i < count; ++i)
+	{
+		Vec3 point = hull->GetPoint(i) + com;
+		outPoints[i * 3] = point.GetX();
+		outPoints[i * 3 + 1] = point.GetY();
+		outPoints[i * 3 + 2] = point.GetZ();
+	}
+
+	return count;
+}
+
+JoltShape JoltCreateMutableCompoundShape()
+{
+	MutableCompoundShapeSettings compound_settings;
+	ShapeSettings::ShapeResult compound_result = compound_settings.Create();
+
+	return ToJoltShape(compound_result);
+}
+
+int JoltMutableCompoundShapeAddShape(JoltShape compound, JoltShape subShape,
+                                     float posX, float posY, float posZ,
+                                     float rotX, float rotY, float rotZ, float rotW)
+{
+	MutableCompoundShape* c = static_cast<MutableCompoundShape*>(compound);
+	const Shape* s = static_cast<const Shape*>(subShape);
+
+	uint index = c->AddShape(Vec3(posX, posY, posZ), Quat(rotX, rotY, rotZ, rotW).Normalized(), s);
+	return static_cast<int>(index);
+}
+
+int JoltMutableCompoundShapeRemoveShape(JoltShape compound, int index)
+{
+	MutableCompoundShape* c = static_cast<MutableCompoundShape*>(compound);
+	if (index < 0 || index >= static_cast<int>(c->GetNumSubShapes()))
+	{
+		return 0;
+	}
+
+	c->RemoveShape(static_cast<uint>(index));
+	return 1;
+}
+
+int JoltMutableCompoundShapeModifyShape(JoltShape compound, int index,
+                                        float posX, float posY, float posZ,
+                                        float rotX, float rotY, float rotZ, float rotW)
+{
+	MutableCompoundShape* c = static_cast<MutableCompoundShape*>(compound);
+	if (index < 0 || index >= static_cast<int>(c->GetNumSubShapes()))
+	{
+		return 0;
+	}
+
+	c->ModifyShape(static_cast<uint>(index), Vec3(posX, posY, posZ), Quat(rotX, rotY, rotZ, rotW).Normalized());
+	return 1;
+}
+
+int JoltMutableCompoundShapeGetNumShapes(JoltShape compound)
+{
+	const MutableCompoundShape* c = static_cast<const MutableCompoundShape*>(compound);
+	return static_cast<int>(c->GetNumSubShapes());
+}
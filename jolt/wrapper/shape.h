@@ -15,6 +15,18 @@ extern "C" {
 typedef void* JoltShape;
 typedef void* JoltTransformedShape;
 
+// Shape type enum (groups Jolt's EShapeSubType)
+typedef enum {
+    JoltShapeTypeSphere = 0,
+    JoltShapeTypeBox = 1,
+    JoltShapeTypeCapsule = 2,
+    JoltShapeTypeConvexHull = 3,
+    JoltShapeTypeMesh = 4,
+    JoltShapeTypeCompound = 5,  // Static or mutable compound of several shapes
+    JoltShapeTypeDecorated = 6, // Wraps another shape (e.g. rotated/translated or scaled)
+    JoltShapeTypeOther = 7      // Any other shape type
+} JoltShapeType;
+
 // Create a sphere shape
 JoltShape JoltCreateSphere(float radius);
 
@@ -24,24 +36,47 @@ JoltShape JoltCreateBox(float halfExtentX, float halfExtentY, float halfExtentZ)
 // Create a capsule shape
 JoltShape JoltCreateCapsule(float halfHeight, float radius);
 
+// Create a capsule shape with its origin at the bottom of the lower cap
+JoltShape JoltCreateCapsuleAtFeet(float halfHeight, float radius);
+
 // Create a convex hull shape from an array of points
 JoltShape JoltCreateConvexHull(const float* points, int numPoints);
 
+// Create a convex hull shape from an array of points, keeping at most maxVertices hull vertices
+// The hull is simplified by only adding the points that contribute most to its volume
+JoltShape JoltCreateConvexHullWithMaxVertices(const float* points, int numPoints, int maxVertices);
+
+// Create a shape that scales another shape (adds a reference to shape)
+// Returns NULL if the scale is not valid (e.g. a zero component)
+JoltShape JoltCreateScaledShape(JoltShape shape, float scaleX, float scaleY, float scaleZ);
+
 // Create a mesh shape from vertices and indices
 JoltShape JoltCreateMesh(const float* vertices, int numVertices,
                                const int* indices, int numIndices);
 
+// Maximum number of materials in a mesh (Jolt stores the material index in 5 bits per triangle)
+#define JOLT_MAX_MESH_MATERIALS 32
+
+// Create a mesh shape where each triangle has a material index
+// materialIndices: one index per triangle (numIndices / 3 entries), each in [0, JOLT_MAX_MESH_MATERIALS)
+// Returns NULL if an index is out of range or Jolt fails to build the mesh
+JoltShape JoltCreateMeshWithMaterials(const float* vertices, int numVertices,
+                                      const int* indices, int numIndices,
+                                      const int* materialIndices);
+
 // Destroy a shape
 void JoltDestroyShape(JoltShape shape);
 
 // Cast a ray against a shape
 // Returns 1 if hit, 0 if miss
 // outFraction: receives the fraction along the ray where the hit occurred [0, 1]
+// outNormal: receives the surface normal at the hit point in the shape's local space
 int JoltShapeCastRay(JoltShape shape,
                      float originX, float originY, float originZ,
                      float directionX, float directionY, float directionZ,
                      int backfaceMode, int treatConvexAsSolid,
-                     float* outFraction);
+                     float* outFraction,
+                     float* outNormalX, float* outNormalY, float* outNormalZ);
 
 // Create a transformed shape (combines shape with position and rotation)
 // bodyID: use 0 if no associated body
@@ -56,13 +91,84 @@ void JoltDestroyTransformedShape(JoltTransformedShape transformedShape);
 // Cast a ray against a transformed shape in world space
 // Returns 1 if hit, 0 if miss
 // outFraction: receives the fraction along the ray where the hit occurred [0, 1]
+// outNormal: receives the world space surface normal at the hit point
 int JoltTransformedShapeCastRay(JoltTransformedShape transformedShape,
                                  float originX, float originY, float originZ,
                                  float directionX, float directionY, float directionZ,
-                                 float* outFraction);
+                                 float* outFraction,
+                                 float* outNormalX, float* outNormalY, float* outNormalZ);
+
+// Get the number of triangles in the debug triangulation of a shape
+int JoltShapeGetTriangleCount(JoltShape shape);
+
+// Get the debug triangulation of a shape in local space
+// outVertices: array of maxTriangles * 9 floats (3 vertices per triangle, allocated by caller)
+// Returns: actual number of triangles written
+int JoltShapeGetTriangles(JoltShape shape, float* outVertices, int maxTriangles);
+
+// Get the local space bounding box of a shape
+void JoltShapeGetLocalBounds(JoltShape shape,
+                             float* outMinX, float* outMinY, float* outMinZ,
+                             float* outMaxX, float* outMaxY, float* outMaxZ);
+
+// Get the mass properties a dynamic body with this shape gets (using the shape's density)
+// outCenterOfMass: 3 floats, center of mass in local space
+// outInertia: 9 floats, inertia tensor around the center of mass in local space (column-major)
+void JoltShapeGetMassProperties(JoltShape shape,
+                                float* outMass,
+                                float* outCenterOfMass,
+                                float* outInertia);
+
+// Get the type of a shape
+JoltShapeType JoltShapeGetType(JoltShape shape);
+
+// Check if a shape is convex, looking through decorated shapes to the shape they wrap
+// Returns 1 if convex, 0 otherwise
+int JoltShapeIsConvex(JoltShape shape);
+
+// Get the number of vertices of a convex hull shape
+// Returns 0 if the shape is not a convex hull
+int JoltConvexHullShapeGetNumPoints(JoltShape shape);
+
+// Get the vertices of a convex hull shape in local space
+// outPoints: array of maxPoints * 3 floats (allocated by caller)
+// Returns: actual number of points written (0 if the shape is not a convex hull)
+int JoltConvexHullShapeGetPoints(JoltShape shape, float* outPoints, int maxPoints);
+
+// Create an empty mutable compound shape (destroy with JoltDestroyShape)
+// Its center of mass stays at the origin as sub-shapes are added and removed
+JoltShape JoltCreateMutableCompoundShape();
+
+// Add a sub-shape to a mutable compound shape (adds a reference to subShape)
+// Returns: the index of the new sub-shape
+int JoltMutableCompoundShapeAddShape(JoltShape compound, JoltShape subShape,
+                                     float posX, float posY, float posZ,
+                                     float rotX, float rotY, float rotZ, float rotW);
+
+// Remove a sub-shape from a mutable compound shape, later sub-shapes shift down one index
+// Returns 1 on success, 0 if index is out of range
+int JoltMutableCompoundShapeRemoveShape(JoltShape compound, int index);
+
+// Change the position and rotation of a sub-shape of a mutable compound shape
+// Returns 1 on success, 0 if index is out of range
+int JoltMutableCompoundShapeModifyShape(JoltShape compound, int index,
+                                        float posX, float posY, float posZ,
+                                        float rotX, float rotY, float rotZ, float rotW);
+
+// Get the number of sub-shapes of a mutable compound shape
+int JoltMutableCompoundShapeGetNumShapes(JoltShape compound);
 
 #ifdef __cplusplus
 }
+
+// C++ only: Resolves the material of a contact (used by character.cpp)
+namespace JPH {
+    class PhysicsMaterial;
+}
+
+// Get the index of a material assigned by JoltCreateMeshWithMaterials, 0 for any other material
+int GetMaterialIndex(const JPH::PhysicsMaterial* material);
+
 #endif
 
 #endif // JOLT_WRAPPER_SHAPE_H
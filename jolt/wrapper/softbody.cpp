@@ -0,0 +1,160 @@
+/*
+ * Jolt Physics C Wrapper - Soft Body Implementation
+ */
+
+#include "softbody.h"
+#include "physics.h"
+#include "layers.h"
+#include <Jolt/Jolt.h>
+#include <Jolt/Physics/PhysicsSystem.h>
+#include <Jolt/Physics/Body/BodyInterface.h>
+#include <Jolt/Physics/Body/BodyLock.h>
+#include <Jolt/Physics/SoftBody/SoftBodySharedSettings.h>
+#include <Jolt/Physics/SoftBody/SoftBodyCreationSettings.h>
+#include <Jolt/Physics/SoftBody/SoftBodyMotionProperties.h>
+
+using namespace JPH;
+
+JoltBodyID JoltCreateClothSoftBody(JoltPhysicsSystem system,
+                                   const float* vertices, int numVertices,
+                                   const int* indices, int numIndices,
+                                   float x, float y, float z)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+
+	// Out of range indices would make Jolt read past the vertices
+	if (numVertices <= 0 || numIndices < 3 || numIndices % 3 != 0)
+	{
+		return nullptr;
+	}
+	for (int i = 0; i < numIndices; ++i)
+	{
+		if (indices[i] < 0 || indices[i] >= numVertices)
+		{
+			return nullptr;
+		}
+	}
+
+	Ref<SoftBodySharedSettings> shared_settings = new SoftBodySharedSettings;
+
+	shared_settings->mVertices.reserve(numVertices);
+	for (int i = 0; i < numVertices; ++i)
+	{
+		SoftBodySharedSettings::Vertex vertex;
+		vertex.mPosition = Float3(vertices[i * 3], vertices[i * 3 + 1], vertices[i * 3 + 2]);
+		vertex.mInvMass = 1.0f;
+		shared_settings->mVertices.push_back(vertex);
+	}
+
+	for (int i = 0; i + 2 < numIndices; i += 3)
+	{
+		shared_settings->AddFace(SoftBodySharedSettings::Face(indices[i], indices[i + 1], indices[i + 2]));
+	}
+
+	// Stiff edges and shear, no bending resistance so the cloth drapes freely
+	SoftBodySharedSettings::VertexAttributes attributes(1.0e-5f, 1.0e-5f, 1.0e-5f);
+	shared_settings->CreateConstraints(&attributes, 1, SoftBodySharedSettings::EBendType::None);
+	shared_settings->Optimize();
+
+	SoftBodyCreationSettings creation_settings(shared_settings, RVec3(x, y, z), Quat::sIdentity(), Layers::MOVING);
+
+	BodyInterface& bi = ps->GetBodyInterface();
+	Body* body = bi.CreateSoftBody(creation_settings);
+	if (!body)
+	{
+		return nullptr;
+	}
+
+	bi.AddBody(body->GetID(), EActivation::Activate);
+
+	BodyID* bodyID = new BodyID(body->GetID());
+	return static_cast<JoltBodyID>(bodyID);
+}
+
+void JoltDestroySoftBody(JoltPhysicsSystem system, JoltBodyID bodyID)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	BodyID* bid = static_cast<BodyID*>(bodyID);
+	if (!bid) return;
+
+	BodyInterface& bi = ps->GetBodyInterface();
+	bi.RemoveBody(*bid);
+	bi.DestroyBody(*bid);
+	delete bid;
+}
+
+int JoltSoftBodyGetNumVertices(JoltPhysicsSystem system, JoltBodyID bodyID)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID* bid = static_cast<const BodyID*>(bodyID);
+
+	BodyLockRead lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || !lock.GetBody().IsSoftBody())
+	{
+		return 0;
+	}
+
+	const SoftBodyMotionProperties* mp = static_cast<const SoftBodyMotionProperties*>(lock.GetBody().GetMotionProperties());
+	return static_cast<int>(mp->GetVertices().size());
+}
+
+int JoltSoftBodyGetVertexPositions(JoltPhysicsSystem system, JoltBodyID bodyID,
+                                   float* outPositions, int maxVertices)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID* bid = static_cast<const BodyID*>(bodyID);
+
+	BodyLockRead lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || !lock.GetBody().IsSoftBody())
+	{
+		return 0;
+	}
+
+	const Body& body = lock.GetBody();
+	const SoftBodyMotionProperties* mp = static_cast<const SoftBodyMotionProperties*>(body.GetMotionProperties());
+
+	// Vertex positions are stored relative to the center of mass
+	RMat44 com = body.GetCenterOfMassTransform();
+	int count = 0;
+	for (const SoftBodyVertex& vertex : mp->GetVertices())
+	{
+		if (count >= maxVertices)
+			break;
+
+		RVec3 position = com * vertex.mPosition;
+		outPositions[count * 3] = static_cast<float>(position.GetX());
+		outPositions[count * 3 + 1] = static_cast<float>(position.GetY());
+		outPositions[count * 3 + 2] = static_cast<float>(position.GetZ());
+		count++;
+	}
+
+	return count;
+}
+
+void JoltSoftBodyPinVertex(JoltPhysicsSystem system, JoltBodyID bodyID, int index)
+{
+	PhysicsSystemWrapper* wrapper = static_cast<PhysicsSystemWrapper*>(system);
+	PhysicsSystem* ps = GetPhysicsSystem(wrapper);
+	const BodyID* bid = static_cast<const BodyID*>(bodyID);
+
+	BodyLockWrite lock(ps->GetBodyLockInterface(), *bid);
+	if (!lock.Succeeded() || !lock.GetBody().IsSoftBody())
+	{
+		return;
+	}
+
+	SoftBodyMotionProperties* mp = static_cast<SoftBodyMotionProperties*>(lock.GetBody().GetMotionProperties());
+	if (index < 0 || index >= static_cast<int>(mp->GetVertices().size()))
+	{
+		return;
+	}
+
+	// Infinite mass keeps the vertex where it is
+	SoftBodyVertex& vertex = mp->GetVertex(index);
+	vertex.mInvMass = 0.0f;
+	vertex.mVelocity = Vec3::sZero();
+}
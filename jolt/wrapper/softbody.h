@@ -0,0 +1,46 @@
+/*
+ * Jolt Physics C Wrapper - Soft Bodies
+ *
+ * Handles creation and readback of soft bodies (cloth).
+ */
+
+#ifndef JOLT_WRAPPER_SOFTBODY_H
+#define JOLT_WRAPPER_SOFTBODY_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque pointer types (defined in other headers)
+typedef void* JoltPhysicsSystem;
+typedef void* JoltBodyID;
+
+// Create a cloth soft body from a triangle mesh and add it to the world (active)
+// vertices: array of numVertices * 3 floats, relative to the position
+// indices: array of numIndices ints, 3 per triangle, each in [0, numVertices)
+// Returns: the body ID of the soft body (free with JoltDestroySoftBody), or NULL on failure or invalid indices
+JoltBodyID JoltCreateClothSoftBody(JoltPhysicsSystem system,
+                                   const float* vertices, int numVertices,
+                                   const int* indices, int numIndices,
+                                   float x, float y, float z);
+
+// Remove a soft body from the world, destroy it and free its body ID
+void JoltDestroySoftBody(JoltPhysicsSystem system, JoltBodyID bodyID);
+
+// Get the number of vertices of a soft body
+int JoltSoftBodyGetNumVertices(JoltPhysicsSystem system, JoltBodyID bodyID);
+
+// Get the world space positions of the vertices of a soft body
+// outPositions: array of maxVertices * 3 floats (allocated by caller)
+// Returns: actual number of vertices written
+int JoltSoftBodyGetVertexPositions(JoltPhysicsSystem system, JoltBodyID bodyID,
+                                   float* outPositions, int maxVertices);
+
+// Pin a vertex of a soft body in place (gives it infinite mass)
+void JoltSoftBodyPinVertex(JoltPhysicsSystem system, JoltBodyID bodyID, int index);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // JOLT_WRAPPER_SOFTBODY_H